@@ -33,16 +33,27 @@ type serviceInfoRepresentation struct {
 	ServerStatus map[string]string `json:"serverStatus,omitempty"`
 }
 
+type tcpServiceInfoRepresentation struct {
+	*runtime.TCPServiceInfo
+	ServerStatus map[string]string `json:"serverStatus,omitempty"`
+}
+
+type udpServiceInfoRepresentation struct {
+	*runtime.UDPServiceInfo
+	ServerStatus map[string]string `json:"serverStatus,omitempty"`
+}
+
 // RunTimeRepresentation is the configuration information exposed by the API handler.
 type RunTimeRepresentation struct {
-	Routers        map[string]*runtime.RouterInfo        `json:"routers,omitempty"`
-	Middlewares    map[string]*runtime.MiddlewareInfo    `json:"middlewares,omitempty"`
-	Services       map[string]*serviceInfoRepresentation `json:"services,omitempty"`
-	TCPRouters     map[string]*runtime.TCPRouterInfo     `json:"tcpRouters,omitempty"`
-	TCPMiddlewares map[string]*runtime.TCPMiddlewareInfo `json:"tcpMiddlewares,omitempty"`
-	TCPServices    map[string]*runtime.TCPServiceInfo    `json:"tcpServices,omitempty"`
-	UDPRouters     map[string]*runtime.UDPRouterInfo     `json:"udpRouters,omitempty"`
-	UDPServices    map[string]*runtime.UDPServiceInfo    `json:"udpServices,omitempty"`
+	Routers        map[string]*runtime.RouterInfo           `json:"routers,omitempty"`
+	Middlewares    map[string]*runtime.MiddlewareInfo       `json:"middlewares,omitempty"`
+	Services       map[string]*serviceInfoRepresentation    `json:"services,omitempty"`
+	TCPRouters     map[string]*runtime.TCPRouterInfo        `json:"tcpRouters,omitempty"`
+	TCPMiddlewares map[string]*runtime.TCPMiddlewareInfo    `json:"tcpMiddlewares,omitempty"`
+	TCPServices    map[string]*tcpServiceInfoRepresentation `json:"tcpServices,omitempty"`
+	UDPRouters     map[string]*runtime.UDPRouterInfo        `json:"udpRouters,omitempty"`
+	UDPMiddlewares map[string]*runtime.UDPMiddlewareInfo    `json:"udpMiddlewares,omitempty"`
+	UDPServices    map[string]*udpServiceInfoRepresentation `json:"udpServices,omitempty"`
 }
 
 // Handler serves the configuration and status of Traefik on API endpoints.
@@ -51,12 +62,18 @@ type Handler struct {
 
 	// runtimeConfiguration is the data set used to create all the data representations exposed by the API.
 	runtimeConfiguration *runtime.Configuration
+
+	// routingInspector evaluates the requests and connections against the routing of the entry points, for the match endpoints.
+	routingInspector RoutingInspector
 }
 
 // NewBuilder returns a http.Handler builder based on runtime.Configuration.
-func NewBuilder(staticConfig static.Configuration) func(*runtime.Configuration) http.Handler {
+// The match endpoints are only served if a routingInspector is given.
+func NewBuilder(staticConfig static.Configuration, routingInspector RoutingInspector) func(*runtime.Configuration) http.Handler {
 	return func(configuration *runtime.Configuration) http.Handler {
-		return New(staticConfig, configuration).createRouter()
+		handler := New(staticConfig, configuration)
+		handler.routingInspector = routingInspector
+		return handler.createRouter()
 	}
 }
 
@@ -108,6 +125,13 @@ func (h Handler) createRouter() *mux.Router {
 	router.Methods(http.MethodGet).Path("/api/udp/routers/{routerID}").HandlerFunc(h.getUDPRouter)
 	router.Methods(http.MethodGet).Path("/api/udp/services").HandlerFunc(h.getUDPServices)
 	router.Methods(http.MethodGet).Path("/api/udp/services/{serviceID}").HandlerFunc(h.getUDPService)
+	router.Methods(http.MethodGet).Path("/api/udp/middlewares").HandlerFunc(h.getUDPMiddlewares)
+	router.Methods(http.MethodGet).Path("/api/udp/middlewares/{middlewareID}").HandlerFunc(h.getUDPMiddleware)
+
+	if h.routingInspector != nil {
+		router.Methods(http.MethodPost).Path("/api/http/match").HandlerFunc(h.matchHTTP)
+		router.Methods(http.MethodPost).Path("/api/tcp/match").HandlerFunc(h.matchTCP)
+	}
 
 	version.Handler{}.Append(router)
 
@@ -123,15 +147,32 @@ func (h Handler) getRuntimeConfiguration(rw http.ResponseWriter, request *http.R
 		}
 	}
 
+	tcpSiRepr := make(map[string]*tcpServiceInfoRepresentation, len(h.runtimeConfiguration.TCPServices))
+	for k, v := range h.runtimeConfiguration.TCPServices {
+		tcpSiRepr[k] = &tcpServiceInfoRepresentation{
+			TCPServiceInfo: v,
+			ServerStatus:   v.GetAllStatus(),
+		}
+	}
+
+	udpSiRepr := make(map[string]*udpServiceInfoRepresentation, len(h.runtimeConfiguration.UDPServices))
+	for k, v := range h.runtimeConfiguration.UDPServices {
+		udpSiRepr[k] = &udpServiceInfoRepresentation{
+			UDPServiceInfo: v,
+			ServerStatus:   v.GetAllStatus(),
+		}
+	}
+
 	result := RunTimeRepresentation{
 		Routers:        h.runtimeConfiguration.Routers,
 		Middlewares:    h.runtimeConfiguration.Middlewares,
 		Services:       siRepr,
 		TCPRouters:     h.runtimeConfiguration.TCPRouters,
 		TCPMiddlewares: h.runtimeConfiguration.TCPMiddlewares,
-		TCPServices:    h.runtimeConfiguration.TCPServices,
+		TCPServices:    tcpSiRepr,
 		UDPRouters:     h.runtimeConfiguration.UDPRouters,
-		UDPServices:    h.runtimeConfiguration.UDPServices,
+		UDPMiddlewares: h.runtimeConfiguration.UDPMiddlewares,
+		UDPServices:    udpSiRepr,
 	}
 
 	rw.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	httpmuxer "github.com/traefik/traefik/v2/pkg/muxer/http"
+	tcpmuxer "github.com/traefik/traefik/v2/pkg/muxer/tcp"
+	"github.com/traefik/traefik/v2/pkg/rules"
+)
+
+// matchRequest describes the synthetic request, or connection, to route.
+type matchRequest struct {
+	EntryPoint string            `json:"entryPoint"`
+	Method     string            `json:"method,omitempty"`
+	Host       string            `json:"host,omitempty"`
+	Path       string            `json:"path,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	ClientIP   string            `json:"clientIP,omitempty"`
+	SNI        string            `json:"sni,omitempty"`
+	ALPN       []string          `json:"alpn,omitempty"`
+	TLS        bool              `json:"tls,omitempty"`
+}
+
+type matchRepresentation struct {
+	EntryPoint string                    `json:"entryPoint"`
+	TLS        bool                      `json:"tls"`
+	Router     string                    `json:"router,omitempty"`
+	Candidates []candidateRepresentation `json:"candidates"`
+}
+
+type candidateRepresentation struct {
+	Name     string `json:"name"`
+	Rule     string `json:"rule"`
+	Priority int    `json:"priority"`
+	Matched  bool   `json:"matched"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// RoutingInspector gives access to the routing of the entry points.
+type RoutingInspector interface {
+	// InspectHTTP passes the request through the handlers applied before routing on the given entry point,
+	// and calls inspect with the resulting request, and with the muxer routing it, if any.
+	InspectHTTP(entryPointName string, tls bool, req *http.Request, inspect func(*http.Request, *httpmuxer.Muxer)) error
+	// TCPMuxer returns the muxer routing the connections, without or with TLS, on the given entry point.
+	TCPMuxer(entryPointName string, tls bool) (*tcpmuxer.Muxer, error)
+}
+
+func (h Handler) matchHTTP(rw http.ResponseWriter, request *http.Request) {
+	matchReq, ok := h.decodeMatchRequest(rw, request)
+	if !ok {
+		return
+	}
+
+	tlsRouters := matchReq.TLS || matchReq.SNI != ""
+
+	req, err := newMatchHTTPRequest(matchReq, tlsRouters)
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := matchRepresentation{
+		EntryPoint: matchReq.EntryPoint,
+		TLS:        tlsRouters,
+		Candidates: []candidateRepresentation{},
+	}
+
+	// The request goes through the handlers applied before routing on the entry point,
+	// e.g. the forwarded headers and the JWT validation, before being evaluated by the muxer of the entry point.
+	err = h.routingInspector.InspectHTTP(matchReq.EntryPoint, tlsRouters, req, func(req *http.Request, muxer *httpmuxer.Muxer) {
+		if muxer == nil {
+			return
+		}
+
+		for _, res := range muxer.MatchAll(req) {
+			var rule string
+			if rt, ok := h.runtimeConfiguration.Routers[res.Name]; ok {
+				rule = rt.Rule
+			}
+
+			result.addCandidate(res.Name, rule, res.Priority, res.Matched, res.FailedMatchers)
+		}
+	})
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeMatchResult(rw, request, result)
+}
+
+func (h Handler) matchTCP(rw http.ResponseWriter, request *http.Request) {
+	matchReq, ok := h.decodeMatchRequest(rw, request)
+	if !ok {
+		return
+	}
+
+	tlsRouters := matchReq.TLS || matchReq.SNI != ""
+
+	muxer, err := h.routingInspector.TCPMuxer(matchReq.EntryPoint, tlsRouters)
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := matchRepresentation{
+		EntryPoint: matchReq.EntryPoint,
+		TLS:        tlsRouters,
+		Candidates: []candidateRepresentation{},
+	}
+
+	if muxer != nil {
+		meta := tcpmuxer.NewSyntheticConnData(matchReq.SNI, matchReq.ClientIP, matchReq.ALPN)
+
+		for _, res := range muxer.MatchAll(meta) {
+			var rule string
+			if rt, ok := h.runtimeConfiguration.TCPRouters[res.Name]; ok {
+				rule = rt.Rule
+			}
+
+			result.addCandidate(res.Name, rule, res.Priority, res.Matched, res.FailedMatchers)
+		}
+	}
+
+	writeMatchResult(rw, request, result)
+}
+
+// addCandidate adds a router evaluated by the muxer, in evaluation order.
+func (r *matchRepresentation) addCandidate(name, rule string, priority int, matched bool, failedMatchers []*rules.Tree) {
+	candidate := candidateRepresentation{
+		Name:     name,
+		Rule:     rule,
+		Priority: priority,
+		Matched:  matched,
+	}
+
+	switch {
+	case matched && r.Router == "":
+		r.Router = name
+	case matched:
+		candidate.Reason = fmt.Sprintf("router %s matched first", r.Router)
+	default:
+		candidate.Reason = mismatchReason(failedMatchers)
+	}
+
+	r.Candidates = append(r.Candidates, candidate)
+}
+
+func (h Handler) decodeMatchRequest(rw http.ResponseWriter, request *http.Request) (matchRequest, bool) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	var matchReq matchRequest
+	if err := json.NewDecoder(request.Body).Decode(&matchReq); err != nil {
+		writeError(rw, fmt.Sprintf("invalid match request: %v", err), http.StatusBadRequest)
+		return matchRequest{}, false
+	}
+
+	if _, ok := h.staticConfig.EntryPoints[matchReq.EntryPoint]; !ok {
+		writeError(rw, fmt.Sprintf("entry point not found: %s", matchReq.EntryPoint), http.StatusNotFound)
+		return matchRequest{}, false
+	}
+
+	return matchReq, true
+}
+
+func writeMatchResult(rw http.ResponseWriter, request *http.Request, result matchRepresentation) {
+	err := json.NewEncoder(rw).Encode(result)
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func newMatchHTTPRequest(matchReq matchRequest, tls bool) (*http.Request, error) {
+	scheme := "http"
+	if tls {
+		scheme = "https"
+	}
+
+	path := matchReq.Path
+	if path == "" {
+		path = "/"
+	}
+
+	method := matchReq.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", matchReq.Path, err)
+	}
+	u.Scheme = scheme
+	u.Host = matchReq.Host
+
+	req, err := http.NewRequest(method, u.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range matchReq.Headers {
+		req.Header.Set(k, v)
+	}
+
+	req.RemoteAddr = matchReq.ClientIP
+
+	return req, nil
+}
+
+func mismatchReason(failedMatchers []*rules.Tree) string {
+	if len(failedMatchers) == 0 {
+		return "rule did not match"
+	}
+
+	failed := make([]string, 0, len(failedMatchers))
+	for _, matcher := range failedMatchers {
+		failed = append(failed, matcherString(matcher))
+	}
+
+	sort.Strings(failed)
+
+	return "rule did not match, failed matchers: " + strings.Join(failed, ", ")
+}
+
+// matcherString returns the rule of the given matcher.
+func matcherString(matcher *rules.Tree) string {
+	values := make([]string, 0, len(matcher.Value))
+	for _, v := range matcher.Value {
+		values = append(values, "`"+v+"`")
+	}
+
+	var not string
+	if matcher.Not {
+		not = "!"
+	}
+
+	return not + matcher.Matcher + "(" + strings.Join(values, ", ") + ")"
+}
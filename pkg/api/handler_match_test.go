@@ -0,0 +1,351 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
+	httpmuxer "github.com/traefik/traefik/v2/pkg/muxer/http"
+	tcpmuxer "github.com/traefik/traefik/v2/pkg/muxer/tcp"
+	"github.com/traefik/traefik/v2/pkg/tcp"
+)
+
+func TestHandler_Match(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		path               string
+		body               string
+		expectedStatusCode int
+		expectedRouter     string
+		expectedCandidates []candidateRepresentation
+	}{
+		{
+			desc:               "invalid body",
+			path:               "/api/http/match",
+			body:               "{",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "unknown entry point",
+			path:               "/api/http/match",
+			body:               `{"entryPoint": "unknown"}`,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "HTTP request matching several routers",
+			path:               "/api/http/match",
+			body:               `{"entryPoint": "web", "host": "foo.bar", "path": "/api/users"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedRouter:     "api@myprovider",
+			expectedCandidates: []candidateRepresentation{
+				{
+					Name:     "api@myprovider",
+					Rule:     "Host(`foo.bar`) && PathPrefix(`/api`)",
+					Priority: 100,
+					Matched:  true,
+				},
+				{
+					Name:     "other@myprovider",
+					Rule:     "Host(`other.bar`)",
+					Priority: 17,
+					Reason:   "rule did not match, failed matchers: Host(`other.bar`)",
+				},
+				{
+					Name:     "foo@myprovider",
+					Rule:     "Host(`foo.bar`)",
+					Priority: 15,
+					Matched:  true,
+					Reason:   "router api@myprovider matched first",
+				},
+			},
+		},
+		{
+			desc:               "HTTP request matching no router",
+			path:               "/api/http/match",
+			body:               `{"entryPoint": "web", "host": "baz.bar", "path": "/web", "method": "POST"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedCandidates: []candidateRepresentation{
+				{
+					Name:     "api@myprovider",
+					Rule:     "Host(`foo.bar`) && PathPrefix(`/api`)",
+					Priority: 100,
+					Reason:   "rule did not match, failed matchers: Host(`foo.bar`), PathPrefix(`/api`)",
+				},
+				{
+					Name:     "other@myprovider",
+					Rule:     "Host(`other.bar`)",
+					Priority: 17,
+					Reason:   "rule did not match, failed matchers: Host(`other.bar`)",
+				},
+				{
+					Name:     "foo@myprovider",
+					Rule:     "Host(`foo.bar`)",
+					Priority: 15,
+					Reason:   "rule did not match, failed matchers: Host(`foo.bar`)",
+				},
+			},
+		},
+		{
+			desc:               "HTTP request decorated before routing",
+			path:               "/api/http/match",
+			body:               `{"entryPoint": "private", "host": "admin.bar", "headers": {"Authorization": "Bearer admin"}}`,
+			expectedStatusCode: http.StatusOK,
+			expectedRouter:     "admin@myprovider",
+			expectedCandidates: []candidateRepresentation{
+				{
+					Name:     "admin@myprovider",
+					Rule:     "Host(`admin.bar`) && JWTClaim(`group`, `admin`)",
+					Priority: 10,
+					Matched:  true,
+				},
+			},
+		},
+		{
+			desc:               "HTTP request without the claims of the decorator",
+			path:               "/api/http/match",
+			body:               `{"entryPoint": "private", "host": "admin.bar"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedCandidates: []candidateRepresentation{
+				{
+					Name:     "admin@myprovider",
+					Rule:     "Host(`admin.bar`) && JWTClaim(`group`, `admin`)",
+					Priority: 10,
+					Reason:   "rule did not match, failed matchers: JWTClaim(`group`, `admin`)",
+				},
+			},
+		},
+		{
+			desc:               "UDP entry point",
+			path:               "/api/tcp/match",
+			body:               `{"entryPoint": "dns"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "HTTPS request",
+			path:               "/api/http/match",
+			body:               `{"entryPoint": "web", "host": "foo.bar", "sni": "foo.bar"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedRouter:     "secure@myprovider",
+			expectedCandidates: []candidateRepresentation{
+				{
+					Name:     "secure@myprovider",
+					Rule:     "Host(`foo.bar`)",
+					Priority: 15,
+					Matched:  true,
+				},
+			},
+		},
+		{
+			desc:               "TCP connection",
+			path:               "/api/tcp/match",
+			body:               `{"entryPoint": "web", "sni": "foo.bar", "clientIP": "10.0.0.1"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedRouter:     "tcpfoo@myprovider",
+			expectedCandidates: []candidateRepresentation{
+				{
+					Name:     "tcpip@myprovider",
+					Rule:     "HostSNI(`foo.bar`) && ClientIP(`192.168.0.0/16`)",
+					Priority: 50,
+					Reason:   "rule did not match, failed matchers: ClientIP(`192.168.0.0/16`)",
+				},
+				{
+					Name:     "tcpfoo@myprovider",
+					Rule:     "HostSNI(`foo.bar`)",
+					Priority: 18,
+					Matched:  true,
+				},
+				{
+					Name:     "tcpall@myprovider",
+					Rule:     "HostSNI(`*`)",
+					Priority: -1,
+					Matched:  true,
+					Reason:   "router tcpfoo@myprovider matched first",
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rtConf := runtime.NewConfig(dynamic.Configuration{
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{
+						"foo@myprovider": {
+							EntryPoints: []string{"web"},
+							Rule:        "Host(`foo.bar`)",
+						},
+						"api@myprovider": {
+							EntryPoints: []string{"web"},
+							Rule:        "Host(`foo.bar`) && PathPrefix(`/api`)",
+							Priority:    100,
+						},
+						"other@myprovider": {
+							EntryPoints: []string{"web"},
+							Rule:        "Host(`other.bar`)",
+						},
+						"admin@myprovider": {
+							EntryPoints: []string{"private"},
+							Rule:        "Host(`admin.bar`) && JWTClaim(`group`, `admin`)",
+							Priority:    10,
+						},
+						"secure@myprovider": {
+							EntryPoints: []string{"web"},
+							Rule:        "Host(`foo.bar`)",
+							TLS:         &dynamic.RouterTLSConfig{},
+						},
+					},
+				},
+				TCP: &dynamic.TCPConfiguration{
+					Routers: map[string]*dynamic.TCPRouter{
+						"tcpfoo@myprovider": {
+							EntryPoints: []string{"web"},
+							Rule:        "HostSNI(`foo.bar`)",
+							TLS:         &dynamic.RouterTCPTLSConfig{},
+						},
+						"tcpip@myprovider": {
+							EntryPoints: []string{"web"},
+							Rule:        "HostSNI(`foo.bar`) && ClientIP(`192.168.0.0/16`)",
+							Priority:    50,
+							TLS:         &dynamic.RouterTCPTLSConfig{},
+						},
+						"tcpall@myprovider": {
+							EntryPoints: []string{"web"},
+							Rule:        "HostSNI(`*`)",
+							TLS:         &dynamic.RouterTCPTLSConfig{},
+						},
+					},
+				},
+			})
+			staticConf := static.Configuration{
+				API:    &static.API{},
+				Global: &static.Global{},
+				EntryPoints: static.EntryPoints{
+					"web":     &static.EntryPoint{},
+					"private": &static.EntryPoint{},
+					"dns":     &static.EntryPoint{Address: ":53/udp"},
+				},
+			}
+			handler := NewBuilder(staticConf, newRoutingInspectorMock(t, rtConf, "web", "private"))(rtConf)
+			server := httptest.NewServer(handler)
+
+			resp, err := http.DefaultClient.Post(server.URL+test.path, "application/json", bytes.NewBufferString(test.body))
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			require.Equal(t, test.expectedStatusCode, resp.StatusCode)
+
+			if test.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var result matchRepresentation
+			err = json.NewDecoder(resp.Body).Decode(&result)
+			require.NoError(t, err)
+
+			var matchReq matchRequest
+			err = json.Unmarshal([]byte(test.body), &matchReq)
+			require.NoError(t, err)
+
+			assert.Equal(t, matchReq.EntryPoint, result.EntryPoint)
+			assert.Equal(t, test.expectedRouter, result.Router)
+			assert.Equal(t, test.expectedCandidates, result.Candidates)
+		})
+	}
+}
+
+// routingInspectorMock routes with muxers built from the runtime configuration,
+// and decorates the requests before routing them as the entry points do,
+// with the claims of their bearer token, which is the value of the group claim.
+type routingInspectorMock struct {
+	httpMuxers map[string]*httpmuxer.Muxer
+	tcpMuxers  map[string]*tcpmuxer.Muxer
+}
+
+func newRoutingInspectorMock(t *testing.T, rtConf *runtime.Configuration, entryPoints ...string) routingInspectorMock {
+	t.Helper()
+
+	ctx := context.Background()
+
+	inspector := routingInspectorMock{
+		httpMuxers: make(map[string]*httpmuxer.Muxer),
+		tcpMuxers:  make(map[string]*tcpmuxer.Muxer),
+	}
+
+	for _, tls := range []bool{false, true} {
+		for entryPoint, routers := range rtConf.GetRoutersByEntryPoints(ctx, entryPoints, tls) {
+			muxer, err := httpmuxer.NewMuxer()
+			require.NoError(t, err)
+
+			for name, rt := range routers {
+				err = muxer.AddNamedRoute(name, rt.Rule, rt.Priority, http.NotFoundHandler())
+				require.NoError(t, err)
+			}
+
+			muxer.SortRoutes()
+			inspector.httpMuxers[muxerKey(entryPoint, tls)] = muxer
+		}
+	}
+
+	for entryPoint, routers := range rtConf.GetTCPRoutersByEntryPoints(ctx, entryPoints) {
+		for name, rt := range routers {
+			tls := rt.TLS != nil
+
+			muxer, ok := inspector.tcpMuxers[muxerKey(entryPoint, tls)]
+			if !ok {
+				var err error
+				muxer, err = tcpmuxer.NewMuxer()
+				require.NoError(t, err)
+
+				inspector.tcpMuxers[muxerKey(entryPoint, tls)] = muxer
+			}
+
+			err := muxer.AddNamedRoute(name, rt.Rule, rt.Priority, tcp.HandlerFunc(func(tcp.WriteCloser) {}))
+			require.NoError(t, err)
+		}
+	}
+
+	return inspector
+}
+
+func (r routingInspectorMock) InspectHTTP(entryPointName string, tls bool, req *http.Request, inspect func(*http.Request, *httpmuxer.Muxer)) error {
+	if entryPointName == "dns" {
+		return errors.New("dns is not a TCP entry point")
+	}
+
+	if group := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "); group != "" {
+		req = req.WithContext(requestdecorator.WithJWTClaims(req.Context(), map[string]interface{}{"group": group}))
+	}
+
+	requestdecorator.New(nil).ServeHTTP(httptest.NewRecorder(), req, func(_ http.ResponseWriter, req *http.Request) {
+		inspect(req, r.httpMuxers[muxerKey(entryPointName, tls)])
+	})
+
+	return nil
+}
+
+func (r routingInspectorMock) TCPMuxer(entryPointName string, tls bool) (*tcpmuxer.Muxer, error) {
+	if entryPointName == "dns" {
+		return nil, errors.New("dns is not a TCP entry point")
+	}
+
+	return r.tcpMuxers[muxerKey(entryPointName, tls)], nil
+}
+
+func muxerKey(entryPoint string, tls bool) string {
+	return fmt.Sprintf("%s-%t", entryPoint, tls)
+}
@@ -51,8 +51,9 @@ func (h Handler) getOverview(rw http.ResponseWriter, request *http.Request) {
 			Middlewares: getTCPMiddlewareSection(h.runtimeConfiguration.TCPMiddlewares),
 		},
 		UDP: schemeOverview{
-			Routers:  getUDPRouterSection(h.runtimeConfiguration.UDPRouters),
-			Services: getUDPServiceSection(h.runtimeConfiguration.UDPServices),
+			Routers:     getUDPRouterSection(h.runtimeConfiguration.UDPRouters),
+			Services:    getUDPServiceSection(h.runtimeConfiguration.UDPServices),
+			Middlewares: getUDPMiddlewareSection(h.runtimeConfiguration.UDPMiddlewares),
 		},
 		Features:  getFeatures(h.staticConfig),
 		Providers: getProviders(h.staticConfig),
@@ -219,6 +220,25 @@ func getUDPServiceSection(services map[string]*runtime.UDPServiceInfo) *section
 	}
 }
 
+func getUDPMiddlewareSection(middlewares map[string]*runtime.UDPMiddlewareInfo) *section {
+	var countErrors int
+	var countWarnings int
+	for _, mid := range middlewares {
+		switch mid.Status {
+		case runtime.StatusDisabled:
+			countErrors++
+		case runtime.StatusWarning:
+			countWarnings++
+		}
+	}
+
+	return &section{
+		Total:    len(middlewares),
+		Warnings: countWarnings,
+		Errors:   countErrors,
+	}
+}
+
 func getProviders(conf static.Configuration) []string {
 	if conf.Providers == nil {
 		return nil
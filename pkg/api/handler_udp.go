@@ -29,20 +29,38 @@ func newUDPRouterRepresentation(name string, rt *runtime.UDPRouterInfo) udpRoute
 
 type udpServiceRepresentation struct {
 	*runtime.UDPServiceInfo
-	Name     string `json:"name,omitempty"`
-	Provider string `json:"provider,omitempty"`
-	Type     string `json:"type,omitempty"`
+	ServerStatus map[string]string `json:"serverStatus,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	Provider     string            `json:"provider,omitempty"`
+	Type         string            `json:"type,omitempty"`
 }
 
 func newUDPServiceRepresentation(name string, si *runtime.UDPServiceInfo) udpServiceRepresentation {
 	return udpServiceRepresentation{
 		UDPServiceInfo: si,
+		ServerStatus:   si.GetAllStatus(),
 		Name:           name,
 		Provider:       getProviderName(name),
 		Type:           strings.ToLower(extractType(si.UDPService)),
 	}
 }
 
+type udpMiddlewareRepresentation struct {
+	*runtime.UDPMiddlewareInfo
+	Name     string `json:"name,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+func newUDPMiddlewareRepresentation(name string, mi *runtime.UDPMiddlewareInfo) udpMiddlewareRepresentation {
+	return udpMiddlewareRepresentation{
+		UDPMiddlewareInfo: mi,
+		Name:              name,
+		Provider:          getProviderName(name),
+		Type:              strings.ToLower(extractType(mi.UDPMiddleware)),
+	}
+}
+
 func (h Handler) getUDPRouters(rw http.ResponseWriter, request *http.Request) {
 	results := make([]udpRouterRepresentation, 0, len(h.runtimeConfiguration.UDPRouters))
 
@@ -147,12 +165,64 @@ func (h Handler) getUDPService(rw http.ResponseWriter, request *http.Request) {
 	}
 }
 
+func (h Handler) getUDPMiddlewares(rw http.ResponseWriter, request *http.Request) {
+	results := make([]udpMiddlewareRepresentation, 0, len(h.runtimeConfiguration.UDPMiddlewares))
+
+	criterion := newSearchCriterion(request.URL.Query())
+
+	for name, mi := range h.runtimeConfiguration.UDPMiddlewares {
+		if keepUDPMiddleware(name, mi, criterion) {
+			results = append(results, newUDPMiddlewareRepresentation(name, mi))
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	pageInfo, err := pagination(request, len(results))
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rw.Header().Set(nextPageHeader, strconv.Itoa(pageInfo.nextPage))
+
+	err = json.NewEncoder(rw).Encode(results[pageInfo.startIndex:pageInfo.endIndex])
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h Handler) getUDPMiddleware(rw http.ResponseWriter, request *http.Request) {
+	middlewareID := mux.Vars(request)["middlewareID"]
+
+	rw.Header().Set("Content-Type", "application/json")
+
+	middleware, ok := h.runtimeConfiguration.UDPMiddlewares[middlewareID]
+	if !ok {
+		writeError(rw, fmt.Sprintf("middleware not found: %s", middlewareID), http.StatusNotFound)
+		return
+	}
+
+	result := newUDPMiddlewareRepresentation(middlewareID, middleware)
+
+	err := json.NewEncoder(rw).Encode(result)
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func keepUDPRouter(name string, item *runtime.UDPRouterInfo, criterion *searchCriterion) bool {
 	if criterion == nil {
 		return true
 	}
 
-	return criterion.withStatus(item.Status) && criterion.searchIn(name)
+	return criterion.withStatus(item.Status) && criterion.searchIn(item.Rule, name)
 }
 
 func keepUDPService(name string, item *runtime.UDPServiceInfo, criterion *searchCriterion) bool {
@@ -162,3 +232,11 @@ func keepUDPService(name string, item *runtime.UDPServiceInfo, criterion *search
 
 	return criterion.withStatus(item.Status) && criterion.searchIn(name)
 }
+
+func keepUDPMiddleware(name string, item *runtime.UDPMiddlewareInfo, criterion *searchCriterion) bool {
+	if criterion == nil {
+		return true
+	}
+
+	return criterion.withStatus(item.Status) && criterion.searchIn(name)
+}
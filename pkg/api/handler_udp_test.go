@@ -484,6 +484,124 @@ func TestHandler_UDP(t *testing.T) {
 				statusCode: http.StatusNotFound,
 			},
 		},
+		{
+			desc: "all udp middlewares",
+			path: "/api/udp/middlewares",
+			conf: runtime.Configuration{
+				UDPMiddlewares: map[string]*runtime.UDPMiddlewareInfo{
+					"ipallowlist@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							IPAllowList: &dynamic.UDPIPAllowList{
+								SourceRange: []string{"127.0.0.1/32"},
+							},
+						},
+						UsedBy: []string{"bar@myprovider", "test@myprovider"},
+					},
+					"ratelimit@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							RateLimit: &dynamic.UDPRateLimit{
+								Average: 100,
+								Burst:   10,
+							},
+						},
+						UsedBy: []string{"test@myprovider"},
+					},
+					"ipallowlist@anotherprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							IPAllowList: &dynamic.UDPIPAllowList{
+								SourceRange: []string{"127.0.0.1/32"},
+							},
+						},
+						UsedBy: []string{"bar@myprovider"},
+					},
+				},
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				nextPage:   "1",
+				jsonFile:   "testdata/udpmiddlewares.json",
+			},
+		},
+		{
+			desc: "udp middlewares filtered by status",
+			path: "/api/udp/middlewares?status=enabled",
+			conf: runtime.Configuration{
+				UDPMiddlewares: map[string]*runtime.UDPMiddlewareInfo{
+					"ipallowlist@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							IPAllowList: &dynamic.UDPIPAllowList{
+								SourceRange: []string{"127.0.0.1/32"},
+							},
+						},
+						UsedBy: []string{"bar@myprovider", "test@myprovider"},
+						Status: runtime.StatusEnabled,
+					},
+					"ratelimit@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							RateLimit: &dynamic.UDPRateLimit{
+								Average: 100,
+								Burst:   10,
+							},
+						},
+						UsedBy: []string{"test@myprovider"},
+						Status: runtime.StatusDisabled,
+					},
+				},
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				nextPage:   "1",
+				jsonFile:   "testdata/udpmiddlewares-filtered-status.json",
+			},
+		},
+		{
+			desc: "one udp middleware by id",
+			path: "/api/udp/middlewares/ratelimit@myprovider",
+			conf: runtime.Configuration{
+				UDPMiddlewares: map[string]*runtime.UDPMiddlewareInfo{
+					"ipallowlist@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							IPAllowList: &dynamic.UDPIPAllowList{
+								SourceRange: []string{"127.0.0.1/32"},
+							},
+						},
+						UsedBy: []string{"bar@myprovider"},
+					},
+					"ratelimit@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							RateLimit: &dynamic.UDPRateLimit{
+								Average: 100,
+								Burst:   10,
+							},
+						},
+						UsedBy: []string{"test@myprovider"},
+					},
+				},
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				jsonFile:   "testdata/udpmiddleware-ratelimit.json",
+			},
+		},
+		{
+			desc: "one udp middleware by id, that does not exist",
+			path: "/api/udp/middlewares/foo@myprovider",
+			conf: runtime.Configuration{
+				UDPMiddlewares: map[string]*runtime.UDPMiddlewareInfo{
+					"ipallowlist@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							IPAllowList: &dynamic.UDPIPAllowList{
+								SourceRange: []string{"127.0.0.1/32"},
+							},
+						},
+						UsedBy: []string{"bar@myprovider"},
+					},
+				},
+			},
+			expected: expected{
+				statusCode: http.StatusNotFound,
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -0,0 +1,43 @@
+package attempt
+
+import (
+	"context"
+	"sync"
+)
+
+type recordKey struct{}
+
+// Record records the servers to which the attempts of a retried request were forwarded,
+// so that the load balancers forward the retries to other servers.
+type Record struct {
+	mu      sync.Mutex
+	servers []string
+}
+
+// WithRecord returns a copy of the given context, in which the attempts of the request are recorded.
+func WithRecord(ctx context.Context) context.Context {
+	return context.WithValue(ctx, recordKey{}, &Record{})
+}
+
+// FromContext returns the record of the attempts held by the given context,
+// or nil if the request is not retried.
+func FromContext(ctx context.Context) *Record {
+	record, _ := ctx.Value(recordKey{}).(*Record)
+	return record
+}
+
+// Add records that an attempt was forwarded to the given server.
+func (r *Record) Add(server string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.servers = append(r.servers, server)
+}
+
+// Servers returns the servers to which the previous attempts were forwarded.
+func (r *Record) Servers() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.servers...)
+}
@@ -20,8 +20,42 @@ const (
 
 	// DefaultFlushInterval is the default value for the ResponseForwarding flush interval.
 	DefaultFlushInterval = ptypes.Duration(100 * time.Millisecond)
+
+	// DefaultOutlierConsecutiveErrors is the default value for the OutlierDetection consecutiveErrors.
+	DefaultOutlierConsecutiveErrors = 5
+	// DefaultOutlierBaseEjectionTime is the default value for the OutlierDetection baseEjectionTime.
+	DefaultOutlierBaseEjectionTime = ptypes.Duration(30 * time.Second)
+	// DefaultOutlierMaxEjectionTime is the default value for the OutlierDetection maxEjectionTime.
+	DefaultOutlierMaxEjectionTime = ptypes.Duration(300 * time.Second)
+
+	// DefaultOutlierMaxEjectionPercent is the default value for the OutlierDetection maxEjectionPercent.
+	DefaultOutlierMaxEjectionPercent = 50
+
+	// DefaultStickySessionTimeout is the default value for the AppSession timeout,
+	// and the lifetime of the opaque sticky cookie identifiers without max age.
+	DefaultStickySessionTimeout = ptypes.Duration(time.Hour)
 )
 
+const (
+	// BalancerStrategyRoundRobin is the weighted round robin load-balancing strategy, used by default.
+	BalancerStrategyRoundRobin = "RoundRobin"
+	// BalancerStrategyLeastRequests is the load-balancing strategy picking the server with the least outstanding requests.
+	BalancerStrategyLeastRequests = "LeastRequests"
+	// BalancerStrategyPeakEWMA is the load-balancing strategy picking the server with the lowest
+	// peak exponentially weighted moving average of latencies, weighted by its outstanding requests.
+	BalancerStrategyPeakEWMA = "PeakEWMA"
+	// BalancerStrategyConsistentHash is the load-balancing strategy picking the server
+	// from a hash ring, according to a key extracted from the request.
+	BalancerStrategyConsistentHash = "ConsistentHash"
+)
+
+// DefaultLocalityMinHealthyPercent is the default value for the Locality minHealthyPercent.
+const DefaultLocalityMinHealthyPercent = 70
+
+// DefaultConsistentHashBoundedLoad is the default maximum load of a server picked by consistent hashing,
+// as a percentage of the average load of the servers.
+const DefaultConsistentHashBoundedLoad = 125
+
 // +k8s:deepcopy-gen=true
 
 // HTTPConfiguration contains all the HTTP configuration parameters.
@@ -80,6 +114,7 @@ type Mirroring struct {
 	MaxBodySize *int64          `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
 	Mirrors     []MirrorService `json:"mirrors,omitempty" toml:"mirrors,omitempty" yaml:"mirrors,omitempty" export:"true"`
 	HealthCheck *HealthCheck    `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	Diff        *MirroringDiff  `json:"diff,omitempty" toml:"diff,omitempty" yaml:"diff,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 }
 
 // SetDefaults Default values for a WRRService.
@@ -90,11 +125,47 @@ func (m *Mirroring) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// MirroringDiff holds the configuration of the comparison of the mirror responses with the response of the mirrored service.
+type MirroringDiff struct {
+	// Headers are the names of the compared headers. All the headers are compared when empty.
+	Headers []string `json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty" export:"true"`
+	// IgnoredHeaders are the names of the headers which are never compared, e.g. Date.
+	IgnoredHeaders []string `json:"ignoredHeaders,omitempty" toml:"ignoredHeaders,omitempty" yaml:"ignoredHeaders,omitempty" export:"true"`
+	IgnoreBody     bool     `json:"ignoreBody,omitempty" toml:"ignoreBody,omitempty" yaml:"ignoreBody,omitempty" export:"true"`
+	// LogSampleRate is the ratio, between 0 and 1, of the mismatches which are logged.
+	LogSampleRate float64 `json:"logSampleRate,omitempty" toml:"logSampleRate,omitempty" yaml:"logSampleRate,omitempty" export:"true"`
+}
+
+// SetDefaults Default values for a MirroringDiff.
+func (m *MirroringDiff) SetDefaults() {
+	m.LogSampleRate = 1
+}
+
+// +k8s:deepcopy-gen=true
+
 // Failover holds the Failover configuration.
+// The traffic is sent either to Service, or to Fallback when Service is down,
+// or to the first eligible tier of Tiers.
 type Failover struct {
-	Service     string       `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
-	Fallback    string       `json:"fallback,omitempty" toml:"fallback,omitempty" yaml:"fallback,omitempty" export:"true"`
-	HealthCheck *HealthCheck `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+	Service  string `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
+	Fallback string `json:"fallback,omitempty" toml:"fallback,omitempty" yaml:"fallback,omitempty" export:"true"`
+	// Tiers defines the tiers of services, from the highest priority one.
+	// It cannot be used along with Service and Fallback.
+	Tiers []FailoverTier `json:"tiers,omitempty" toml:"tiers,omitempty" yaml:"tiers,omitempty" export:"true"`
+	// FailbackDelay defines how long a higher priority tier must have been eligible again,
+	// before the traffic fails back to it.
+	FailbackDelay ptypes.Duration `json:"failbackDelay,omitempty" toml:"failbackDelay,omitempty" yaml:"failbackDelay,omitempty" export:"true"`
+	HealthCheck   *HealthCheck    `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// FailoverTier is a tier of services of a Failover, load-balanced with weighted round robin.
+type FailoverTier struct {
+	Services []WRRService `json:"services,omitempty" toml:"services,omitempty" yaml:"services,omitempty" export:"true"`
+	// MinHealthy defines the minimum number of healthy services for the tier to be eligible to the traffic.
+	// It defaults to 1, and is at most the number of services of the tier.
+	MinHealthy int `json:"minHealthy,omitempty" toml:"minHealthy,omitempty" yaml:"minHealthy,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -139,6 +210,35 @@ func (w *WRRService) SetDefaults() {
 type Sticky struct {
 	// Cookie defines the sticky cookie configuration.
 	Cookie *Cookie `json:"cookie,omitempty" toml:"cookie,omitempty" yaml:"cookie,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// AppSession defines the sticky sessions keyed on a session identifier issued by the servers.
+	AppSession *AppSession `json:"appSession,omitempty" toml:"appSession,omitempty" yaml:"appSession,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ConsistentHash holds the consistent hashing configuration.
+// The hash key is extracted from the request with at most one of Header, Cookie, Query, Path, or IPStrategy.
+// By default, the hash key is the client IP address.
+type ConsistentHash struct {
+	// Header defines the name of the request header used as hash key.
+	Header string `json:"header,omitempty" toml:"header,omitempty" yaml:"header,omitempty" export:"true"`
+	// Cookie defines the name of the cookie used as hash key.
+	Cookie string `json:"cookie,omitempty" toml:"cookie,omitempty" yaml:"cookie,omitempty" export:"true"`
+	// Query defines the name of the query parameter used as hash key.
+	Query string `json:"query,omitempty" toml:"query,omitempty" yaml:"query,omitempty" export:"true"`
+	// Path defines whether the request path is used as hash key.
+	Path bool `json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty" export:"true"`
+	// IPStrategy defines how the client IP address, used as hash key, is selected.
+	IPStrategy *IPStrategy `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// BoundedLoad defines the maximum load of a server, as a percentage of the average load of the servers.
+	// When a server is above this bound, the next server on the hash ring is picked.
+	// Zero disables the bound.
+	BoundedLoad int `json:"boundedLoad,omitempty" toml:"boundedLoad,omitempty" yaml:"boundedLoad,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (c *ConsistentHash) SetDefaults() {
+	c.BoundedLoad = DefaultConsistentHashBoundedLoad
 }
 
 // +k8s:deepcopy-gen=true
@@ -154,6 +254,37 @@ type Cookie struct {
 	// SameSite defines the same site policy.
 	// More info: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite
 	SameSite string `json:"sameSite,omitempty" toml:"sameSite,omitempty" yaml:"sameSite,omitempty" export:"true"`
+	// MaxAge defines the number of seconds until the cookie expires.
+	// When set to a negative number, the cookie expires immediately.
+	// When set to zero, the cookie never expires.
+	MaxAge int `json:"maxAge,omitempty" toml:"maxAge,omitempty" yaml:"maxAge,omitempty" export:"true"`
+	// Path defines the path that must exist in the requested URL for the client to send the cookie.
+	// Defaults to "/".
+	Path string `json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty" export:"true"`
+	// Domain defines the host to which the cookie will be sent.
+	Domain string `json:"domain,omitempty" toml:"domain,omitempty" yaml:"domain,omitempty" export:"true"`
+	// Opaque defines whether the cookie value is a random identifier mapped to the server,
+	// instead of the name of the server.
+	Opaque bool `json:"opaque,omitempty" toml:"opaque,omitempty" yaml:"opaque,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// AppSession holds the sticky sessions configuration based on a session identifier issued by the servers.
+// The identifier is learned from the responses of the servers, in the cookie or the header with the given name,
+// and the requests holding it, in the same cookie or header, are forwarded to the server which issued it.
+type AppSession struct {
+	// Cookie defines the name of the cookie holding the session identifier.
+	Cookie string `json:"cookie,omitempty" toml:"cookie,omitempty" yaml:"cookie,omitempty" export:"true"`
+	// Header defines the name of the header holding the session identifier.
+	Header string `json:"header,omitempty" toml:"header,omitempty" yaml:"header,omitempty" export:"true"`
+	// Timeout defines how long a session identifier is remembered after its last use.
+	Timeout ptypes.Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values for an AppSession.
+func (a *AppSession) SetDefaults() {
+	a.Timeout = DefaultStickySessionTimeout
 }
 
 // +k8s:deepcopy-gen=true
@@ -162,11 +293,28 @@ type Cookie struct {
 type ServersLoadBalancer struct {
 	Sticky  *Sticky  `json:"sticky,omitempty" toml:"sticky,omitempty" yaml:"sticky,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 	Servers []Server `json:"servers,omitempty" toml:"servers,omitempty" yaml:"servers,omitempty" label-slice-as-struct:"server" export:"true"`
+	// Strategy defines the load-balancing strategy between the servers:
+	// RoundRobin (default), LeastRequests, PeakEWMA, or ConsistentHash.
+	Strategy string `json:"strategy,omitempty" toml:"strategy,omitempty" yaml:"strategy,omitempty" export:"true"`
+	// ConsistentHash defines the consistent hashing configuration, used by the ConsistentHash strategy.
+	ConsistentHash *ConsistentHash `json:"consistentHash,omitempty" toml:"consistentHash,omitempty" yaml:"consistentHash,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 	// HealthCheck enables regular active checks of the responsiveness of the
 	// children servers of this load-balancer. To propagate status changes (e.g. all
 	// servers of this service are down) upwards, HealthCheck must also be enabled on
 	// the parent(s) of this service.
-	HealthCheck        *ServerHealthCheck  `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" export:"true"`
+	HealthCheck *ServerHealthCheck `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" export:"true"`
+	// OutlierDetection enables the passive health checking of the children servers of this load-balancer,
+	// which ejects the servers failing the forwarded requests.
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty" toml:"outlierDetection,omitempty" yaml:"outlierDetection,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// SlowStart defines the duration over which the weight of a server ramps up linearly,
+	// after it is added or comes back up.
+	SlowStart ptypes.Duration `json:"slowStart,omitempty" toml:"slowStart,omitempty" yaml:"slowStart,omitempty" export:"true"`
+	// Hedging enables the request hedging, which sends a second request to another server,
+	// when the first one has not responded within a delay.
+	Hedging *Hedging `json:"hedging,omitempty" toml:"hedging,omitempty" yaml:"hedging,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// Locality enables the locality-aware load-balancing, which prefers the servers in the zone,
+	// then in the region, of this Traefik instance.
+	Locality           *Locality           `json:"locality,omitempty" toml:"locality,omitempty" yaml:"locality,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 	PassHostHeader     *bool               `json:"passHostHeader" toml:"passHostHeader" yaml:"passHostHeader" export:"true"`
 	ResponseForwarding *ResponseForwarding `json:"responseForwarding,omitempty" toml:"responseForwarding,omitempty" yaml:"responseForwarding,omitempty" export:"true"`
 	ServersTransport   string              `json:"serversTransport,omitempty" toml:"serversTransport,omitempty" yaml:"serversTransport,omitempty" export:"true"`
@@ -222,6 +370,10 @@ type Server struct {
 	URL    string `json:"url,omitempty" toml:"url,omitempty" yaml:"url,omitempty" label:"-"`
 	Scheme string `toml:"-" json:"-" yaml:"-" file:"-"`
 	Port   string `toml:"-" json:"-" yaml:"-" file:"-"`
+	// Zone defines the zone of the server (e.g. its availability zone), used by the locality-aware load-balancing.
+	Zone string `json:"zone,omitempty" toml:"zone,omitempty" yaml:"zone,omitempty" export:"true"`
+	// Region defines the region of the server, used by the locality-aware load-balancing.
+	Region string `json:"region,omitempty" toml:"region,omitempty" yaml:"region,omitempty" export:"true"`
 }
 
 // SetDefaults Default values for a Server.
@@ -256,6 +408,61 @@ func (h *ServerHealthCheck) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// Locality holds the locality-aware load-balancing configuration.
+// The servers are split in tiers: the ones in the zone of Traefik, the ones in its region, and the others.
+// The requests are sent to the first tier with healthy servers, and spill over to the next tiers
+// only when the healthy capacity of the tier drops below MinHealthyPercent.
+type Locality struct {
+	// MinHealthyPercent defines the percentage of healthy capacity (i.e. the weight of the healthy servers)
+	// of a tier, below which part of the traffic spills over to the next tier.
+	MinHealthyPercent int `json:"minHealthyPercent,omitempty" toml:"minHealthyPercent,omitempty" yaml:"minHealthyPercent,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values for a Locality.
+func (l *Locality) SetDefaults() {
+	l.MinHealthyPercent = DefaultLocalityMinHealthyPercent
+}
+
+// +k8s:deepcopy-gen=true
+
+// Hedging holds the request hedging configuration.
+// A request without body, of one of the hedged methods, is sent to a second server
+// when the first one has not responded within the hedging delay.
+// The first response is used, and the other request is canceled.
+type Hedging struct {
+	// Methods defines the methods of the hedged requests. Defaults to GET and HEAD.
+	Methods []string `json:"methods,omitempty" toml:"methods,omitempty" yaml:"methods,omitempty" export:"true"`
+	// Delay defines the fixed hedging delay.
+	// With a percentile, it is the hedging delay until enough response latencies are observed.
+	Delay ptypes.Duration `json:"delay,omitempty" toml:"delay,omitempty" yaml:"delay,omitempty" export:"true"`
+	// Percentile defines the percentile, between 0 and 100, of the observed response latencies used as hedging delay.
+	Percentile float64 `json:"percentile,omitempty" toml:"percentile,omitempty" yaml:"percentile,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// OutlierDetection holds the passive health check configuration.
+type OutlierDetection struct {
+	// ConsecutiveErrors defines the number of consecutive failed requests (5xx responses or connection errors) before a server is ejected.
+	ConsecutiveErrors int `json:"consecutiveErrors,omitempty" toml:"consecutiveErrors,omitempty" yaml:"consecutiveErrors,omitempty" export:"true"`
+	// BaseEjectionTime defines the ejection time of a server, doubled on each consecutive ejection.
+	BaseEjectionTime ptypes.Duration `json:"baseEjectionTime,omitempty" toml:"baseEjectionTime,omitempty" yaml:"baseEjectionTime,omitempty" export:"true"`
+	// MaxEjectionTime defines the maximum ejection time of a server.
+	MaxEjectionTime ptypes.Duration `json:"maxEjectionTime,omitempty" toml:"maxEjectionTime,omitempty" yaml:"maxEjectionTime,omitempty" export:"true"`
+	// MaxEjectionPercent defines the maximum percentage of servers which can be ejected at the same time.
+	MaxEjectionPercent int `json:"maxEjectionPercent,omitempty" toml:"maxEjectionPercent,omitempty" yaml:"maxEjectionPercent,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (o *OutlierDetection) SetDefaults() {
+	o.ConsecutiveErrors = DefaultOutlierConsecutiveErrors
+	o.BaseEjectionTime = DefaultOutlierBaseEjectionTime
+	o.MaxEjectionTime = DefaultOutlierMaxEjectionTime
+	o.MaxEjectionPercent = DefaultOutlierMaxEjectionPercent
+}
+
+// +k8s:deepcopy-gen=true
+
 // HealthCheck controls healthcheck awareness and propagation at the services level.
 type HealthCheck struct{}
 
@@ -27,6 +27,8 @@ type Middleware struct {
 	BasicAuth         *BasicAuth         `json:"basicAuth,omitempty" toml:"basicAuth,omitempty" yaml:"basicAuth,omitempty" export:"true"`
 	DigestAuth        *DigestAuth        `json:"digestAuth,omitempty" toml:"digestAuth,omitempty" yaml:"digestAuth,omitempty" export:"true"`
 	ForwardAuth       *ForwardAuth       `json:"forwardAuth,omitempty" toml:"forwardAuth,omitempty" yaml:"forwardAuth,omitempty" export:"true"`
+	JWT               *JWT               `json:"jwt,omitempty" toml:"jwt,omitempty" yaml:"jwt,omitempty" export:"true"`
+	OIDC              *OIDC              `json:"oidc,omitempty" toml:"oidc,omitempty" yaml:"oidc,omitempty" export:"true"`
 	InFlightReq       *InFlightReq       `json:"inFlightReq,omitempty" toml:"inFlightReq,omitempty" yaml:"inFlightReq,omitempty" export:"true"`
 	Buffering         *Buffering         `json:"buffering,omitempty" toml:"buffering,omitempty" yaml:"buffering,omitempty" export:"true"`
 	CircuitBreaker    *CircuitBreaker    `json:"circuitBreaker,omitempty" toml:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty" export:"true"`
@@ -227,6 +229,29 @@ type ForwardAuth struct {
 	// AuthRequestHeaders defines the list of the headers to copy from the request to the authentication server.
 	// If not set or empty then all request headers are passed.
 	AuthRequestHeaders []string `json:"authRequestHeaders,omitempty" toml:"authRequestHeaders,omitempty" yaml:"authRequestHeaders,omitempty" export:"true"`
+	// ForwardBody defines whether to send the request body to the authentication server.
+	ForwardBody bool `json:"forwardBody,omitempty" toml:"forwardBody,omitempty" yaml:"forwardBody,omitempty" export:"true"`
+	// MaxBodySize defines the maximum size in bytes of the request body forwarded to the authentication server.
+	// The requests with a larger body are refused. Default: 1048576 (1MiB).
+	MaxBodySize int64 `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+	// Cache defines the caching of the decisions of the authentication server.
+	Cache *ForwardAuthCache `json:"cache,omitempty" toml:"cache,omitempty" yaml:"cache,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ForwardAuthCache holds the forward auth cache configuration.
+type ForwardAuthCache struct {
+	// TTL defines how long the decisions of the authentication server are cached.
+	TTL ptypes.Duration `json:"ttl,omitempty" toml:"ttl,omitempty" yaml:"ttl,omitempty" export:"true"`
+	// KeyHeaders defines the request headers whose values identify a decision in the cache.
+	KeyHeaders []string `json:"keyHeaders,omitempty" toml:"keyHeaders,omitempty" yaml:"keyHeaders,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a ForwardAuthCache.
+func (c *ForwardAuthCache) SetDefaults() {
+	c.TTL = ptypes.Duration(5 * time.Second)
+	c.KeyHeaders = []string{"Authorization"}
 }
 
 // +k8s:deepcopy-gen=true
@@ -424,6 +449,110 @@ type InFlightReq struct {
 
 // +k8s:deepcopy-gen=true
 
+// JWT holds the JWT middleware configuration.
+// This middleware authenticates the requests bearing a valid JSON Web Token.
+type JWT struct {
+	// Secret defines the secret used to verify the HMAC signatures (HS256, HS384, HS512).
+	Secret string `json:"secret,omitempty" toml:"secret,omitempty" yaml:"secret,omitempty" loggable:"false"`
+	// PublicKey defines the PEM encoded public key used to verify the RSA, ECDSA and EdDSA signatures.
+	PublicKey string `json:"publicKey,omitempty" toml:"publicKey,omitempty" yaml:"publicKey,omitempty"`
+	// JWKSURL defines the URL of the JSON Web Key Set holding the public keys used to verify the signatures.
+	JWKSURL string `json:"jwksUrl,omitempty" toml:"jwksUrl,omitempty" yaml:"jwksUrl,omitempty"`
+	// JWKSRefreshInterval defines how long the fetched JSON Web Key Set is cached.
+	// It defaults to 1h.
+	JWKSRefreshInterval ptypes.Duration `json:"jwksRefreshInterval,omitempty" toml:"jwksRefreshInterval,omitempty" yaml:"jwksRefreshInterval,omitempty" export:"true"`
+	// Algorithms defines the accepted signature algorithms.
+	// It defaults to the algorithms matching the configured keys.
+	Algorithms []string `json:"algorithms,omitempty" toml:"algorithms,omitempty" yaml:"algorithms,omitempty" export:"true"`
+	// Issuer defines the expected value of the iss claim.
+	Issuer string `json:"issuer,omitempty" toml:"issuer,omitempty" yaml:"issuer,omitempty"`
+	// Audience defines the accepted values of the aud claim, one of which the token must be intended for.
+	Audience []string `json:"audience,omitempty" toml:"audience,omitempty" yaml:"audience,omitempty"`
+	// ClockSkew defines the tolerance applied when checking the exp and nbf claims.
+	ClockSkew ptypes.Duration `json:"clockSkew,omitempty" toml:"clockSkew,omitempty" yaml:"clockSkew,omitempty" export:"true"`
+	// AllowMissingExpiration defines whether to accept the tokens without expiration time, i.e. without exp claim.
+	AllowMissingExpiration bool `json:"allowMissingExpiration,omitempty" toml:"allowMissingExpiration,omitempty" yaml:"allowMissingExpiration,omitempty" export:"true"`
+	// ClaimsHeaders defines the request headers to set, by name, with the value of the given claims.
+	// Nested claims are selected with a dot separated path.
+	ClaimsHeaders map[string]string `json:"claimsHeaders,omitempty" toml:"claimsHeaders,omitempty" yaml:"claimsHeaders,omitempty" export:"true"`
+	// RemoveHeader defines whether to remove the authorization header before forwarding the request to the backend.
+	RemoveHeader bool `json:"removeHeader,omitempty" toml:"removeHeader,omitempty" yaml:"removeHeader,omitempty" export:"true"`
+	// HeaderField defines a header field to store the subject of the token.
+	HeaderField string `json:"headerField,omitempty" toml:"headerField,omitempty" yaml:"headerField,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a JWT.
+func (j *JWT) SetDefaults() {
+	j.JWKSRefreshInterval = ptypes.Duration(time.Hour)
+}
+
+// +k8s:deepcopy-gen=true
+
+// OIDC holds the OpenID Connect middleware configuration.
+// This middleware authenticates the users with an OpenID Connect provider, with the authorization code flow.
+type OIDC struct {
+	// Issuer defines the URL of the OpenID Connect provider, from which its configuration is discovered.
+	Issuer string `json:"issuer,omitempty" toml:"issuer,omitempty" yaml:"issuer,omitempty"`
+	// TLS defines the configuration used to secure the connections to the OpenID Connect provider.
+	TLS *types.ClientTLS `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+	// ClientID defines the identifier of the client registered with the OpenID Connect provider.
+	ClientID string `json:"clientId,omitempty" toml:"clientId,omitempty" yaml:"clientId,omitempty"`
+	// ClientSecret defines the secret of the client registered with the OpenID Connect provider.
+	// It can be omitted for a public client.
+	ClientSecret string `json:"clientSecret,omitempty" toml:"clientSecret,omitempty" yaml:"clientSecret,omitempty" loggable:"false"`
+	// Scopes defines the scopes requested to the OpenID Connect provider.
+	Scopes []string `json:"scopes,omitempty" toml:"scopes,omitempty" yaml:"scopes,omitempty" export:"true"`
+	// RedirectURL defines the URL, or the path on the host of the request, to which the provider redirects the users after authentication.
+	RedirectURL string `json:"redirectUrl,omitempty" toml:"redirectUrl,omitempty" yaml:"redirectUrl,omitempty" export:"true"`
+	// LogoutURL defines the path on which the users are logged out.
+	LogoutURL string `json:"logoutUrl,omitempty" toml:"logoutUrl,omitempty" yaml:"logoutUrl,omitempty" export:"true"`
+	// PostLogoutRedirectURL defines the URL to which the users are redirected after logout.
+	PostLogoutRedirectURL string `json:"postLogoutRedirectUrl,omitempty" toml:"postLogoutRedirectUrl,omitempty" yaml:"postLogoutRedirectUrl,omitempty" export:"true"`
+	// Secret defines the secret used to encrypt the session cookie.
+	Secret string `json:"secret,omitempty" toml:"secret,omitempty" yaml:"secret,omitempty" loggable:"false"`
+	// Session defines the session cookie configuration.
+	Session *OIDCSession `json:"session,omitempty" toml:"session,omitempty" yaml:"session,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// ForwardAccessToken defines whether to forward the access token to the backend, in the authorization header.
+	ForwardAccessToken bool `json:"forwardAccessToken,omitempty" toml:"forwardAccessToken,omitempty" yaml:"forwardAccessToken,omitempty" export:"true"`
+	// ClaimsHeaders defines the request headers to set, by name, with the value of the given claims of the ID token.
+	// Nested claims are selected with a dot separated path.
+	ClaimsHeaders map[string]string `json:"claimsHeaders,omitempty" toml:"claimsHeaders,omitempty" yaml:"claimsHeaders,omitempty" export:"true"`
+	// HeaderField defines a header field to store the subject of the ID token.
+	HeaderField string `json:"headerField,omitempty" toml:"headerField,omitempty" yaml:"headerField,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on an OIDC.
+func (o *OIDC) SetDefaults() {
+	o.Scopes = []string{"openid", "profile", "email"}
+	o.RedirectURL = "/oidc/callback"
+}
+
+// +k8s:deepcopy-gen=true
+
+// OIDCSession holds the OpenID Connect session cookie configuration.
+type OIDCSession struct {
+	// Name defines the name of the session cookie.
+	Name string `json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty" export:"true"`
+	// Path defines the path of the session cookie.
+	Path string `json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty" export:"true"`
+	// Domain defines the domain of the session cookie.
+	Domain string `json:"domain,omitempty" toml:"domain,omitempty" yaml:"domain,omitempty" export:"true"`
+	// Secure defines whether the session cookie can only be transmitted over an encrypted connection (i.e. HTTPS).
+	Secure bool `json:"secure,omitempty" toml:"secure,omitempty" yaml:"secure,omitempty" export:"true"`
+	// SameSite defines the same site policy of the session cookie.
+	SameSite string `json:"sameSite,omitempty" toml:"sameSite,omitempty" yaml:"sameSite,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on an OIDCSession.
+func (s *OIDCSession) SetDefaults() {
+	s.Name = "traefik_oidc"
+	s.Path = "/"
+	s.Secure = true
+	s.SameSite = "lax"
+}
+
+// +k8s:deepcopy-gen=true
+
 // PassTLSClientCert holds the pass TLS client cert middleware configuration.
 // This middleware adds the selected data from the passed client TLS certificate to a header.
 // More info: https://doc.traefik.io/traefik/v2.9/middlewares/http/passtlsclientcert/
@@ -470,6 +599,15 @@ type RateLimit struct {
 	// If several strategies are defined at the same time, an error will be raised.
 	// If none are set, the default is to use the request's remote address field (as an ipStrategy).
 	SourceCriterion *SourceCriterion `json:"sourceCriterion,omitempty" toml:"sourceCriterion,omitempty" yaml:"sourceCriterion,omitempty" export:"true"`
+
+	// Redis defines the Redis store in which the requests are counted,
+	// so that the rate is limited across all the Traefik instances sharing the store.
+	Redis *RateLimitRedis `json:"redis,omitempty" toml:"redis,omitempty" yaml:"redis,omitempty" export:"true"`
+
+	// Plans defines quota tiers, by name.
+	// The sources of a plan are limited with the rate of the plan,
+	// and the other sources with the rate of the middleware, i.e. the default plan.
+	Plans map[string]*RateLimitPlan `json:"plans,omitempty" toml:"plans,omitempty" yaml:"plans,omitempty" export:"true"`
 }
 
 // SetDefaults sets the default values on a RateLimit.
@@ -480,6 +618,53 @@ func (r *RateLimit) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// RateLimitPlan holds the rate limit of a quota tier.
+type RateLimitPlan struct {
+	// Sources defines the sources, as selected by the source criterion of the middleware, which are limited with this plan.
+	Sources []string `json:"sources,omitempty" toml:"sources,omitempty" yaml:"sources,omitempty" loggable:"false"`
+	// Average is the maximum rate, by default in requests/s, allowed for the sources of the plan.
+	// It defaults to 0, which means no rate limiting.
+	Average int64 `json:"average,omitempty" toml:"average,omitempty" yaml:"average,omitempty" export:"true"`
+	// Period, in combination with Average, defines the actual maximum rate, such as:
+	// r = Average / Period. It defaults to a second.
+	Period ptypes.Duration `json:"period,omitempty" toml:"period,omitempty" yaml:"period,omitempty" export:"true"`
+	// Burst is the maximum number of requests allowed to arrive in the same arbitrarily small period of time.
+	// It defaults to 1.
+	Burst int64 `json:"burst,omitempty" toml:"burst,omitempty" yaml:"burst,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a RateLimitPlan.
+func (r *RateLimitPlan) SetDefaults() {
+	r.Burst = 1
+	r.Period = ptypes.Duration(time.Second)
+}
+
+// +k8s:deepcopy-gen=true
+
+// RateLimitRedis holds the configuration of the Redis store shared by the rate limiters.
+type RateLimitRedis struct {
+	// Endpoints defines the addresses of the Redis servers, or of the nodes of the Redis cluster.
+	Endpoints []string `json:"endpoints,omitempty" toml:"endpoints,omitempty" yaml:"endpoints,omitempty" export:"true"`
+	// TLS defines the configuration used to secure the connections to the Redis servers.
+	TLS *types.ClientTLS `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" export:"true"`
+	// Username defines the username used to authenticate to the Redis servers.
+	Username string `json:"username,omitempty" toml:"username,omitempty" yaml:"username,omitempty" loggable:"false"`
+	// Password defines the password used to authenticate to the Redis servers.
+	Password string `json:"password,omitempty" toml:"password,omitempty" yaml:"password,omitempty" loggable:"false"`
+	// DB defines the Redis database in which the requests are counted.
+	DB int `json:"db,omitempty" toml:"db,omitempty" yaml:"db,omitempty" export:"true"`
+	// Timeout defines the maximum duration of an operation on the Redis servers,
+	// after which the rate is limited locally until the store is available again.
+	Timeout ptypes.Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a RateLimitRedis.
+func (r *RateLimitRedis) SetDefaults() {
+	r.Timeout = ptypes.Duration(500 * time.Millisecond)
+}
+
+// +k8s:deepcopy-gen=true
+
 // RedirectRegex holds the redirect regex middleware configuration.
 // This middleware redirects a request using regex matching and replacement.
 // More info: https://doc.traefik.io/traefik/v2.9/middlewares/http/redirectregex/#regex
@@ -531,8 +716,8 @@ type ReplacePathRegex struct {
 // +k8s:deepcopy-gen=true
 
 // Retry holds the retry middleware configuration.
-// This middleware reissues requests a given number of times to a backend server if that server does not reply.
-// As soon as the server answers, the middleware stops retrying, regardless of the response status.
+// This middleware reissues requests a given number of times to a backend server if that server does not reply,
+// or if it answers with one of the configured status codes.
 // More info: https://doc.traefik.io/traefik/v2.9/middlewares/http/retry/
 type Retry struct {
 	// Attempts defines how many times the request should be retried.
@@ -543,6 +728,32 @@ type Retry struct {
 	// The value of initialInterval should be provided in seconds or as a valid duration format,
 	// see https://pkg.go.dev/time#ParseDuration.
 	InitialInterval ptypes.Duration `json:"initialInterval,omitempty" toml:"initialInterval,omitempty" yaml:"initialInterval,omitempty" export:"true"`
+	// Status defines which status or range of statuses of the responses should result in a retry.
+	// It can be defined as a single number (503), as multiple comma-separated numbers (502,503),
+	// as ranges by separating two codes with a dash (502-504), or a combination of the two.
+	Status []string `json:"status,omitempty" toml:"status,omitempty" yaml:"status,omitempty" export:"true"`
+	// Methods defines the methods of the requests which are retried on status.
+	// Defaults to the idempotent methods: GET, HEAD, OPTIONS, TRACE, PUT and DELETE.
+	Methods []string `json:"methods,omitempty" toml:"methods,omitempty" yaml:"methods,omitempty" export:"true"`
+	// Budget limits the retries to a percentage of the requests, to prevent retry storms.
+	Budget *RetryBudget `json:"budget,omitempty" toml:"budget,omitempty" yaml:"budget,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RetryBudget holds the retry budget configuration.
+// The budget is shared by all the routers using the retry middleware, and kept across the configuration reloads.
+type RetryBudget struct {
+	// Percent defines the maximum percentage of retries to requests, over the last 10 seconds.
+	Percent int `json:"percent,omitempty" toml:"percent,omitempty" yaml:"percent,omitempty" export:"true"`
+	// MinRetriesPerSecond defines the number of retries per second which are always allowed, regardless of the percentage.
+	MinRetriesPerSecond int `json:"minRetriesPerSecond,omitempty" toml:"minRetriesPerSecond,omitempty" yaml:"minRetriesPerSecond,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a RetryBudget.
+func (r *RetryBudget) SetDefaults() {
+	r.Percent = 20
+	r.MinRetriesPerSecond = 10
 }
 
 // +k8s:deepcopy-gen=true
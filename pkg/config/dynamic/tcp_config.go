@@ -2,10 +2,19 @@ package dynamic
 
 import (
 	"reflect"
+	"time"
 
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v2/pkg/types"
 )
 
+// TCPBalancerStrategyLeastConnections is the load-balancing strategy picking the TCP server with the least active connections.
+// The BalancerStrategyRoundRobin and BalancerStrategyPeakEWMA strategies are also available for TCP servers.
+const TCPBalancerStrategyLeastConnections = "LeastConnections"
+
+// DefaultTCPStickyTimeout is the default value for the TCPSticky timeout.
+const DefaultTCPStickyTimeout = ptypes.Duration(time.Minute)
+
 // +k8s:deepcopy-gen=true
 
 // TCPConfiguration contains all the TCP configuration parameters.
@@ -21,6 +30,7 @@ type TCPConfiguration struct {
 type TCPService struct {
 	LoadBalancer *TCPServersLoadBalancer `json:"loadBalancer,omitempty" toml:"loadBalancer,omitempty" yaml:"loadBalancer,omitempty" export:"true"`
 	Weighted     *TCPWeightedRoundRobin  `json:"weighted,omitempty" toml:"weighted,omitempty" yaml:"weighted,omitempty" label:"-" export:"true"`
+	Failover     *TCPFailover            `json:"failover,omitempty" toml:"failover,omitempty" yaml:"failover,omitempty" label:"-" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -46,6 +56,27 @@ func (w *TCPWRRService) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// TCPFailover is a tcp service sending the traffic to the first eligible tier of services.
+type TCPFailover struct {
+	// Tiers defines the tiers of services, from the highest priority one.
+	Tiers []TCPFailoverTier `json:"tiers,omitempty" toml:"tiers,omitempty" yaml:"tiers,omitempty" export:"true"`
+	// FailbackDelay defines how long a higher priority tier must have been eligible again,
+	// before the traffic fails back to it.
+	FailbackDelay ptypes.Duration `json:"failbackDelay,omitempty" toml:"failbackDelay,omitempty" yaml:"failbackDelay,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// TCPFailoverTier is a tier of services of a TCPFailover, load-balanced with weighted round robin.
+type TCPFailoverTier struct {
+	Services []TCPWRRService `json:"services,omitempty" toml:"services,omitempty" yaml:"services,omitempty" export:"true"`
+	// MinHealthy defines the minimum number of healthy services for the tier to be eligible to the traffic.
+	// It defaults to 1, and is at most the number of services of the tier.
+	MinHealthy int `json:"minHealthy,omitempty" toml:"minHealthy,omitempty" yaml:"minHealthy,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // TCPRouter holds the router configuration.
 type TCPRouter struct {
 	EntryPoints []string            `json:"entryPoints,omitempty" toml:"entryPoints,omitempty" yaml:"entryPoints,omitempty" export:"true"`
@@ -78,6 +109,17 @@ type TCPServersLoadBalancer struct {
 	TerminationDelay *int           `json:"terminationDelay,omitempty" toml:"terminationDelay,omitempty" yaml:"terminationDelay,omitempty" export:"true"`
 	ProxyProtocol    *ProxyProtocol `json:"proxyProtocol,omitempty" toml:"proxyProtocol,omitempty" yaml:"proxyProtocol,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 	Servers          []TCPServer    `json:"servers,omitempty" toml:"servers,omitempty" yaml:"servers,omitempty" label-slice-as-struct:"server" export:"true"`
+	// Strategy defines the load-balancing strategy between the servers:
+	// RoundRobin (default), LeastConnections, or PeakEWMA.
+	Strategy string `json:"strategy,omitempty" toml:"strategy,omitempty" yaml:"strategy,omitempty" export:"true"`
+	// Sticky enables the source IP affinity, i.e. the connections of a client IP are forwarded to the same server.
+	Sticky *TCPSticky `json:"sticky,omitempty" toml:"sticky,omitempty" yaml:"sticky,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// Locality enables the locality-aware load-balancing, which prefers the servers in the zone,
+	// then in the region, of this Traefik instance.
+	Locality *Locality `json:"locality,omitempty" toml:"locality,omitempty" yaml:"locality,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// HealthCheck enables regular active checks of the responsiveness of the
+	// servers of this load-balancer.
+	HealthCheck *TCPServerHealthCheck `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" export:"true"`
 }
 
 // SetDefaults Default values for a TCPServersLoadBalancer.
@@ -105,10 +147,58 @@ func (l *TCPServersLoadBalancer) Mergeable(loadBalancer *TCPServersLoadBalancer)
 
 // +k8s:deepcopy-gen=true
 
+// TCPServerHealthCheck holds the configuration of the TCP health check.
+// The health check opens a connection to each server, optionally performs a TLS handshake,
+// and optionally sends a payload and expects a reply, within the timeout.
+type TCPServerHealthCheck struct {
+	// Mode defines the kind of connection opened to the server, either "tcp" (plain connection) or "tls" (TLS handshake).
+	Mode string `json:"mode,omitempty" toml:"mode,omitempty" yaml:"mode,omitempty" export:"true"`
+	// Port defines the server port used for the health check, instead of the port of the server address.
+	Port     int             `json:"port,omitempty" toml:"port,omitempty,omitzero" yaml:"port,omitempty" export:"true"`
+	Interval ptypes.Duration `json:"interval,omitempty" toml:"interval,omitempty" yaml:"interval,omitempty" export:"true"`
+	Timeout  ptypes.Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty" export:"true"`
+	// ServerName defines the server name used for the TLS handshake.
+	// When empty, the host of the server address is used.
+	ServerName string `json:"serverName,omitempty" toml:"serverName,omitempty" yaml:"serverName,omitempty"`
+	// InsecureSkipVerify disables the verification of the server certificate during the TLS handshake.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" toml:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty" export:"true"`
+	// Payload defines the content sent to the server once connected.
+	Payload string `json:"payload,omitempty" toml:"payload,omitempty" yaml:"payload,omitempty"`
+	// Expect defines the regular expression the first bytes sent by the server have to match.
+	// When empty, no reply is expected.
+	Expect string `json:"expect,omitempty" toml:"expect,omitempty" yaml:"expect,omitempty"`
+}
+
+// SetDefaults sets the default values for a TCPServerHealthCheck.
+func (h *TCPServerHealthCheck) SetDefaults() {
+	h.Mode = "tcp"
+	h.Interval = DefaultHealthCheckInterval
+	h.Timeout = DefaultHealthCheckTimeout
+}
+
+// +k8s:deepcopy-gen=true
+
+// TCPSticky holds the source IP affinity configuration of a TCP load-balancer.
+type TCPSticky struct {
+	// Timeout defines how long the server of a client IP is remembered after its last connection.
+	Timeout ptypes.Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values for a TCPSticky.
+func (s *TCPSticky) SetDefaults() {
+	s.Timeout = DefaultTCPStickyTimeout
+}
+
+// +k8s:deepcopy-gen=true
+
 // TCPServer holds a TCP Server configuration.
 type TCPServer struct {
 	Address string `json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty" label:"-"`
 	Port    string `toml:"-" json:"-" yaml:"-"`
+	// Zone defines the zone of the server (e.g. its availability zone), used by the locality-aware load-balancing.
+	Zone string `json:"zone,omitempty" toml:"zone,omitempty" yaml:"zone,omitempty" export:"true"`
+	// Region defines the region of the server, used by the locality-aware load-balancing.
+	Region string `json:"region,omitempty" toml:"region,omitempty" yaml:"region,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
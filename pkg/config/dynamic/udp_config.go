@@ -2,14 +2,21 @@ package dynamic
 
 import (
 	"reflect"
+	"time"
+
+	ptypes "github.com/traefik/paerser/types"
 )
 
+// DefaultUDPStickyTimeout is the default value for the UDPSticky timeout.
+const DefaultUDPStickyTimeout = ptypes.Duration(time.Minute)
+
 // +k8s:deepcopy-gen=true
 
 // UDPConfiguration contains all the UDP configuration parameters.
 type UDPConfiguration struct {
-	Routers  map[string]*UDPRouter  `json:"routers,omitempty" toml:"routers,omitempty" yaml:"routers,omitempty" export:"true"`
-	Services map[string]*UDPService `json:"services,omitempty" toml:"services,omitempty" yaml:"services,omitempty" export:"true"`
+	Routers     map[string]*UDPRouter     `json:"routers,omitempty" toml:"routers,omitempty" yaml:"routers,omitempty" export:"true"`
+	Services    map[string]*UDPService    `json:"services,omitempty" toml:"services,omitempty" yaml:"services,omitempty" export:"true"`
+	Middlewares map[string]*UDPMiddleware `json:"middlewares,omitempty" toml:"middlewares,omitempty" yaml:"middlewares,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -18,6 +25,7 @@ type UDPConfiguration struct {
 type UDPService struct {
 	LoadBalancer *UDPServersLoadBalancer `json:"loadBalancer,omitempty" toml:"loadBalancer,omitempty" yaml:"loadBalancer,omitempty" export:"true"`
 	Weighted     *UDPWeightedRoundRobin  `json:"weighted,omitempty" toml:"weighted,omitempty" yaml:"weighted,omitempty" label:"-" export:"true"`
+	Failover     *UDPFailover            `json:"failover,omitempty" toml:"failover,omitempty" yaml:"failover,omitempty" label:"-" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -43,10 +51,34 @@ func (w *UDPWRRService) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// UDPFailover is a UDP service sending the traffic to the first eligible tier of services.
+type UDPFailover struct {
+	// Tiers defines the tiers of services, from the highest priority one.
+	Tiers []UDPFailoverTier `json:"tiers,omitempty" toml:"tiers,omitempty" yaml:"tiers,omitempty" export:"true"`
+	// FailbackDelay defines how long a higher priority tier must have been eligible again,
+	// before the traffic fails back to it.
+	FailbackDelay ptypes.Duration `json:"failbackDelay,omitempty" toml:"failbackDelay,omitempty" yaml:"failbackDelay,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// UDPFailoverTier is a tier of services of a UDPFailover, load-balanced with weighted round robin.
+type UDPFailoverTier struct {
+	Services []UDPWRRService `json:"services,omitempty" toml:"services,omitempty" yaml:"services,omitempty" export:"true"`
+	// MinHealthy defines the minimum number of healthy services for the tier to be eligible to the traffic.
+	// It defaults to 1, and is at most the number of services of the tier.
+	MinHealthy int `json:"minHealthy,omitempty" toml:"minHealthy,omitempty" yaml:"minHealthy,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // UDPRouter defines the configuration for an UDP router.
 type UDPRouter struct {
 	EntryPoints []string `json:"entryPoints,omitempty" toml:"entryPoints,omitempty" yaml:"entryPoints,omitempty" export:"true"`
+	Middlewares []string `json:"middlewares,omitempty" toml:"middlewares,omitempty" yaml:"middlewares,omitempty" export:"true"`
 	Service     string   `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
+	Rule        string   `json:"rule,omitempty" toml:"rule,omitempty" yaml:"rule,omitempty"`
+	Priority    int      `json:"priority,omitempty" toml:"priority,omitempty,omitzero" yaml:"priority,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -54,6 +86,11 @@ type UDPRouter struct {
 // UDPServersLoadBalancer defines the configuration for a load-balancer of UDP servers.
 type UDPServersLoadBalancer struct {
 	Servers []UDPServer `json:"servers,omitempty" toml:"servers,omitempty" yaml:"servers,omitempty" label-slice-as-struct:"server" export:"true"`
+	// Sticky enables the source IP affinity, i.e. the sessions of a client IP are forwarded to the same server.
+	Sticky *UDPSticky `json:"sticky,omitempty" toml:"sticky,omitempty" yaml:"sticky,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// HealthCheck enables regular active checks of the responsiveness of the
+	// servers of this load-balancer.
+	HealthCheck *UDPServerHealthCheck `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" export:"true"`
 }
 
 // Mergeable reports whether the given load-balancer can be merged with the receiver.
@@ -75,6 +112,41 @@ func (l *UDPServersLoadBalancer) Mergeable(loadBalancer *UDPServersLoadBalancer)
 
 // +k8s:deepcopy-gen=true
 
+// UDPSticky holds the source IP affinity configuration of a UDP load-balancer.
+type UDPSticky struct {
+	// Timeout defines how long the server of a client IP is remembered after the start of its last session.
+	Timeout ptypes.Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values for a UDPSticky.
+func (s *UDPSticky) SetDefaults() {
+	s.Timeout = DefaultUDPStickyTimeout
+}
+
+// +k8s:deepcopy-gen=true
+
+// UDPServerHealthCheck holds the configuration of the UDP health check.
+// The health check sends a payload to each server, and expects a reply within the timeout.
+type UDPServerHealthCheck struct {
+	// Port defines the server port used for the health check, instead of the port of the server address.
+	Port     int             `json:"port,omitempty" toml:"port,omitempty,omitzero" yaml:"port,omitempty" export:"true"`
+	Interval ptypes.Duration `json:"interval,omitempty" toml:"interval,omitempty" yaml:"interval,omitempty" export:"true"`
+	Timeout  ptypes.Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty" export:"true"`
+	// Payload defines the content of the datagram sent to the server.
+	Payload string `json:"payload,omitempty" toml:"payload,omitempty" yaml:"payload,omitempty"`
+	// Expect defines the regular expression the reply of the server has to match.
+	// When empty, any reply is accepted.
+	Expect string `json:"expect,omitempty" toml:"expect,omitempty" yaml:"expect,omitempty"`
+}
+
+// SetDefaults sets the default values for a UDPServerHealthCheck.
+func (h *UDPServerHealthCheck) SetDefaults() {
+	h.Interval = DefaultHealthCheckInterval
+	h.Timeout = DefaultHealthCheckTimeout
+}
+
+// +k8s:deepcopy-gen=true
+
 // UDPServer defines a UDP server configuration.
 type UDPServer struct {
 	Address string `json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty" label:"-"`
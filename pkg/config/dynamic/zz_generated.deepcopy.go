@@ -50,6 +50,22 @@ func (in *AddPrefix) DeepCopy() *AddPrefix {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSession) DeepCopyInto(out *AppSession) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSession.
+func (in *AppSession) DeepCopy() *AppSession {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -211,6 +227,27 @@ func (in Configurations) DeepCopy() Configurations {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentHash) DeepCopyInto(out *ConsistentHash) {
+	*out = *in
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentHash.
+func (in *ConsistentHash) DeepCopy() *ConsistentHash {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentHash)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContentType) DeepCopyInto(out *ContentType) {
 	*out = *in
@@ -288,6 +325,13 @@ func (in *ErrorPage) DeepCopy() *ErrorPage {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Failover) DeepCopyInto(out *Failover) {
 	*out = *in
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]FailoverTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.HealthCheck != nil {
 		in, out := &in.HealthCheck, &out.HealthCheck
 		*out = new(HealthCheck)
@@ -306,6 +350,29 @@ func (in *Failover) DeepCopy() *Failover {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverTier) DeepCopyInto(out *FailoverTier) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]WRRService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverTier.
+func (in *FailoverTier) DeepCopy() *FailoverTier {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardAuth) DeepCopyInto(out *ForwardAuth) {
 	*out = *in
@@ -324,6 +391,11 @@ func (in *ForwardAuth) DeepCopyInto(out *ForwardAuth) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ForwardAuthCache)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -337,6 +409,27 @@ func (in *ForwardAuth) DeepCopy() *ForwardAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardAuthCache) DeepCopyInto(out *ForwardAuthCache) {
+	*out = *in
+	if in.KeyHeaders != nil {
+		in, out := &in.KeyHeaders, &out.KeyHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuthCache.
+func (in *ForwardAuthCache) DeepCopy() *ForwardAuthCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardAuthCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardingTimeouts) DeepCopyInto(out *ForwardingTimeouts) {
 	*out = *in
@@ -553,6 +646,27 @@ func (in *HealthCheck) DeepCopy() *HealthCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hedging) DeepCopyInto(out *Hedging) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hedging.
+func (in *Hedging) DeepCopy() *Hedging {
+	if in == nil {
+		return nil
+	}
+	out := new(Hedging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAllowList) DeepCopyInto(out *IPAllowList) {
 	*out = *in
@@ -621,6 +735,55 @@ func (in *InFlightReq) DeepCopy() *InFlightReq {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWT) DeepCopyInto(out *JWT) {
+	*out = *in
+	if in.Algorithms != nil {
+		in, out := &in.Algorithms, &out.Algorithms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimsHeaders != nil {
+		in, out := &in.ClaimsHeaders, &out.ClaimsHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWT.
+func (in *JWT) DeepCopy() *JWT {
+	if in == nil {
+		return nil
+	}
+	out := new(JWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Locality) DeepCopyInto(out *Locality) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Locality.
+func (in *Locality) DeepCopy() *Locality {
+	if in == nil {
+		return nil
+	}
+	out := new(Locality)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Message) DeepCopyInto(out *Message) {
 	*out = *in
@@ -720,6 +883,16 @@ func (in *Middleware) DeepCopyInto(out *Middleware) {
 		*out = new(ForwardAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWT)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDC)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InFlightReq != nil {
 		in, out := &in.InFlightReq, &out.InFlightReq
 		*out = new(InFlightReq)
@@ -748,7 +921,7 @@ func (in *Middleware) DeepCopyInto(out *Middleware) {
 	if in.Retry != nil {
 		in, out := &in.Retry, &out.Retry
 		*out = new(Retry)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ContentType != nil {
 		in, out := &in.ContentType, &out.ContentType
@@ -814,6 +987,11 @@ func (in *Mirroring) DeepCopyInto(out *Mirroring) {
 		*out = new(HealthCheck)
 		**out = **in
 	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(MirroringDiff)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -827,6 +1005,32 @@ func (in *Mirroring) DeepCopy() *Mirroring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirroringDiff) DeepCopyInto(out *MirroringDiff) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoredHeaders != nil {
+		in, out := &in.IgnoredHeaders, &out.IgnoredHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringDiff.
+func (in *MirroringDiff) DeepCopy() *MirroringDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(MirroringDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Model) DeepCopyInto(out *Model) {
 	*out = *in
@@ -853,6 +1057,76 @@ func (in *Model) DeepCopy() *Model {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetection.
+func (in *OutlierDetection) DeepCopy() *OutlierDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDC) DeepCopyInto(out *OIDC) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(types.ClientTLS)
+		**out = **in
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(OIDCSession)
+		**out = **in
+	}
+	if in.ClaimsHeaders != nil {
+		in, out := &in.ClaimsHeaders, &out.ClaimsHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDC.
+func (in *OIDC) DeepCopy() *OIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCSession) DeepCopyInto(out *OIDCSession) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSession.
+func (in *OIDCSession) DeepCopy() *OIDCSession {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PassTLSClientCert) DeepCopyInto(out *PassTLSClientCert) {
 	*out = *in
@@ -898,6 +1172,26 @@ func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 		*out = new(SourceCriterion)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RateLimitRedis)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plans != nil {
+		in, out := &in.Plans, &out.Plans
+		*out = make(map[string]*RateLimitPlan, len(*in))
+		for key, val := range *in {
+			var outVal *RateLimitPlan
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(RateLimitPlan)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
@@ -911,6 +1205,53 @@ func (in *RateLimit) DeepCopy() *RateLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitPlan) DeepCopyInto(out *RateLimitPlan) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPlan.
+func (in *RateLimitPlan) DeepCopy() *RateLimitPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitRedis) DeepCopyInto(out *RateLimitRedis) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(types.ClientTLS)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRedis.
+func (in *RateLimitRedis) DeepCopy() *RateLimitRedis {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitRedis)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedirectRegex) DeepCopyInto(out *RedirectRegex) {
 	*out = *in
@@ -994,6 +1335,21 @@ func (in *ResponseForwarding) DeepCopy() *ResponseForwarding {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Retry) DeepCopyInto(out *Retry) {
 	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(RetryBudget)
+		**out = **in
+	}
 	return
 }
 
@@ -1007,6 +1363,22 @@ func (in *Retry) DeepCopy() *Retry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBudget) DeepCopyInto(out *RetryBudget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBudget.
+func (in *RetryBudget) DeepCopy() *RetryBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Router) DeepCopyInto(out *Router) {
 	*out = *in
@@ -1141,11 +1513,31 @@ func (in *ServersLoadBalancer) DeepCopyInto(out *ServersLoadBalancer) {
 		*out = make([]Server, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConsistentHash != nil {
+		in, out := &in.ConsistentHash, &out.ConsistentHash
+		*out = new(ConsistentHash)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HealthCheck != nil {
 		in, out := &in.HealthCheck, &out.HealthCheck
 		*out = new(ServerHealthCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(OutlierDetection)
+		**out = **in
+	}
+	if in.Hedging != nil {
+		in, out := &in.Hedging, &out.Hedging
+		*out = new(Hedging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Locality != nil {
+		in, out := &in.Locality, &out.Locality
+		*out = new(Locality)
+		**out = **in
+	}
 	if in.PassHostHeader != nil {
 		in, out := &in.PassHostHeader, &out.PassHostHeader
 		*out = new(bool)
@@ -1291,6 +1683,11 @@ func (in *Sticky) DeepCopyInto(out *Sticky) {
 		*out = new(Cookie)
 		**out = **in
 	}
+	if in.AppSession != nil {
+		in, out := &in.AppSession, &out.AppSession
+		*out = new(AppSession)
+		**out = **in
+	}
 	return
 }
 
@@ -1407,6 +1804,52 @@ func (in *TCPConfiguration) DeepCopy() *TCPConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPFailover) DeepCopyInto(out *TCPFailover) {
+	*out = *in
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]TCPFailoverTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPFailover.
+func (in *TCPFailover) DeepCopy() *TCPFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPFailoverTier) DeepCopyInto(out *TCPFailoverTier) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]TCPWRRService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPFailoverTier.
+func (in *TCPFailoverTier) DeepCopy() *TCPFailoverTier {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPFailoverTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPIPAllowList) DeepCopyInto(out *TCPIPAllowList) {
 	*out = *in
@@ -1517,6 +1960,22 @@ func (in *TCPServer) DeepCopy() *TCPServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPServerHealthCheck) DeepCopyInto(out *TCPServerHealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPServerHealthCheck.
+func (in *TCPServerHealthCheck) DeepCopy() *TCPServerHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPServerHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPServersLoadBalancer) DeepCopyInto(out *TCPServersLoadBalancer) {
 	*out = *in
@@ -1535,6 +1994,21 @@ func (in *TCPServersLoadBalancer) DeepCopyInto(out *TCPServersLoadBalancer) {
 		*out = make([]TCPServer, len(*in))
 		copy(*out, *in)
 	}
+	if in.Sticky != nil {
+		in, out := &in.Sticky, &out.Sticky
+		*out = new(TCPSticky)
+		**out = **in
+	}
+	if in.Locality != nil {
+		in, out := &in.Locality, &out.Locality
+		*out = new(Locality)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(TCPServerHealthCheck)
+		**out = **in
+	}
 	return
 }
 
@@ -1561,6 +2035,11 @@ func (in *TCPService) DeepCopyInto(out *TCPService) {
 		*out = new(TCPWeightedRoundRobin)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(TCPFailover)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1574,6 +2053,22 @@ func (in *TCPService) DeepCopy() *TCPService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPSticky) DeepCopyInto(out *TCPSticky) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPSticky.
+func (in *TCPSticky) DeepCopy() *TCPSticky {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPSticky)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPWRRService) DeepCopyInto(out *TCPWRRService) {
 	*out = *in
@@ -1750,6 +2245,21 @@ func (in *UDPConfiguration) DeepCopyInto(out *UDPConfiguration) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Middlewares != nil {
+		in, out := &in.Middlewares, &out.Middlewares
+		*out = make(map[string]*UDPMiddleware, len(*in))
+		for key, val := range *in {
+			var outVal *UDPMiddleware
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(UDPMiddleware)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
@@ -1763,6 +2273,115 @@ func (in *UDPConfiguration) DeepCopy() *UDPConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDPFailover) DeepCopyInto(out *UDPFailover) {
+	*out = *in
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]UDPFailoverTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPFailover.
+func (in *UDPFailover) DeepCopy() *UDPFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(UDPFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDPFailoverTier) DeepCopyInto(out *UDPFailoverTier) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]UDPWRRService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPFailoverTier.
+func (in *UDPFailoverTier) DeepCopy() *UDPFailoverTier {
+	if in == nil {
+		return nil
+	}
+	out := new(UDPFailoverTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDPIPAllowList) DeepCopyInto(out *UDPIPAllowList) {
+	*out = *in
+	if in.SourceRange != nil {
+		in, out := &in.SourceRange, &out.SourceRange
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPIPAllowList.
+func (in *UDPIPAllowList) DeepCopy() *UDPIPAllowList {
+	if in == nil {
+		return nil
+	}
+	out := new(UDPIPAllowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDPMiddleware) DeepCopyInto(out *UDPMiddleware) {
+	*out = *in
+	if in.IPAllowList != nil {
+		in, out := &in.IPAllowList, &out.IPAllowList
+		*out = new(UDPIPAllowList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(UDPRateLimit)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPMiddleware.
+func (in *UDPMiddleware) DeepCopy() *UDPMiddleware {
+	if in == nil {
+		return nil
+	}
+	out := new(UDPMiddleware)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDPRateLimit) DeepCopyInto(out *UDPRateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPRateLimit.
+func (in *UDPRateLimit) DeepCopy() *UDPRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(UDPRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDPRouter) DeepCopyInto(out *UDPRouter) {
 	*out = *in
@@ -1771,6 +2390,11 @@ func (in *UDPRouter) DeepCopyInto(out *UDPRouter) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Middlewares != nil {
+		in, out := &in.Middlewares, &out.Middlewares
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1800,6 +2424,22 @@ func (in *UDPServer) DeepCopy() *UDPServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDPServerHealthCheck) DeepCopyInto(out *UDPServerHealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPServerHealthCheck.
+func (in *UDPServerHealthCheck) DeepCopy() *UDPServerHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UDPServerHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDPServersLoadBalancer) DeepCopyInto(out *UDPServersLoadBalancer) {
 	*out = *in
@@ -1808,6 +2448,16 @@ func (in *UDPServersLoadBalancer) DeepCopyInto(out *UDPServersLoadBalancer) {
 		*out = make([]UDPServer, len(*in))
 		copy(*out, *in)
 	}
+	if in.Sticky != nil {
+		in, out := &in.Sticky, &out.Sticky
+		*out = new(UDPSticky)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(UDPServerHealthCheck)
+		**out = **in
+	}
 	return
 }
 
@@ -1834,6 +2484,11 @@ func (in *UDPService) DeepCopyInto(out *UDPService) {
 		*out = new(UDPWeightedRoundRobin)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(UDPFailover)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1847,6 +2502,22 @@ func (in *UDPService) DeepCopy() *UDPService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDPSticky) DeepCopyInto(out *UDPSticky) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPSticky.
+func (in *UDPSticky) DeepCopy() *UDPSticky {
+	if in == nil {
+		return nil
+	}
+	out := new(UDPSticky)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDPWRRService) DeepCopyInto(out *UDPWRRService) {
 	*out = *in
@@ -201,12 +201,21 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.tcp.services.Service1.loadbalancer.TerminationDelay":      "42",
 		"traefik.tcp.services.Service1.loadbalancer.proxyProtocol":         "true",
 
-		"traefik.udp.routers.Router0.entrypoints":                "foobar, fiibar",
-		"traefik.udp.routers.Router0.service":                    "foobar",
-		"traefik.udp.routers.Router1.entrypoints":                "foobar, fiibar",
-		"traefik.udp.routers.Router1.service":                    "foobar",
-		"traefik.udp.services.Service0.loadbalancer.server.Port": "42",
-		"traefik.udp.services.Service1.loadbalancer.server.Port": "42",
+		"traefik.udp.middlewares.Middleware0.ipallowlist.sourcerange": "foobar, fiibar",
+		"traefik.udp.middlewares.Middleware1.ratelimit.average":       "42",
+		"traefik.udp.middlewares.Middleware1.ratelimit.burst":         "42",
+		"traefik.udp.middlewares.Middleware1.ratelimit.period":        "1s",
+		"traefik.udp.routers.Router0.entrypoints":                     "foobar, fiibar",
+		"traefik.udp.routers.Router0.middlewares":                     "foobar, fiibar",
+		"traefik.udp.routers.Router0.rule":                            "foobar",
+		"traefik.udp.routers.Router0.priority":                        "42",
+		"traefik.udp.routers.Router0.service":                         "foobar",
+		"traefik.udp.routers.Router1.entrypoints":                     "foobar, fiibar",
+		"traefik.udp.routers.Router1.rule":                            "foobar",
+		"traefik.udp.routers.Router1.priority":                        "42",
+		"traefik.udp.routers.Router1.service":                         "foobar",
+		"traefik.udp.services.Service0.loadbalancer.server.Port":      "42",
+		"traefik.udp.services.Service1.loadbalancer.server.Port":      "42",
 	}
 
 	configuration, err := DecodeConfiguration(labels)
@@ -286,14 +295,36 @@ func TestDecodeConfiguration(t *testing.T) {
 						"foobar",
 						"fiibar",
 					},
-					Service: "foobar",
+					Middlewares: []string{
+						"foobar",
+						"fiibar",
+					},
+					Service:  "foobar",
+					Rule:     "foobar",
+					Priority: 42,
 				},
 				"Router1": {
 					EntryPoints: []string{
 						"foobar",
 						"fiibar",
 					},
-					Service: "foobar",
+					Service:  "foobar",
+					Rule:     "foobar",
+					Priority: 42,
+				},
+			},
+			Middlewares: map[string]*dynamic.UDPMiddleware{
+				"Middleware0": {
+					IPAllowList: &dynamic.UDPIPAllowList{
+						SourceRange: []string{"foobar", "fiibar"},
+					},
+				},
+				"Middleware1": {
+					RateLimit: &dynamic.UDPRateLimit{
+						Average: 42,
+						Burst:   42,
+						Period:  ptypes.Duration(time.Second),
+					},
 				},
 			},
 			Services: map[string]*dynamic.UDPService{
@@ -781,14 +812,36 @@ func TestEncodeConfiguration(t *testing.T) {
 						"foobar",
 						"fiibar",
 					},
-					Service: "foobar",
+					Middlewares: []string{
+						"foobar",
+						"fiibar",
+					},
+					Service:  "foobar",
+					Rule:     "foobar",
+					Priority: 42,
 				},
 				"Router1": {
 					EntryPoints: []string{
 						"foobar",
 						"fiibar",
 					},
-					Service: "foobar",
+					Service:  "foobar",
+					Rule:     "foobar",
+					Priority: 42,
+				},
+			},
+			Middlewares: map[string]*dynamic.UDPMiddleware{
+				"Middleware0": {
+					IPAllowList: &dynamic.UDPIPAllowList{
+						SourceRange: []string{"foobar", "fiibar"},
+					},
+				},
+				"Middleware1": {
+					RateLimit: &dynamic.UDPRateLimit{
+						Average: 42,
+						Burst:   42,
+						Period:  ptypes.Duration(time.Second),
+					},
 				},
 			},
 			Services: map[string]*dynamic.UDPService{
@@ -1224,6 +1277,8 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.Address":                                 "foobar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.AuthResponseHeaders":                     "foobar, fiibar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.AuthRequestHeaders":                      "foobar, fiibar",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.ForwardBody":                             "false",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.MaxBodySize":                             "0",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.TLS.CA":                                  "foobar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.TLS.CAOptional":                          "true",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.TLS.Cert":                                "foobar",
@@ -1340,11 +1395,14 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Timeout":              "1000000000",
 		"traefik.HTTP.Services.Service0.LoadBalancer.PassHostHeader":                   "true",
 		"traefik.HTTP.Services.Service0.LoadBalancer.ResponseForwarding.FlushInterval": "1000000000",
+		"traefik.HTTP.Services.Service0.LoadBalancer.SlowStart":                        "0",
 		"traefik.HTTP.Services.Service0.LoadBalancer.server.Port":                      "8080",
 		"traefik.HTTP.Services.Service0.LoadBalancer.server.Scheme":                    "foobar",
 		"traefik.HTTP.Services.Service0.LoadBalancer.Sticky.Cookie.Name":               "foobar",
 		"traefik.HTTP.Services.Service0.LoadBalancer.Sticky.Cookie.HTTPOnly":           "true",
 		"traefik.HTTP.Services.Service0.LoadBalancer.Sticky.Cookie.Secure":             "false",
+		"traefik.HTTP.Services.Service0.LoadBalancer.Sticky.Cookie.MaxAge":             "0",
+		"traefik.HTTP.Services.Service0.LoadBalancer.Sticky.Cookie.Opaque":             "false",
 		"traefik.HTTP.Services.Service1.LoadBalancer.HealthCheck.Headers.name0":        "foobar",
 		"traefik.HTTP.Services.Service1.LoadBalancer.HealthCheck.Headers.name1":        "foobar",
 		"traefik.HTTP.Services.Service1.LoadBalancer.HealthCheck.Hostname":             "foobar",
@@ -1356,6 +1414,7 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Services.Service1.LoadBalancer.HealthCheck.Timeout":              "1000000000",
 		"traefik.HTTP.Services.Service1.LoadBalancer.PassHostHeader":                   "true",
 		"traefik.HTTP.Services.Service1.LoadBalancer.ResponseForwarding.FlushInterval": "1000000000",
+		"traefik.HTTP.Services.Service1.LoadBalancer.SlowStart":                        "0",
 		"traefik.HTTP.Services.Service1.LoadBalancer.server.Port":                      "8080",
 		"traefik.HTTP.Services.Service1.LoadBalancer.server.Scheme":                    "foobar",
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Headers.name0":        "foobar",
@@ -1379,12 +1438,21 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.TCP.Services.Service1.LoadBalancer.server.Port":      "42",
 		"traefik.TCP.Services.Service1.LoadBalancer.TerminationDelay": "42",
 
-		"traefik.UDP.Routers.Router0.EntryPoints":                "foobar, fiibar",
-		"traefik.UDP.Routers.Router0.Service":                    "foobar",
-		"traefik.UDP.Routers.Router1.EntryPoints":                "foobar, fiibar",
-		"traefik.UDP.Routers.Router1.Service":                    "foobar",
-		"traefik.UDP.Services.Service0.LoadBalancer.server.Port": "42",
-		"traefik.UDP.Services.Service1.LoadBalancer.server.Port": "42",
+		"traefik.UDP.Middlewares.Middleware0.IPAllowList.SourceRange": "foobar, fiibar",
+		"traefik.UDP.Middlewares.Middleware1.RateLimit.Average":       "42",
+		"traefik.UDP.Middlewares.Middleware1.RateLimit.Burst":         "42",
+		"traefik.UDP.Middlewares.Middleware1.RateLimit.Period":        "1000000000",
+		"traefik.UDP.Routers.Router0.EntryPoints":                     "foobar, fiibar",
+		"traefik.UDP.Routers.Router0.Middlewares":                     "foobar, fiibar",
+		"traefik.UDP.Routers.Router0.Rule":                            "foobar",
+		"traefik.UDP.Routers.Router0.Priority":                        "42",
+		"traefik.UDP.Routers.Router0.Service":                         "foobar",
+		"traefik.UDP.Routers.Router1.EntryPoints":                     "foobar, fiibar",
+		"traefik.UDP.Routers.Router1.Rule":                            "foobar",
+		"traefik.UDP.Routers.Router1.Priority":                        "42",
+		"traefik.UDP.Routers.Router1.Service":                         "foobar",
+		"traefik.UDP.Services.Service0.LoadBalancer.server.Port":      "42",
+		"traefik.UDP.Services.Service1.LoadBalancer.server.Port":      "42",
 	}
 
 	for key, val := range expected {
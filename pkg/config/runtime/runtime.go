@@ -20,6 +20,8 @@ const (
 const (
 	StatusUp   = "UP"
 	StatusDown = "DOWN"
+	// StatusEjected is the status of a server ejected by the outlier detection.
+	StatusEjected = "EJECTED"
 )
 
 // Configuration holds the information about the currently running traefik instance.
@@ -31,6 +33,7 @@ type Configuration struct {
 	TCPRouters     map[string]*TCPRouterInfo     `json:"tcpRouters,omitempty"`
 	TCPServices    map[string]*TCPServiceInfo    `json:"tcpServices,omitempty"`
 	UDPRouters     map[string]*UDPRouterInfo     `json:"udpRouters,omitempty"`
+	UDPMiddlewares map[string]*UDPMiddlewareInfo `json:"udpMiddlewares,omitempty"`
 	UDPServices    map[string]*UDPServiceInfo    `json:"udpServices,omitempty"`
 }
 
@@ -105,6 +108,13 @@ func NewConfig(conf dynamic.Configuration) *Configuration {
 				runtimeConfig.UDPServices[k] = &UDPServiceInfo{UDPService: v, Status: StatusEnabled}
 			}
 		}
+
+		if len(conf.UDP.Middlewares) > 0 {
+			runtimeConfig.UDPMiddlewares = make(map[string]*UDPMiddlewareInfo, len(conf.UDP.Middlewares))
+			for k, v := range conf.UDP.Middlewares {
+				runtimeConfig.UDPMiddlewares[k] = &UDPMiddlewareInfo{UDPMiddleware: v, Status: StatusEnabled}
+			}
+		}
 	}
 
 	return runtimeConfig
@@ -211,6 +221,14 @@ func (c *Configuration) PopulateUsedBy() {
 			continue
 		}
 
+		for _, midName := range routerInfo.UDPRouter.Middlewares {
+			fullMidName := getQualifiedName(providerName, midName)
+			if _, ok := c.UDPMiddlewares[fullMidName]; !ok {
+				continue
+			}
+			c.UDPMiddlewares[fullMidName].UsedBy = append(c.UDPMiddlewares[fullMidName].UsedBy, routerName)
+		}
+
 		serviceName := getQualifiedName(providerName, routerInfo.UDPRouter.Service)
 		if _, ok := c.UDPServices[serviceName]; !ok {
 			continue
@@ -226,6 +244,15 @@ func (c *Configuration) PopulateUsedBy() {
 
 		sort.Strings(c.UDPServices[k].UsedBy)
 	}
+
+	for midName, mid := range c.UDPMiddlewares {
+		// lazily initialize Status in case caller forgot to do it
+		if mid.Status == "" {
+			mid.Status = StatusEnabled
+		}
+
+		sort.Strings(c.UDPMiddlewares[midName].UsedBy)
+	}
 }
 
 func contains(entryPoints []string, entryPointName string) bool {
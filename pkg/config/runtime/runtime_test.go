@@ -663,6 +663,72 @@ func TestPopulateUsedBy(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "UDP middleware used by two UDP routers",
+			conf: &runtime.Configuration{
+				UDPServices: map[string]*runtime.UDPServiceInfo{
+					"foo-service@myprovider": {
+						UDPService: &dynamic.UDPService{
+							LoadBalancer: &dynamic.UDPServersLoadBalancer{
+								Servers: []dynamic.UDPServer{
+									{
+										Address: "127.0.0.1:8085",
+									},
+								},
+							},
+						},
+					},
+				},
+				UDPMiddlewares: map[string]*runtime.UDPMiddlewareInfo{
+					"allowlist@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							IPAllowList: &dynamic.UDPIPAllowList{
+								SourceRange: []string{"10.0.0.0/8"},
+							},
+						},
+					},
+					"ratelimit@myprovider": {
+						UDPMiddleware: &dynamic.UDPMiddleware{
+							RateLimit: &dynamic.UDPRateLimit{
+								Average: 10,
+							},
+						},
+					},
+				},
+				UDPRouters: map[string]*runtime.UDPRouterInfo{
+					"foo@myprovider": {
+						UDPRouter: &dynamic.UDPRouter{
+							EntryPoints: []string{"dns"},
+							Service:     "foo-service@myprovider",
+							Middlewares: []string{"allowlist", "ratelimit@myprovider"},
+							Rule:        "ClientIP(`10.0.0.0/8`)",
+						},
+					},
+					"bar@myprovider": {
+						UDPRouter: &dynamic.UDPRouter{
+							EntryPoints: []string{"dns"},
+							Service:     "foo-service@myprovider",
+							Middlewares: []string{"ratelimit"},
+						},
+					},
+				},
+			},
+			expected: runtime.Configuration{
+				UDPServices: map[string]*runtime.UDPServiceInfo{
+					"foo-service@myprovider": {
+						UsedBy: []string{"bar@myprovider", "foo@myprovider"},
+					},
+				},
+				UDPMiddlewares: map[string]*runtime.UDPMiddlewareInfo{
+					"allowlist@myprovider": {
+						UsedBy: []string{"foo@myprovider"},
+					},
+					"ratelimit@myprovider": {
+						UsedBy: []string{"bar@myprovider", "foo@myprovider"},
+					},
+				},
+			},
+		},
 	}
 	for _, test := range testCases {
 		test := test
@@ -687,6 +753,16 @@ func TestPopulateUsedBy(t *testing.T) {
 				require.NotNil(t, runtimeConf.TCPServices[key])
 				assert.Equal(t, expectedTCPService.UsedBy, runtimeConf.TCPServices[key].UsedBy)
 			}
+
+			for key, expectedUDPService := range test.expected.UDPServices {
+				require.NotNil(t, runtimeConf.UDPServices[key])
+				assert.Equal(t, expectedUDPService.UsedBy, runtimeConf.UDPServices[key].UsedBy)
+			}
+
+			for key, expectedUDPMiddleware := range test.expected.UDPMiddlewares {
+				require.NotNil(t, runtimeConf.UDPMiddlewares[key])
+				assert.Equal(t, expectedUDPMiddleware.UsedBy, runtimeConf.UDPMiddlewares[key].UsedBy)
+			}
 		})
 	}
 }
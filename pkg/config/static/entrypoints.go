@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v2/pkg/types"
@@ -61,6 +62,25 @@ type HTTPConfig struct {
 	Redirections *Redirections `description:"Set of redirection" json:"redirections,omitempty" toml:"redirections,omitempty" yaml:"redirections,omitempty" export:"true"`
 	Middlewares  []string      `description:"Default middlewares for the routers linked to the entry point." json:"middlewares,omitempty" toml:"middlewares,omitempty" yaml:"middlewares,omitempty" export:"true"`
 	TLS          *TLSConfig    `description:"Default TLS configuration for the routers linked to the entry point." json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+	JWT          *JWTConfig    `description:"Validates the bearer tokens of the requests before routing, for the JWTClaim matcher." json:"jwt,omitempty" toml:"jwt,omitempty" yaml:"jwt,omitempty" export:"true"`
+}
+
+// JWTConfig is the configuration of the validation of the bearer tokens of an entry point, before routing.
+type JWTConfig struct {
+	Secret                 string          `description:"Secret used to verify the HMAC signed tokens." json:"secret,omitempty" toml:"secret,omitempty" yaml:"secret,omitempty" loggable:"false"`
+	PublicKey              string          `description:"PEM encoded public key used to verify the signed tokens." json:"publicKey,omitempty" toml:"publicKey,omitempty" yaml:"publicKey,omitempty"`
+	JWKSURL                string          `description:"URL of the JSON Web Key Set used to verify the signed tokens." json:"jwksUrl,omitempty" toml:"jwksUrl,omitempty" yaml:"jwksUrl,omitempty" export:"true"`
+	JWKSRefreshInterval    ptypes.Duration `description:"Interval between two refreshes of the JSON Web Key Set." json:"jwksRefreshInterval,omitempty" toml:"jwksRefreshInterval,omitempty" yaml:"jwksRefreshInterval,omitempty" export:"true"`
+	Algorithms             []string        `description:"Accepted signing algorithms." json:"algorithms,omitempty" toml:"algorithms,omitempty" yaml:"algorithms,omitempty" export:"true"`
+	Issuer                 string          `description:"Expected issuer of the tokens." json:"issuer,omitempty" toml:"issuer,omitempty" yaml:"issuer,omitempty" export:"true"`
+	Audience               []string        `description:"Accepted audiences of the tokens." json:"audience,omitempty" toml:"audience,omitempty" yaml:"audience,omitempty" export:"true"`
+	ClockSkew              ptypes.Duration `description:"Tolerated clock skew when checking the validity period of the tokens." json:"clockSkew,omitempty" toml:"clockSkew,omitempty" yaml:"clockSkew,omitempty" export:"true"`
+	AllowMissingExpiration bool            `description:"Accepts the tokens without expiration time." json:"allowMissingExpiration,omitempty" toml:"allowMissingExpiration,omitempty" yaml:"allowMissingExpiration,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (c *JWTConfig) SetDefaults() {
+	c.JWKSRefreshInterval = ptypes.Duration(time.Hour)
 }
 
 // HTTP2Config is the HTTP2 configuration of an entry point.
@@ -76,6 +76,8 @@ type Configuration struct {
 
 	HostResolver *types.HostResolverConfig `description:"Enable CNAME Flattening." json:"hostResolver,omitempty" toml:"hostResolver,omitempty" yaml:"hostResolver,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
 
+	Locality *types.Locality `description:"Location of this Traefik instance, for the locality-aware load-balancing." json:"locality,omitempty" toml:"locality,omitempty" yaml:"locality,omitempty" export:"true"`
+
 	CertificatesResolvers map[string]CertificateResolver `description:"Certificates resolvers configuration." json:"certificatesResolvers,omitempty" toml:"certificatesResolvers,omitempty" yaml:"certificatesResolvers,omitempty" export:"true"`
 
 	// Deprecated.
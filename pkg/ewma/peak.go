@@ -0,0 +1,62 @@
+package ewma
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultDecay is the default time constant of the decay of a Peak.
+const DefaultDecay = 10 * time.Second
+
+// Peak is a peak-sensitive exponentially weighted moving average of latencies.
+// It immediately jumps to any latency higher than its current value,
+// and exponentially decays towards lower latencies, as time passes.
+type Peak struct {
+	decay time.Duration
+
+	mu    sync.Mutex
+	value float64 // in nanoseconds.
+	stamp time.Time
+}
+
+// NewPeak creates a new Peak with the given decay time constant.
+func NewPeak(decay time.Duration) *Peak {
+	if decay <= 0 {
+		decay = DefaultDecay
+	}
+
+	return &Peak{decay: decay}
+}
+
+// Observe records the given latency, measured at the given time.
+func (p *Peak) Observe(latency time.Duration, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rtt := float64(latency)
+
+	switch {
+	case p.stamp.IsZero(), rtt > p.value:
+		p.value = rtt
+	default:
+		elapsed := now.Sub(p.stamp)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+
+		w := math.Exp(-float64(elapsed) / float64(p.decay))
+		p.value = p.value*w + rtt*(1-w)
+	}
+
+	p.stamp = now
+}
+
+// Value returns the current average, in nanoseconds.
+// It returns zero while no latency has been observed.
+func (p *Peak) Value() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.value
+}
@@ -0,0 +1,73 @@
+package ewma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeak_Observe(t *testing.T) {
+	start := time.Now()
+
+	testCases := []struct {
+		desc      string
+		latencies []time.Duration
+		offsets   []time.Duration
+		expected  time.Duration
+	}{
+		{
+			desc:     "no latency observed",
+			expected: 0,
+		},
+		{
+			desc:      "first latency",
+			latencies: []time.Duration{10 * time.Millisecond},
+			offsets:   []time.Duration{0},
+			expected:  10 * time.Millisecond,
+		},
+		{
+			desc:      "peak is taken immediately",
+			latencies: []time.Duration{10 * time.Millisecond, 50 * time.Millisecond},
+			offsets:   []time.Duration{0, 0},
+			expected:  50 * time.Millisecond,
+		},
+		{
+			desc:      "lower latency at the same time is ignored",
+			latencies: []time.Duration{50 * time.Millisecond, 10 * time.Millisecond},
+			offsets:   []time.Duration{0, 0},
+			expected:  50 * time.Millisecond,
+		},
+		{
+			desc:      "lower latency long after is taken",
+			latencies: []time.Duration{50 * time.Millisecond, 10 * time.Millisecond},
+			offsets:   []time.Duration{0, time.Hour},
+			expected:  10 * time.Millisecond,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			peak := NewPeak(0)
+			for i, latency := range test.latencies {
+				peak.Observe(latency, start.Add(test.offsets[i]))
+			}
+
+			assert.InDelta(t, float64(test.expected), peak.Value(), float64(time.Microsecond))
+		})
+	}
+}
+
+func TestPeak_Observe_decay(t *testing.T) {
+	start := time.Now()
+
+	peak := NewPeak(time.Second)
+	peak.Observe(100*time.Millisecond, start)
+	peak.Observe(0, start.Add(time.Second))
+
+	// After one time constant, the previous value weighs 1/e.
+	assert.InDelta(t, 36.79*float64(time.Millisecond), peak.Value(), float64(10*time.Microsecond))
+}
@@ -133,6 +133,11 @@ func (shc *ServiceHealthChecker) Launch(ctx context.Context) {
 
 				shc.balancer.SetStatus(ctx, proxyName, up)
 
+				// The status of an ejected server is reported by the outlier detector.
+				if outliers, ok := shc.balancer.(ejector); ok && outliers.isEjected(proxyName) {
+					continue
+				}
+
 				statusStr := runtime.StatusDown
 				if up {
 					statusStr = runtime.StatusUp
@@ -0,0 +1,134 @@
+package healthcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+const (
+	// TCPModeTCP is the TCP health check mode opening a plain connection.
+	TCPModeTCP = "tcp"
+	// TCPModeTLS is the TCP health check mode performing a TLS handshake.
+	TCPModeTLS = "tls"
+)
+
+// maxReplySize is the maximum number of bytes read from a server to match the expected reply.
+const maxReplySize = 4096
+
+// TCPServiceHealthChecker checks the health of the servers of a TCP service,
+// by connecting to them and optionally exchanging a payload and a reply.
+type TCPServiceHealthChecker struct {
+	*targetsChecker
+
+	config *dynamic.TCPServerHealthCheck
+	expect *regexp.Regexp
+}
+
+// NewTCPServiceHealthChecker returns a health checker of the given TCP servers.
+func NewTCPServiceHealthChecker(ctx context.Context, config *dynamic.TCPServerHealthCheck, service StatusSetter, info *runtime.TCPServiceInfo, targets map[string]string) (*TCPServiceHealthChecker, error) {
+	switch config.Mode {
+	case "", TCPModeTCP, TCPModeTLS:
+	default:
+		return nil, fmt.Errorf("unknown health check mode %q", config.Mode)
+	}
+
+	var expect *regexp.Regexp
+	if config.Expect != "" {
+		var err error
+		expect, err = regexp.Compile(config.Expect)
+		if err != nil {
+			return nil, fmt.Errorf("compiling expected reply pattern: %w", err)
+		}
+	}
+
+	shc := &TCPServiceHealthChecker{
+		targetsChecker: newTargetsChecker(ctx, config.Interval, config.Timeout, service, info, targets),
+		config:         config,
+		expect:         expect,
+	}
+	shc.probe = shc.executeHealthCheck
+
+	return shc, nil
+}
+
+// executeHealthCheck probes the server at the given address, until the deadline of the context,
+// and returns an error with a meaningful description if the health check failed.
+func (shc *TCPServiceHealthChecker) executeHealthCheck(ctx context.Context, address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("parsing server address: %w", err)
+	}
+
+	if shc.config.Port != 0 {
+		port = strconv.Itoa(shc.config.Port)
+	}
+
+	conn, err := shc.dial(ctx, host, net.JoinHostPort(host, port))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		return fmt.Errorf("setting deadline: %w", err)
+	}
+
+	if shc.config.Payload != "" {
+		if _, err = conn.Write([]byte(shc.config.Payload)); err != nil {
+			return fmt.Errorf("sending payload: %w", err)
+		}
+	}
+
+	if shc.expect == nil {
+		return nil
+	}
+
+	reply := make([]byte, maxReplySize)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return fmt.Errorf("reading reply: %w", err)
+	}
+
+	if !shc.expect.Match(reply[:n]) {
+		return fmt.Errorf("reply %q does not match %q", reply[:n], shc.config.Expect)
+	}
+
+	return nil
+}
+
+func (shc *TCPServiceHealthChecker) dial(ctx context.Context, host, address string) (net.Conn, error) {
+	if shc.config.Mode != TCPModeTLS {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("dialing server: %w", err)
+		}
+		return conn, nil
+	}
+
+	serverName := shc.config.ServerName
+	if serverName == "" {
+		serverName = host
+	}
+
+	dialer := tls.Dialer{
+		Config: &tls.Config{
+			ServerName:         serverName,
+			InsecureSkipVerify: shc.config.InsecureSkipVerify,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("dialing server with TLS: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,256 @@
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+// startTCPServer starts a TCP server writing to each connection the next reply of the sequence.
+// An empty reply means that the connection is closed without writing anything.
+// It calls the given 'done' function once all the replies have been depleted.
+func startTCPServer(t *testing.T, done func(), replies ...string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	sequence := HealthSequence[int]{}
+	for i := range replies {
+		sequence.sequence = append(sequence.sequence, i)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			if sequence.IsEmpty() {
+				_ = conn.Close()
+				if done != nil {
+					done()
+				}
+				continue
+			}
+
+			if reply := replies[sequence.Pop()]; reply != "" {
+				_, _ = conn.Write([]byte(reply))
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestTCPServiceHealthChecker_executeHealthCheck(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      dynamic.TCPServerHealthCheck
+		reply       string
+		usePort     bool
+		useTLS      bool
+		closed      bool
+		expectedErr bool
+	}{
+		{
+			desc:   "connect",
+			config: dynamic.TCPServerHealthCheck{},
+		},
+		{
+			desc:        "connect to a closed server",
+			config:      dynamic.TCPServerHealthCheck{},
+			closed:      true,
+			expectedErr: true,
+		},
+		{
+			desc:   "reply matching",
+			config: dynamic.TCPServerHealthCheck{Payload: "PING\r\n", Expect: "^\\+PONG"},
+			reply:  "+PONG\r\n",
+		},
+		{
+			desc:        "reply not matching",
+			config:      dynamic.TCPServerHealthCheck{Payload: "PING\r\n", Expect: "^\\+PONG"},
+			reply:       "-ERR\r\n",
+			expectedErr: true,
+		},
+		{
+			desc:        "no reply",
+			config:      dynamic.TCPServerHealthCheck{Expect: "^220"},
+			expectedErr: true,
+		},
+		{
+			desc:    "health check port",
+			config:  dynamic.TCPServerHealthCheck{Expect: "^220"},
+			reply:   "220 ready",
+			usePort: true,
+		},
+		{
+			desc:   "TLS handshake",
+			config: dynamic.TCPServerHealthCheck{Mode: TCPModeTLS, InsecureSkipVerify: true},
+			useTLS: true,
+		},
+		{
+			desc:        "TLS handshake with an untrusted certificate",
+			config:      dynamic.TCPServerHealthCheck{Mode: TCPModeTLS},
+			useTLS:      true,
+			expectedErr: true,
+		},
+		{
+			desc:        "TLS handshake with a plain server",
+			config:      dynamic.TCPServerHealthCheck{Mode: TCPModeTLS, InsecureSkipVerify: true},
+			reply:       "220 ready",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var address string
+			switch {
+			case test.useTLS:
+				server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+				t.Cleanup(server.Close)
+				address = strings.TrimPrefix(server.URL, "https://")
+			case test.closed:
+				listener, err := net.Listen("tcp4", "127.0.0.1:0")
+				require.NoError(t, err)
+				address = listener.Addr().String()
+				require.NoError(t, listener.Close())
+			default:
+				address = startTCPServer(t, nil, test.reply)
+			}
+
+			config := test.config
+			config.Interval = ptypes.Duration(time.Second)
+			config.Timeout = ptypes.Duration(200 * time.Millisecond)
+
+			target := address
+			if test.usePort {
+				_, port, err := net.SplitHostPort(address)
+				require.NoError(t, err)
+
+				config.Port, err = strconv.Atoi(port)
+				require.NoError(t, err)
+
+				// The port of the server address is not used.
+				target = "127.0.0.1:1"
+			}
+
+			hc, err := NewTCPServiceHealthChecker(context.Background(), &config, nil, nil, nil)
+			require.NoError(t, err)
+
+			err = hc.check(context.Background(), target)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewTCPServiceHealthChecker_invalidConfig(t *testing.T) {
+	_, err := NewTCPServiceHealthChecker(context.Background(), &dynamic.TCPServerHealthCheck{Mode: "foo"}, nil, nil, nil)
+	assert.Error(t, err)
+
+	_, err = NewTCPServiceHealthChecker(context.Background(), &dynamic.TCPServerHealthCheck{Expect: "("}, nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestTCPServiceHealthChecker_Launch(t *testing.T) {
+	testCases := []struct {
+		desc                  string
+		replies               []string
+		expNumRemovedServers  int
+		expNumUpsertedServers int
+		targetStatus          string
+	}{
+		{
+			desc:                  "healthy server staying healthy",
+			replies:               []string{"+PONG"},
+			expNumUpsertedServers: 1,
+			targetStatus:          runtime.StatusUp,
+		},
+		{
+			desc:                 "healthy server becoming sick",
+			replies:              []string{"-ERR"},
+			expNumRemovedServers: 1,
+			targetStatus:         runtime.StatusDown,
+		},
+		{
+			desc:                  "healthy server toggling to sick and back to healthy",
+			replies:               []string{"", "+PONG"},
+			expNumRemovedServers:  1,
+			expNumUpsertedServers: 1,
+			targetStatus:          runtime.StatusUp,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			// The context is passed to the health check and
+			// canonically canceled by the test server once all expected connections have been received.
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			address := startTCPServer(t, cancel, test.replies...)
+
+			lb := &testLoadBalancer{RWMutex: &sync.RWMutex{}}
+
+			config := &dynamic.TCPServerHealthCheck{
+				Payload:  "PING\r\n",
+				Expect:   "PONG",
+				Interval: ptypes.Duration(500 * time.Millisecond),
+				Timeout:  ptypes.Duration(400 * time.Millisecond),
+			}
+
+			serviceInfo := &runtime.TCPServiceInfo{}
+			hc, err := NewTCPServiceHealthChecker(ctx, config, lb, serviceInfo, map[string]string{"test": address})
+			require.NoError(t, err)
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			go func() {
+				hc.Launch(ctx)
+				wg.Done()
+			}()
+
+			select {
+			case <-time.After(time.Duration(len(test.replies)+2) * time.Second):
+				t.Fatal("test did not complete in time")
+			case <-ctx.Done():
+				wg.Wait()
+			}
+
+			lb.Lock()
+			defer lb.Unlock()
+
+			assert.Equal(t, test.expNumRemovedServers, lb.numRemovedServers, "removed servers")
+			assert.Equal(t, test.expNumUpsertedServers, lb.numUpsertedServers, "upserted servers")
+			assert.Equal(t, map[string]string{address: test.targetStatus}, serviceInfo.GetAllStatus())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+// maxDatagramSize is the maximum size of a UDP datagram.
+const maxDatagramSize = 65535
+
+// UDPServiceHealthChecker checks the health of the servers of a UDP service,
+// by sending them a payload and expecting a reply.
+type UDPServiceHealthChecker struct {
+	*targetsChecker
+
+	config *dynamic.UDPServerHealthCheck
+	expect *regexp.Regexp
+}
+
+// NewUDPServiceHealthChecker returns a health checker of the given UDP servers.
+func NewUDPServiceHealthChecker(ctx context.Context, config *dynamic.UDPServerHealthCheck, service StatusSetter, info *runtime.UDPServiceInfo, targets map[string]string) (*UDPServiceHealthChecker, error) {
+	var expect *regexp.Regexp
+	if config.Expect != "" {
+		var err error
+		expect, err = regexp.Compile(config.Expect)
+		if err != nil {
+			return nil, fmt.Errorf("compiling expected reply pattern: %w", err)
+		}
+	}
+
+	shc := &UDPServiceHealthChecker{
+		targetsChecker: newTargetsChecker(ctx, config.Interval, config.Timeout, service, info, targets),
+		config:         config,
+		expect:         expect,
+	}
+	shc.probe = shc.executeHealthCheck
+
+	return shc, nil
+}
+
+// executeHealthCheck probes the server at the given address, until the deadline of the context,
+// and returns an error with a meaningful description if the health check failed.
+func (shc *UDPServiceHealthChecker) executeHealthCheck(ctx context.Context, address string) error {
+	if shc.config.Port != 0 {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return fmt.Errorf("parsing server address: %w", err)
+		}
+		address = net.JoinHostPort(host, strconv.Itoa(shc.config.Port))
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return fmt.Errorf("dialing server: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		return fmt.Errorf("setting deadline: %w", err)
+	}
+
+	if _, err = conn.Write([]byte(shc.config.Payload)); err != nil {
+		return fmt.Errorf("sending payload: %w", err)
+	}
+
+	reply := make([]byte, maxDatagramSize)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return fmt.Errorf("reading reply: %w", err)
+	}
+
+	if shc.expect != nil && !shc.expect.Match(reply[:n]) {
+		return fmt.Errorf("reply %q does not match %q", reply[:n], shc.config.Expect)
+	}
+
+	return nil
+}
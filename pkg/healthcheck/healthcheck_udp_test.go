@@ -0,0 +1,215 @@
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+// startUDPServer starts a UDP server replying to each datagram with the next reply of the sequence.
+// An empty reply means that the datagram is ignored.
+// It calls the given 'done' function once all the replies have been depleted.
+func startUDPServer(t *testing.T, done func(), replies ...string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	sequence := HealthSequence[int]{}
+	for i := range replies {
+		sequence.sequence = append(sequence.sequence, i)
+	}
+
+	go func() {
+		buf := make([]byte, maxDatagramSize)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			if sequence.IsEmpty() {
+				if done != nil {
+					done()
+				}
+				continue
+			}
+
+			reply := replies[sequence.Pop()]
+			if reply == "" {
+				continue
+			}
+
+			_, _ = conn.WriteTo([]byte(reply), addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestUDPServiceHealthChecker_executeHealthCheck(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      dynamic.UDPServerHealthCheck
+		reply       string
+		usePort     bool
+		expectedErr bool
+	}{
+		{
+			desc:   "any reply",
+			config: dynamic.UDPServerHealthCheck{Payload: "ping"},
+			reply:  "whatever",
+		},
+		{
+			desc:   "reply matching",
+			config: dynamic.UDPServerHealthCheck{Payload: "ping", Expect: "^po"},
+			reply:  "pong",
+		},
+		{
+			desc:        "reply not matching",
+			config:      dynamic.UDPServerHealthCheck{Payload: "ping", Expect: "^po"},
+			reply:       "nope",
+			expectedErr: true,
+		},
+		{
+			desc:        "no reply",
+			config:      dynamic.UDPServerHealthCheck{Payload: "ping"},
+			expectedErr: true,
+		},
+		{
+			desc:    "health check port",
+			config:  dynamic.UDPServerHealthCheck{Payload: "ping"},
+			reply:   "pong",
+			usePort: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			address := startUDPServer(t, nil, test.reply)
+
+			config := test.config
+			config.Interval = ptypes.Duration(time.Second)
+			config.Timeout = ptypes.Duration(200 * time.Millisecond)
+
+			target := address
+			if test.usePort {
+				_, port, err := net.SplitHostPort(address)
+				require.NoError(t, err)
+
+				config.Port, err = strconv.Atoi(port)
+				require.NoError(t, err)
+
+				// The port of the server address is not used.
+				target = "127.0.0.1:1"
+			}
+
+			hc, err := NewUDPServiceHealthChecker(context.Background(), &config, nil, nil, nil)
+			require.NoError(t, err)
+
+			err = hc.check(context.Background(), target)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewUDPServiceHealthChecker_invalidExpect(t *testing.T) {
+	_, err := NewUDPServiceHealthChecker(context.Background(), &dynamic.UDPServerHealthCheck{Expect: "("}, nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestUDPServiceHealthChecker_Launch(t *testing.T) {
+	testCases := []struct {
+		desc                  string
+		replies               []string
+		expNumRemovedServers  int
+		expNumUpsertedServers int
+		targetStatus          string
+	}{
+		{
+			desc:                  "healthy server staying healthy",
+			replies:               []string{"pong"},
+			expNumUpsertedServers: 1,
+			targetStatus:          runtime.StatusUp,
+		},
+		{
+			desc:                 "healthy server becoming sick",
+			replies:              []string{"nope"},
+			expNumRemovedServers: 1,
+			targetStatus:         runtime.StatusDown,
+		},
+		{
+			desc:                  "healthy server toggling to sick and back to healthy",
+			replies:               []string{"", "pong"},
+			expNumRemovedServers:  1,
+			expNumUpsertedServers: 1,
+			targetStatus:          runtime.StatusUp,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			// The context is passed to the health check and
+			// canonically canceled by the test server once all expected datagrams have been received.
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			address := startUDPServer(t, cancel, test.replies...)
+
+			lb := &testLoadBalancer{RWMutex: &sync.RWMutex{}}
+
+			config := &dynamic.UDPServerHealthCheck{
+				Payload:  "ping",
+				Expect:   "pong",
+				Interval: ptypes.Duration(500 * time.Millisecond),
+				Timeout:  ptypes.Duration(400 * time.Millisecond),
+			}
+
+			serviceInfo := &runtime.UDPServiceInfo{}
+			hc, err := NewUDPServiceHealthChecker(ctx, config, lb, serviceInfo, map[string]string{"test": address})
+			require.NoError(t, err)
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			go func() {
+				hc.Launch(ctx)
+				wg.Done()
+			}()
+
+			select {
+			case <-time.After(time.Duration(len(test.replies)+2) * time.Second):
+				t.Fatal("test did not complete in time")
+			case <-ctx.Done():
+				wg.Wait()
+			}
+
+			lb.Lock()
+			defer lb.Unlock()
+
+			assert.Equal(t, test.expNumRemovedServers, lb.numRemovedServers, "removed servers")
+			assert.Equal(t, test.expNumUpsertedServers, lb.numUpsertedServers, "upserted servers")
+			assert.Equal(t, map[string]string{address: test.targetStatus}, serviceInfo.GetAllStatus())
+		})
+	}
+}
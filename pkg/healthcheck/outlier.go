@@ -0,0 +1,240 @@
+package healthcheck
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+// outlierCheckInterval is the interval at which the ejected servers are checked for their return.
+const outlierCheckInterval = time.Second
+
+// ejector is implemented by the StatusSetters which can keep a server down, whatever its health check result.
+type ejector interface {
+	isEjected(childName string) bool
+}
+
+type outlierServer struct {
+	// up is the status of the server given by the active health checks.
+	up                bool
+	consecutiveErrors int
+	// ejections is the number of consecutive ejections of the server,
+	// used to compute its ejection time.
+	ejections    int
+	ejectedUntil time.Time
+	returnedAt   time.Time
+}
+
+func (s *outlierServer) ejected() bool {
+	return !s.ejectedUntil.IsZero()
+}
+
+// OutlierDetector is a passive health checker, which ejects the servers failing the forwarded requests.
+// When used along with active health checks, it is given the status of the servers by the ServiceHealthChecker,
+// and a server is only up for the balancer when it is both healthy and not ejected.
+type OutlierDetector struct {
+	balancer StatusSetter
+	info     *runtime.ServiceInfo
+	metrics  metricsHealthCheck
+
+	consecutiveErrors  int
+	baseEjectionTime   time.Duration
+	maxEjectionTime    time.Duration
+	maxEjectionPercent int
+
+	targets map[string]*url.URL
+
+	mu      sync.Mutex
+	servers map[string]*outlierServer
+	ejected int
+
+	now func() time.Time
+}
+
+// NewOutlierDetector returns a new OutlierDetector for the given targets, keyed by server name.
+func NewOutlierDetector(ctx context.Context, metrics metricsHealthCheck, config *dynamic.OutlierDetection, service StatusSetter, info *runtime.ServiceInfo, targets map[string]*url.URL) *OutlierDetector {
+	logger := log.Ctx(ctx)
+
+	consecutiveErrors := config.ConsecutiveErrors
+	if consecutiveErrors <= 0 {
+		logger.Error().Msg("Outlier detection consecutive errors smaller than one")
+		consecutiveErrors = dynamic.DefaultOutlierConsecutiveErrors
+	}
+
+	baseEjectionTime := time.Duration(config.BaseEjectionTime)
+	if baseEjectionTime <= 0 {
+		logger.Error().Msg("Outlier detection base ejection time smaller than zero")
+		baseEjectionTime = time.Duration(dynamic.DefaultOutlierBaseEjectionTime)
+	}
+
+	maxEjectionTime := time.Duration(config.MaxEjectionTime)
+	if maxEjectionTime < baseEjectionTime {
+		logger.Warn().Msgf("Outlier detection max ejection time should not be lower than the base ejection time. Max ejection time set to the base ejection time (%s).", baseEjectionTime)
+		maxEjectionTime = baseEjectionTime
+	}
+
+	return &OutlierDetector{
+		balancer:           service,
+		info:               info,
+		metrics:            metrics,
+		consecutiveErrors:  consecutiveErrors,
+		baseEjectionTime:   baseEjectionTime,
+		maxEjectionTime:    maxEjectionTime,
+		maxEjectionPercent: config.MaxEjectionPercent,
+		targets:            targets,
+		servers:            make(map[string]*outlierServer),
+		now:                time.Now,
+	}
+}
+
+// SetStatus sets the status of the given server, as given by the active health checks.
+// The status is only propagated to the balancer when the server is not ejected.
+func (d *OutlierDetector) SetStatus(ctx context.Context, childName string, up bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	server := d.server(childName)
+	server.up = up
+
+	if server.ejected() {
+		return
+	}
+
+	d.balancer.SetStatus(ctx, childName, up)
+}
+
+// Observe records the outcome of a request forwarded to the given server,
+// and ejects the server when it reaches the number of consecutive errors.
+func (d *OutlierDetector) Observe(ctx context.Context, childName string, failed bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	server := d.server(childName)
+	if !failed {
+		server.consecutiveErrors = 0
+		return
+	}
+
+	server.consecutiveErrors++
+	if server.consecutiveErrors < d.consecutiveErrors || server.ejected() || !server.up {
+		return
+	}
+
+	logger := log.Ctx(ctx).With().Str("targetURL", d.target(childName)).Logger()
+
+	if (d.ejected+1)*100 > d.maxEjectionPercent*len(d.targets) {
+		logger.Warn().Msg("Outlier detected, but the maximum percentage of ejected servers is reached.")
+		return
+	}
+
+	now := d.now()
+
+	// The ejection time is reset once the server has been back for the max ejection time.
+	if !server.returnedAt.IsZero() && now.Sub(server.returnedAt) >= d.maxEjectionTime {
+		server.ejections = 0
+	}
+
+	ejectionTime := d.baseEjectionTime << server.ejections
+	if ejectionTime > d.maxEjectionTime || ejectionTime <= 0 {
+		ejectionTime = d.maxEjectionTime
+	}
+
+	server.ejections++
+	server.consecutiveErrors = 0
+	server.ejectedUntil = now.Add(ejectionTime)
+	d.ejected++
+
+	logger.Warn().Msgf("Outlier detected, server ejected for %s.", ejectionTime)
+
+	d.balancer.SetStatus(ctx, childName, false)
+	d.report(childName, runtime.StatusEjected, 0)
+}
+
+// Launch periodically returns the servers at the end of their ejection time.
+func (d *OutlierDetector) Launch(ctx context.Context) {
+	ticker := time.NewTicker(outlierCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			d.checkEjections(ctx)
+		}
+	}
+}
+
+func (d *OutlierDetector) checkEjections(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	for name, server := range d.servers {
+		if !server.ejected() || now.Before(server.ejectedUntil) {
+			continue
+		}
+
+		server.ejectedUntil = time.Time{}
+		server.returnedAt = now
+		d.ejected--
+
+		log.Ctx(ctx).Info().Str("targetURL", d.target(name)).Msg("Ejected server is back.")
+
+		d.balancer.SetStatus(ctx, name, server.up)
+
+		if server.up {
+			d.report(name, runtime.StatusUp, 1)
+		} else {
+			d.report(name, runtime.StatusDown, 0)
+		}
+	}
+}
+
+// isEjected reports whether the given server is ejected.
+func (d *OutlierDetector) isEjected(childName string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	server, ok := d.servers[childName]
+	return ok && server.ejected()
+}
+
+// server returns the state of the given server. It must be called with the mutex held.
+func (d *OutlierDetector) server(childName string) *outlierServer {
+	server, ok := d.servers[childName]
+	if !ok {
+		// Servers are considered up by default.
+		server = &outlierServer{up: true}
+		d.servers[childName] = server
+	}
+
+	return server
+}
+
+func (d *OutlierDetector) target(childName string) string {
+	if target, ok := d.targets[childName]; ok {
+		return target.String()
+	}
+
+	return childName
+}
+
+func (d *OutlierDetector) report(childName, status string, serverUpMetricValue float64) {
+	target := d.target(childName)
+
+	d.info.UpdateServerStatus(target, status)
+
+	if d.metrics != nil {
+		d.metrics.ServiceServerUpGauge().
+			With("service", childName).
+			With("url", target).
+			Set(serverUpMetricValue)
+	}
+}
@@ -0,0 +1,194 @@
+package healthcheck
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/testhelpers"
+)
+
+func TestOutlierDetector_eject(t *testing.T) {
+	detector, balancer, clock := newTestOutlierDetector(&dynamic.OutlierDetection{ConsecutiveErrors: 3, MaxEjectionPercent: 50}, "first", "second")
+
+	ctx := context.Background()
+
+	// A successful request resets the consecutive errors.
+	detector.Observe(ctx, "first", true)
+	detector.Observe(ctx, "first", true)
+	detector.Observe(ctx, "first", false)
+	detector.Observe(ctx, "first", true)
+	detector.Observe(ctx, "first", true)
+
+	assert.False(t, detector.isEjected("first"))
+	assert.Empty(t, balancer.statuses())
+
+	detector.Observe(ctx, "first", true)
+
+	assert.True(t, detector.isEjected("first"))
+	assert.Equal(t, map[string]bool{"first": false}, balancer.statuses())
+	assert.Equal(t, runtime.StatusEjected, detector.info.GetAllStatus()["http://first"])
+	assert.Equal(t, float64(0), detector.metrics.ServiceServerUpGauge().(*testhelpers.CollectingGauge).GaugeValue)
+
+	// The server is still ejected before the end of its ejection time.
+	clock.add(29 * time.Second)
+	detector.checkEjections(ctx)
+
+	assert.True(t, detector.isEjected("first"))
+
+	clock.add(time.Second)
+	detector.checkEjections(ctx)
+
+	assert.False(t, detector.isEjected("first"))
+	assert.Equal(t, map[string]bool{"first": true}, balancer.statuses())
+	assert.Equal(t, runtime.StatusUp, detector.info.GetAllStatus()["http://first"])
+	assert.Equal(t, float64(1), detector.metrics.ServiceServerUpGauge().(*testhelpers.CollectingGauge).GaugeValue)
+}
+
+func TestOutlierDetector_ejectionTime(t *testing.T) {
+	detector, _, clock := newTestOutlierDetector(&dynamic.OutlierDetection{
+		ConsecutiveErrors:  1,
+		BaseEjectionTime:   ptypes.Duration(10 * time.Second),
+		MaxEjectionTime:    ptypes.Duration(30 * time.Second),
+		MaxEjectionPercent: 100,
+	}, "first")
+
+	ctx := context.Background()
+
+	var ejectionTimes []time.Duration
+	for i := 0; i < 4; i++ {
+		detector.Observe(ctx, "first", true)
+
+		start := clock.get()
+		for detector.isEjected("first") {
+			clock.add(time.Second)
+			detector.checkEjections(ctx)
+		}
+
+		ejectionTimes = append(ejectionTimes, clock.get().Sub(start))
+	}
+
+	assert.Equal(t, []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}, ejectionTimes)
+
+	// The ejection time is reset once the server has been back for the max ejection time.
+	clock.add(30 * time.Second)
+	detector.Observe(ctx, "first", true)
+
+	start := clock.get()
+	for detector.isEjected("first") {
+		clock.add(time.Second)
+		detector.checkEjections(ctx)
+	}
+
+	assert.Equal(t, 10*time.Second, clock.get().Sub(start))
+}
+
+func TestOutlierDetector_maxEjectionPercent(t *testing.T) {
+	detector, balancer, _ := newTestOutlierDetector(&dynamic.OutlierDetection{ConsecutiveErrors: 1, MaxEjectionPercent: 50}, "first", "second", "third", "fourth")
+
+	ctx := context.Background()
+	for _, name := range []string{"first", "second", "third", "fourth"} {
+		detector.Observe(ctx, name, true)
+	}
+
+	assert.Equal(t, map[string]bool{"first": false, "second": false}, balancer.statuses())
+	assert.False(t, detector.isEjected("third"))
+	assert.False(t, detector.isEjected("fourth"))
+}
+
+func TestOutlierDetector_activeHealthCheck(t *testing.T) {
+	detector, balancer, clock := newTestOutlierDetector(&dynamic.OutlierDetection{ConsecutiveErrors: 1, MaxEjectionPercent: 100}, "first")
+
+	ctx := context.Background()
+
+	detector.Observe(ctx, "first", true)
+	assert.Equal(t, map[string]bool{"first": false}, balancer.statuses())
+
+	// The active health checks do not bring back an ejected server.
+	detector.SetStatus(ctx, "first", true)
+	assert.Equal(t, map[string]bool{"first": false}, balancer.statuses())
+
+	detector.SetStatus(ctx, "first", false)
+
+	clock.add(time.Minute)
+	detector.checkEjections(ctx)
+
+	// The server is back, but with the status given by the active health checks.
+	assert.False(t, detector.isEjected("first"))
+	assert.Equal(t, map[string]bool{"first": false}, balancer.statuses())
+	assert.Equal(t, runtime.StatusDown, detector.info.GetAllStatus()["http://first"])
+
+	// A server down for the active health checks is not ejected.
+	detector.Observe(ctx, "first", true)
+	assert.False(t, detector.isEjected("first"))
+
+	detector.SetStatus(ctx, "first", true)
+	assert.Equal(t, map[string]bool{"first": true}, balancer.statuses())
+}
+
+func newTestOutlierDetector(config *dynamic.OutlierDetection, names ...string) (*OutlierDetector, *statusRecorder, *testClock) {
+	defaults := &dynamic.OutlierDetection{}
+	defaults.SetDefaults()
+
+	if config.BaseEjectionTime == 0 {
+		config.BaseEjectionTime = defaults.BaseEjectionTime
+	}
+	if config.MaxEjectionTime == 0 {
+		config.MaxEjectionTime = defaults.MaxEjectionTime
+	}
+
+	targets := make(map[string]*url.URL)
+	for _, name := range names {
+		targets[name] = &url.URL{Scheme: "http", Host: name}
+	}
+
+	balancer := &statusRecorder{status: make(map[string]bool)}
+	clock := &testClock{now: time.Now()}
+
+	detector := NewOutlierDetector(context.Background(), &MetricsMock{&testhelpers.CollectingGauge{}}, config, balancer, &runtime.ServiceInfo{}, targets)
+	detector.now = clock.get
+
+	return detector, balancer, clock
+}
+
+type statusRecorder struct {
+	mu     sync.Mutex
+	status map[string]bool
+}
+
+func (s *statusRecorder) SetStatus(_ context.Context, childName string, up bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status[childName] = up
+}
+
+func (s *statusRecorder) statuses() map[string]bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make(map[string]bool, len(s.status))
+	for name, up := range s.status {
+		statuses[name] = up
+	}
+
+	return statuses
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) get() time.Time {
+	return c.now
+}
+
+func (c *testClock) add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
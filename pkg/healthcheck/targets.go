@@ -0,0 +1,116 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+// serverStatusUpdater records the status of the servers in the runtime information of a service.
+type serverStatusUpdater interface {
+	UpdateServerStatus(server, status string)
+}
+
+// targetsChecker probes the server addresses of a service at regular intervals,
+// and reports their status to the balancer, and to the runtime information, of the service.
+// It is shared by the TCP and UDP health checkers, which only differ by their probe.
+type targetsChecker struct {
+	balancer StatusSetter
+	info     serverStatusUpdater
+
+	interval time.Duration
+	timeout  time.Duration
+
+	targets map[string]string // server addresses, keyed by server name.
+
+	// probe returns an error with a meaningful description if the server at the given address is unhealthy.
+	probe func(ctx context.Context, address string) error
+}
+
+func newTargetsChecker(ctx context.Context, interval, timeout ptypes.Duration, balancer StatusSetter, info serverStatusUpdater, targets map[string]string) *targetsChecker {
+	logger := log.Ctx(ctx)
+
+	checker := &targetsChecker{
+		balancer: balancer,
+		info:     info,
+		interval: time.Duration(interval),
+		timeout:  time.Duration(timeout),
+		targets:  targets,
+	}
+
+	if checker.interval <= 0 {
+		logger.Error().Msg("Health check interval smaller than zero")
+		checker.interval = time.Duration(dynamic.DefaultHealthCheckInterval)
+	}
+
+	if checker.timeout <= 0 {
+		logger.Error().Msg("Health check timeout smaller than zero")
+		checker.timeout = time.Duration(dynamic.DefaultHealthCheckTimeout)
+	}
+
+	if checker.timeout >= checker.interval {
+		logger.Warn().Msgf("Health check timeout should be lower than the health check interval. Interval set to timeout + 1 second (%s).", checker.interval)
+		checker.interval = checker.timeout + time.Second
+	}
+
+	return checker
+}
+
+// Launch runs the health checks until the given context is canceled.
+func (c *targetsChecker) Launch(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			for serverName, address := range c.targets {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				up := true
+				if err := c.check(ctx, address); err != nil {
+					// The context is canceled when the dynamic configuration is refreshed.
+					if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+						return
+					}
+
+					log.Ctx(ctx).Warn().
+						Str("targetAddress", address).
+						Err(err).
+						Msg("Health check failed.")
+
+					up = false
+				}
+
+				c.balancer.SetStatus(ctx, serverName, up)
+
+				statusStr := runtime.StatusDown
+				if up {
+					statusStr = runtime.StatusUp
+				}
+
+				c.info.UpdateServerStatus(address, statusStr)
+			}
+		}
+	}
+}
+
+// check probes the server at the given address, within the health check timeout.
+func (c *targetsChecker) check(ctx context.Context, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.probe(ctx, address)
+}
@@ -0,0 +1,108 @@
+package locality
+
+import (
+	"math/rand"
+
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// Tier is the locality tier of a server, relative to the location of Traefik.
+type Tier int
+
+// The locality tiers, from the most preferred one.
+const (
+	// TierZone is the tier of the servers in the zone of Traefik.
+	TierZone Tier = iota
+	// TierRegion is the tier of the servers in the region, but not in the zone, of Traefik.
+	TierRegion
+	// TierOther is the tier of the other servers, including the ones without any known location.
+	TierOther
+
+	tierCount
+)
+
+// Capacities holds the capacity of the servers of each tier, indexed by tier.
+type Capacities [tierCount]Capacity
+
+// Capacity is the capacity of the servers of a tier, i.e. the sum of their weights.
+type Capacity struct {
+	Healthy float64
+	Total   float64
+}
+
+// Add adds the given server weight to the capacity of the given tier.
+func (c *Capacities) Add(tier Tier, weight float64, healthy bool) {
+	c[tier].Total += weight
+	if healthy {
+		c[tier].Healthy += weight
+	}
+}
+
+// Picker picks the tier of the servers to which a request is sent.
+// A request is sent to the first tier with healthy servers, unless the healthy capacity of this tier
+// is below the configured minimum, in which case the traffic spills over to the next tiers,
+// in proportion to the missing capacity.
+type Picker struct {
+	zone   string
+	region string
+	// minHealthy is the minimum ratio of healthy capacity of a tier, for it to get all the traffic.
+	minHealthy float64
+
+	random func() float64
+}
+
+// NewPicker returns a new Picker for a Traefik instance at the given location.
+// It returns nil if the location is unknown, as the tier of the servers cannot be known then.
+func NewPicker(config *dynamic.Locality, location *types.Locality) *Picker {
+	if location == nil || location.Zone == "" && location.Region == "" {
+		return nil
+	}
+
+	minHealthyPercent := config.MinHealthyPercent
+	if minHealthyPercent <= 0 || minHealthyPercent > 100 {
+		minHealthyPercent = dynamic.DefaultLocalityMinHealthyPercent
+	}
+
+	return &Picker{
+		zone:       location.Zone,
+		region:     location.Region,
+		minHealthy: float64(minHealthyPercent) / 100,
+		random:     rand.Float64,
+	}
+}
+
+// Tier returns the tier of a server in the given zone and region.
+func (p *Picker) Tier(zone, region string) Tier {
+	switch {
+	case p.zone != "" && zone == p.zone:
+		return TierZone
+	case p.region != "" && region == p.region:
+		return TierRegion
+	default:
+		return TierOther
+	}
+}
+
+// Pick returns the tier to which the next request is sent, given the capacity of each tier.
+// It returns false if no tier has healthy servers.
+func (p *Picker) Pick(capacities Capacities) (Tier, bool) {
+	last := Tier(-1)
+	for tier := TierZone; tier < tierCount; tier++ {
+		capacity := capacities[tier]
+		if capacity.Healthy <= 0 {
+			continue
+		}
+
+		last = tier
+
+		// The share of the traffic kept by the tier is proportional to its healthy capacity, up to the minimum.
+		share := capacity.Healthy / capacity.Total / p.minHealthy
+		if share >= 1 || p.random() < share {
+			return tier, true
+		}
+	}
+
+	// The traffic which spilled over from the last tier with healthy servers is sent back to it.
+	return last, last >= 0
+}
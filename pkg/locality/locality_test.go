@@ -0,0 +1,156 @@
+package locality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestNewPicker(t *testing.T) {
+	assert.Nil(t, NewPicker(&dynamic.Locality{}, nil))
+	assert.Nil(t, NewPicker(&dynamic.Locality{}, &types.Locality{}))
+
+	picker := NewPicker(&dynamic.Locality{}, &types.Locality{Zone: "eu-west-1a"})
+	assert.InDelta(t, 0.7, picker.minHealthy, 1e-9)
+
+	picker = NewPicker(&dynamic.Locality{MinHealthyPercent: 50}, &types.Locality{Region: "eu-west-1"})
+	assert.InDelta(t, 0.5, picker.minHealthy, 1e-9)
+}
+
+func TestPicker_Tier(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		zone     string
+		region   string
+		expected Tier
+	}{
+		{
+			desc:     "same zone",
+			zone:     "eu-west-1a",
+			region:   "eu-west-1",
+			expected: TierZone,
+		},
+		{
+			desc:     "same zone without region",
+			zone:     "eu-west-1a",
+			expected: TierZone,
+		},
+		{
+			desc:     "same region",
+			zone:     "eu-west-1b",
+			region:   "eu-west-1",
+			expected: TierRegion,
+		},
+		{
+			desc:     "other region",
+			zone:     "us-east-1a",
+			region:   "us-east-1",
+			expected: TierOther,
+		},
+		{
+			desc:     "unknown location",
+			expected: TierOther,
+		},
+	}
+
+	picker := NewPicker(&dynamic.Locality{}, &types.Locality{Zone: "eu-west-1a", Region: "eu-west-1"})
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, picker.Tier(test.zone, test.region))
+		})
+	}
+}
+
+func TestPicker_Pick(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		capacities Capacities
+		random     float64
+		expected   Tier
+		expectedOk bool
+	}{
+		{
+			desc:       "healthy zone",
+			capacities: Capacities{{Healthy: 3, Total: 3}, {Healthy: 3, Total: 3}, {Healthy: 3, Total: 3}},
+			random:     0.99,
+			expected:   TierZone,
+			expectedOk: true,
+		},
+		{
+			desc:       "zone above the minimum",
+			capacities: Capacities{{Healthy: 3, Total: 4}, {Healthy: 3, Total: 3}, {}},
+			random:     0.99,
+			expected:   TierZone,
+			expectedOk: true,
+		},
+		{
+			desc:       "zone below the minimum, request kept in the zone",
+			capacities: Capacities{{Healthy: 1, Total: 2}, {Healthy: 3, Total: 3}, {}},
+			random:     0.7,
+			expected:   TierZone,
+			expectedOk: true,
+		},
+		{
+			desc:       "zone below the minimum, request spilled over",
+			capacities: Capacities{{Healthy: 1, Total: 2}, {Healthy: 3, Total: 3}, {}},
+			random:     0.72,
+			expected:   TierRegion,
+			expectedOk: true,
+		},
+		{
+			desc:       "no server in the zone",
+			capacities: Capacities{{}, {}, {Healthy: 1, Total: 1}},
+			random:     0.99,
+			expected:   TierOther,
+			expectedOk: true,
+		},
+		{
+			desc:       "zone down",
+			capacities: Capacities{{Total: 2}, {Healthy: 1, Total: 1}, {Healthy: 1, Total: 1}},
+			random:     0.99,
+			expected:   TierRegion,
+			expectedOk: true,
+		},
+		{
+			desc:       "spilled over traffic sent back to the last healthy tier",
+			capacities: Capacities{{Healthy: 1, Total: 2}, {Total: 2}, {}},
+			random:     0.99,
+			expected:   TierZone,
+			expectedOk: true,
+		},
+		{
+			desc:       "all servers down",
+			capacities: Capacities{{Total: 2}, {Total: 2}, {Total: 2}},
+			expected:   -1,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			picker := NewPicker(&dynamic.Locality{MinHealthyPercent: 70}, &types.Locality{Zone: "eu-west-1a", Region: "eu-west-1"})
+			picker.random = func() float64 { return test.random }
+
+			tier, ok := picker.Pick(test.capacities)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expected, tier)
+		})
+	}
+}
+
+func TestCapacities_Add(t *testing.T) {
+	var capacities Capacities
+	capacities.Add(TierZone, 2, true)
+	capacities.Add(TierZone, 1, false)
+	capacities.Add(TierOther, 3, true)
+
+	assert.Equal(t, Capacities{{Healthy: 2, Total: 3}, {}, {Healthy: 3, Total: 3}}, capacities)
+}
@@ -38,14 +38,16 @@ const (
 	ddRouterReqsBytesName    = "router.requests.bytes.total"
 	ddRouterRespsBytesName   = "router.responses.bytes.total"
 
-	ddServiceReqsName         = "service.request.total"
-	ddServiceReqsTLSName      = "service.request.tls.total"
-	ddServiceReqsDurationName = "service.request.duration"
-	ddServiceRetriesName      = "service.retries.total"
-	ddServiceOpenConnsName    = "service.connections.open"
-	ddServiceServerUpName     = "service.server.up"
-	ddServiceReqsBytesName    = "service.requests.bytes.total"
-	ddServiceRespsBytesName   = "service.responses.bytes.total"
+	ddServiceReqsName             = "service.request.total"
+	ddServiceReqsTLSName          = "service.request.tls.total"
+	ddServiceReqsDurationName     = "service.request.duration"
+	ddServiceRetriesName          = "service.retries.total"
+	ddServiceOpenConnsName        = "service.connections.open"
+	ddServiceServerUpName         = "service.server.up"
+	ddServiceReqsBytesName        = "service.requests.bytes.total"
+	ddServiceRespsBytesName       = "service.responses.bytes.total"
+	ddServiceMirrorMismatchesName = "service.mirror.mismatches.total"
+	ddServiceHedgedReqsName       = "service.hedged.requests.total"
 )
 
 // RegisterDatadog registers the metrics pusher if this didn't happen yet and creates a datadog Registry instance.
@@ -100,6 +102,8 @@ func RegisterDatadog(ctx context.Context, config *types.Datadog) Registry {
 		registry.serviceServerUpGauge = datadogClient.NewGauge(ddServiceServerUpName)
 		registry.serviceReqsBytesCounter = datadogClient.NewCounter(ddServiceReqsBytesName, 1.0)
 		registry.serviceRespsBytesCounter = datadogClient.NewCounter(ddServiceRespsBytesName, 1.0)
+		registry.serviceMirrorMismatchesCounter = datadogClient.NewCounter(ddServiceMirrorMismatchesName, 1.0)
+		registry.serviceHedgedReqsCounter = datadogClient.NewCounter(ddServiceHedgedReqsName, 1.0)
 	}
 
 	return registry
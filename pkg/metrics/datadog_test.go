@@ -76,6 +76,8 @@ func testDatadogRegistry(t *testing.T, metricsPrefix string, datadogRegistry Reg
 		metricsPrefix + ".service.server.up:1.000000|g|#service:test,url:http://127.0.0.1,one:two\n",
 		metricsPrefix + ".service.requests.bytes.total:1.000000|c|#service:test,code:200,method:GET\n",
 		metricsPrefix + ".service.responses.bytes.total:1.000000|c|#service:test,code:200,method:GET\n",
+		metricsPrefix + ".service.mirror.mismatches.total:1.000000|c|#service:test,mirror:shadow,field:status\n",
+		metricsPrefix + ".service.hedged.requests.total:1.000000|c|#service:test,result:won\n",
 	}
 
 	udp.ShouldReceiveAll(t, expected, func() {
@@ -111,5 +113,7 @@ func testDatadogRegistry(t *testing.T, metricsPrefix string, datadogRegistry Reg
 		datadogRegistry.ServiceServerUpGauge().With("service", "test", "url", "http://127.0.0.1", "one", "two").Set(1)
 		datadogRegistry.ServiceReqsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
 		datadogRegistry.ServiceRespsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+		datadogRegistry.ServiceMirrorMismatchesCounter().With("service", "test", "mirror", "shadow", "field", "status").Add(1)
+		datadogRegistry.ServiceHedgedReqsCounter().With("service", "test", "result", "won").Add(1)
 	})
 }
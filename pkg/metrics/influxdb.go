@@ -43,14 +43,16 @@ const (
 	influxDBRouterReqsBytesName    = "traefik.router.requests.bytes.total"
 	influxDBRouterRespsBytesName   = "traefik.router.responses.bytes.total"
 
-	influxDBServiceReqsName         = "traefik.service.requests.total"
-	influxDBServiceReqsTLSName      = "traefik.service.requests.tls.total"
-	influxDBServiceReqsDurationName = "traefik.service.request.duration"
-	influxDBServiceRetriesTotalName = "traefik.service.retries.total"
-	influxDBServiceOpenConnsName    = "traefik.service.connections.open"
-	influxDBServiceServerUpName     = "traefik.service.server.up"
-	influxDBServiceReqsBytesName    = "traefik.service.requests.bytes.total"
-	influxDBServiceRespsBytesName   = "traefik.service.responses.bytes.total"
+	influxDBServiceReqsName             = "traefik.service.requests.total"
+	influxDBServiceReqsTLSName          = "traefik.service.requests.tls.total"
+	influxDBServiceReqsDurationName     = "traefik.service.request.duration"
+	influxDBServiceRetriesTotalName     = "traefik.service.retries.total"
+	influxDBServiceOpenConnsName        = "traefik.service.connections.open"
+	influxDBServiceServerUpName         = "traefik.service.server.up"
+	influxDBServiceReqsBytesName        = "traefik.service.requests.bytes.total"
+	influxDBServiceRespsBytesName       = "traefik.service.responses.bytes.total"
+	influxDBServiceMirrorMismatchesName = "traefik.service.mirror.mismatches.total"
+	influxDBServiceHedgedReqsName       = "traefik.service.hedged.requests.total"
 )
 
 const (
@@ -105,6 +107,8 @@ func RegisterInfluxDB(ctx context.Context, config *types.InfluxDB) Registry {
 		registry.serviceServerUpGauge = influxDBClient.NewGauge(influxDBServiceServerUpName)
 		registry.serviceReqsBytesCounter = influxDBClient.NewCounter(influxDBServiceReqsBytesName)
 		registry.serviceRespsBytesCounter = influxDBClient.NewCounter(influxDBServiceRespsBytesName)
+		registry.serviceMirrorMismatchesCounter = influxDBClient.NewCounter(influxDBServiceMirrorMismatchesName)
+		registry.serviceHedgedReqsCounter = influxDBClient.NewCounter(influxDBServiceHedgedReqsName)
 	}
 
 	return registry
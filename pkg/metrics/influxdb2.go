@@ -88,6 +88,8 @@ func RegisterInfluxDB2(ctx context.Context, config *types.InfluxDB2) Registry {
 		registry.serviceServerUpGauge = influxDB2Store.NewGauge(influxDBServiceServerUpName)
 		registry.serviceReqsBytesCounter = influxDB2Store.NewCounter(influxDBServiceReqsBytesName)
 		registry.serviceRespsBytesCounter = influxDB2Store.NewCounter(influxDBServiceRespsBytesName)
+		registry.serviceMirrorMismatchesCounter = influxDB2Store.NewCounter(influxDBServiceMirrorMismatchesName)
+		registry.serviceHedgedReqsCounter = influxDB2Store.NewCounter(influxDBServiceHedgedReqsName)
 	}
 
 	return registry
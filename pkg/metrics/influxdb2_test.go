@@ -116,6 +116,8 @@ func TestInfluxDB2(t *testing.T) {
 		`(traefik\.service\.server\.up,service=test,url=http://127.0.0.1 value=1) [\d]{19}`,
 		`(traefik\.service\.requests\.bytes\.total,code=200,method=GET,service=test count=1) [\d]{19}`,
 		`(traefik\.service\.responses\.bytes\.total,code=200,method=GET,service=test count=1) [\d]{19}`,
+		`(traefik\.service\.mirror\.mismatches\.total,field=status,mirror=shadow,service=test count=1) [\d]{19}`,
+		`(traefik\.service\.hedged\.requests\.total,result=won,service=test count=1) [\d]{19}`,
 	}
 
 	influxDB2Registry.ServiceReqsCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
@@ -125,6 +127,8 @@ func TestInfluxDB2(t *testing.T) {
 	influxDB2Registry.ServiceServerUpGauge().With("service", "test", "url", "http://127.0.0.1").Set(1)
 	influxDB2Registry.ServiceReqsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
 	influxDB2Registry.ServiceRespsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+	influxDB2Registry.ServiceMirrorMismatchesCounter().With("service", "test", "mirror", "shadow", "field", "status").Add(1)
+	influxDB2Registry.ServiceHedgedReqsCounter().With("service", "test", "result", "won").Add(1)
 	msgService := <-c
 
 	assertMessage(t, *msgService, expectedService)
@@ -116,6 +116,8 @@ func TestInfluxDB(t *testing.T) {
 		`(traefik\.service\.connections\.open,service=test,tag1=val1 value=1) [\d]{19}`,
 		`(traefik\.service\.requests\.bytes\.total,code=200,method=GET,service=test,tag1=val1 count=1) [\d]{19}`,
 		`(traefik\.service\.responses\.bytes\.total,code=200,method=GET,service=test,tag1=val1 count=1) [\d]{19}`,
+		`(traefik\.service\.mirror\.mismatches\.total,field=status,mirror=shadow,service=test,tag1=val1 count=1) [\d]{19}`,
+		`(traefik\.service\.hedged\.requests\.total,result=won,service=test,tag1=val1 count=1) [\d]{19}`,
 	}
 
 	msgService := udp.ReceiveString(t, func() {
@@ -129,6 +131,8 @@ func TestInfluxDB(t *testing.T) {
 		influxDBRegistry.ServiceServerUpGauge().With("service", "test", "url", "http://127.0.0.1").Set(1)
 		influxDBRegistry.ServiceReqsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
 		influxDBRegistry.ServiceRespsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+		influxDBRegistry.ServiceMirrorMismatchesCounter().With("service", "test", "mirror", "shadow", "field", "status").Add(1)
+		influxDBRegistry.ServiceHedgedReqsCounter().With("service", "test", "result", "won").Add(1)
 	})
 
 	assertMessage(t, msgService, expectedService)
@@ -238,6 +242,8 @@ func TestInfluxDBHTTP(t *testing.T) {
 		`(traefik\.service\.connections\.open,service=test value=1) [\d]{19}`,
 		`(traefik\.service\.requests\.bytes\.total,code=200,method=GET,service=test count=1) [\d]{19}`,
 		`(traefik\.service\.responses\.bytes\.total,code=200,method=GET,service=test count=1) [\d]{19}`,
+		`(traefik\.service\.mirror\.mismatches\.total,field=status,mirror=shadow,service=test count=1) [\d]{19}`,
+		`(traefik\.service\.hedged\.requests\.total,result=won,service=test count=1) [\d]{19}`,
 	}
 
 	influxDBRegistry.ServiceReqsCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
@@ -250,6 +256,8 @@ func TestInfluxDBHTTP(t *testing.T) {
 	influxDBRegistry.ServiceServerUpGauge().With("service", "test", "url", "http://127.0.0.1").Set(1)
 	influxDBRegistry.ServiceReqsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
 	influxDBRegistry.ServiceRespsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+	influxDBRegistry.ServiceMirrorMismatchesCounter().With("service", "test", "mirror", "shadow", "field", "status").Add(1)
+	influxDBRegistry.ServiceHedgedReqsCounter().With("service", "test", "result", "won").Add(1)
 	msgService := <-c
 
 	assertMessage(t, *msgService, expectedService)
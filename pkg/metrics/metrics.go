@@ -58,6 +58,8 @@ type Registry interface {
 	ServiceServerUpGauge() metrics.Gauge
 	ServiceReqsBytesCounter() metrics.Counter
 	ServiceRespsBytesCounter() metrics.Counter
+	ServiceMirrorMismatchesCounter() metrics.Counter
+	ServiceHedgedReqsCounter() metrics.Counter
 }
 
 // NewVoidRegistry is a noop implementation of metrics.Registry.
@@ -95,6 +97,8 @@ func NewMultiRegistry(registries []Registry) Registry {
 	var serviceServerUpGauge []metrics.Gauge
 	var serviceReqsBytesCounter []metrics.Counter
 	var serviceRespsBytesCounter []metrics.Counter
+	var serviceMirrorMismatchesCounter []metrics.Counter
+	var serviceHedgedReqsCounter []metrics.Counter
 
 	for _, r := range registries {
 		if r.ConfigReloadsCounter() != nil {
@@ -172,6 +176,12 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.ServiceRespsBytesCounter() != nil {
 			serviceRespsBytesCounter = append(serviceRespsBytesCounter, r.ServiceRespsBytesCounter())
 		}
+		if r.ServiceMirrorMismatchesCounter() != nil {
+			serviceMirrorMismatchesCounter = append(serviceMirrorMismatchesCounter, r.ServiceMirrorMismatchesCounter())
+		}
+		if r.ServiceHedgedReqsCounter() != nil {
+			serviceHedgedReqsCounter = append(serviceHedgedReqsCounter, r.ServiceHedgedReqsCounter())
+		}
 	}
 
 	return &standardRegistry{
@@ -203,6 +213,8 @@ func NewMultiRegistry(registries []Registry) Registry {
 		serviceServerUpGauge:           multi.NewGauge(serviceServerUpGauge...),
 		serviceReqsBytesCounter:        multi.NewCounter(serviceReqsBytesCounter...),
 		serviceRespsBytesCounter:       multi.NewCounter(serviceRespsBytesCounter...),
+		serviceMirrorMismatchesCounter: multi.NewCounter(serviceMirrorMismatchesCounter...),
+		serviceHedgedReqsCounter:       multi.NewCounter(serviceHedgedReqsCounter...),
 	}
 }
 
@@ -235,6 +247,8 @@ type standardRegistry struct {
 	serviceServerUpGauge           metrics.Gauge
 	serviceReqsBytesCounter        metrics.Counter
 	serviceRespsBytesCounter       metrics.Counter
+	serviceMirrorMismatchesCounter metrics.Counter
+	serviceHedgedReqsCounter       metrics.Counter
 }
 
 func (r *standardRegistry) IsEpEnabled() bool {
@@ -349,6 +363,14 @@ func (r *standardRegistry) ServiceRespsBytesCounter() metrics.Counter {
 	return r.serviceRespsBytesCounter
 }
 
+func (r *standardRegistry) ServiceMirrorMismatchesCounter() metrics.Counter {
+	return r.serviceMirrorMismatchesCounter
+}
+
+func (r *standardRegistry) ServiceHedgedReqsCounter() metrics.Counter {
+	return r.serviceHedgedReqsCounter
+}
+
 // ScalableHistogram is a Histogram with a predefined time unit,
 // used when producing observations without explicitly setting the observed value.
 type ScalableHistogram interface {
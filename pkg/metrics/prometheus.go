@@ -50,15 +50,17 @@ const (
 	routerRespsBytesTotalName = metricRouterPrefix + "responses_bytes_total"
 
 	// service level.
-	metricServicePrefix        = MetricNamePrefix + "service_"
-	serviceReqsTotalName       = metricServicePrefix + "requests_total"
-	serviceReqsTLSTotalName    = metricServicePrefix + "requests_tls_total"
-	serviceReqDurationName     = metricServicePrefix + "request_duration_seconds"
-	serviceOpenConnsName       = metricServicePrefix + "open_connections"
-	serviceRetriesTotalName    = metricServicePrefix + "retries_total"
-	serviceServerUpName        = metricServicePrefix + "server_up"
-	serviceReqsBytesTotalName  = metricServicePrefix + "requests_bytes_total"
-	serviceRespsBytesTotalName = metricServicePrefix + "responses_bytes_total"
+	metricServicePrefix              = MetricNamePrefix + "service_"
+	serviceReqsTotalName             = metricServicePrefix + "requests_total"
+	serviceReqsTLSTotalName          = metricServicePrefix + "requests_tls_total"
+	serviceReqDurationName           = metricServicePrefix + "request_duration_seconds"
+	serviceOpenConnsName             = metricServicePrefix + "open_connections"
+	serviceRetriesTotalName          = metricServicePrefix + "retries_total"
+	serviceServerUpName              = metricServicePrefix + "server_up"
+	serviceReqsBytesTotalName        = metricServicePrefix + "requests_bytes_total"
+	serviceRespsBytesTotalName       = metricServicePrefix + "responses_bytes_total"
+	serviceMirrorMismatchesTotalName = metricServicePrefix + "mirror_mismatches_total"
+	serviceHedgedReqsTotalName       = metricServicePrefix + "hedged_requests_total"
 )
 
 // promState holds all metric state internally and acts as the only Collector we register for Prometheus.
@@ -275,6 +277,14 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 			Name: serviceRespsBytesTotalName,
 			Help: "The total size of responses in bytes returned by a service, partitioned by status code, protocol, and method.",
 		}, []string{"code", "method", "protocol", "service"})
+		serviceMirrorMismatchesTotal := newCounterFrom(stdprometheus.CounterOpts{
+			Name: serviceMirrorMismatchesTotalName,
+			Help: "How many responses of a mirror differed from the responses of the mirrored service, partitioned by mirror and differing field.",
+		}, []string{"field", "mirror", "service"})
+		serviceHedgedReqsTotal := newCounterFrom(stdprometheus.CounterOpts{
+			Name: serviceHedgedReqsTotalName,
+			Help: "How many hedged requests were sent by a service, partitioned by whether their response was used.",
+		}, []string{"result", "service"})
 
 		promState.vectors = append(promState.vectors,
 			serviceReqs.cv,
@@ -285,6 +295,8 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 			serviceServerUp.gv,
 			serviceReqsBytesTotal.cv,
 			serviceRespsBytesTotal.cv,
+			serviceMirrorMismatchesTotal.cv,
+			serviceHedgedReqsTotal.cv,
 		)
 
 		reg.serviceReqsCounter = serviceReqs
@@ -295,6 +307,8 @@ func initStandardRegistry(config *types.Prometheus) Registry {
 		reg.serviceServerUpGauge = serviceServerUp
 		reg.serviceReqsBytesCounter = serviceReqsBytesTotal
 		reg.serviceRespsBytesCounter = serviceRespsBytesTotal
+		reg.serviceMirrorMismatchesCounter = serviceMirrorMismatchesTotal
+		reg.serviceHedgedReqsCounter = serviceHedgedReqsTotal
 	}
 
 	return reg
@@ -187,6 +187,14 @@ func TestPrometheus(t *testing.T) {
 		ServiceReqsBytesCounter().
 		With("service", "service1", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet, "protocol", "http").
 		Add(1)
+	prometheusRegistry.
+		ServiceMirrorMismatchesCounter().
+		With("service", "service1", "mirror", "shadow", "field", "status").
+		Add(1)
+	prometheusRegistry.
+		ServiceHedgedReqsCounter().
+		With("service", "service1", "result", "won").
+		Add(1)
 
 	delayForTrackingCompletion()
 
@@ -408,6 +416,23 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildCounterAssert(t, serviceRespsBytesTotalName, 1),
 		},
+		{
+			name: serviceMirrorMismatchesTotalName,
+			labels: map[string]string{
+				"field":   "status",
+				"mirror":  "shadow",
+				"service": "service1",
+			},
+			assert: buildCounterAssert(t, serviceMirrorMismatchesTotalName, 1),
+		},
+		{
+			name: serviceHedgedReqsTotalName,
+			labels: map[string]string{
+				"result":  "won",
+				"service": "service1",
+			},
+			assert: buildCounterAssert(t, serviceHedgedReqsTotalName, 1),
+		},
 	}
 
 	for _, test := range testCases {
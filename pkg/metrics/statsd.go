@@ -38,14 +38,16 @@ const (
 	statsdRouterReqsBytesName    = "router.requests.bytes.total"
 	statsdRouterRespsBytesName   = "router.responses.bytes.total"
 
-	statsdServiceReqsName         = "service.request.total"
-	statsdServiceReqsTLSName      = "service.request.tls.total"
-	statsdServiceReqsDurationName = "service.request.duration"
-	statsdServiceRetriesTotalName = "service.retries.total"
-	statsdServiceServerUpName     = "service.server.up"
-	statsdServiceOpenConnsName    = "service.connections.open"
-	statsdServiceReqsBytesName    = "service.requests.bytes.total"
-	statsdServiceRespsBytesName   = "service.responses.bytes.total"
+	statsdServiceReqsName             = "service.request.total"
+	statsdServiceReqsTLSName          = "service.request.tls.total"
+	statsdServiceReqsDurationName     = "service.request.duration"
+	statsdServiceRetriesTotalName     = "service.retries.total"
+	statsdServiceServerUpName         = "service.server.up"
+	statsdServiceOpenConnsName        = "service.connections.open"
+	statsdServiceReqsBytesName        = "service.requests.bytes.total"
+	statsdServiceRespsBytesName       = "service.responses.bytes.total"
+	statsdServiceMirrorMismatchesName = "service.mirror.mismatches.total"
+	statsdServiceHedgedReqsName       = "service.hedged.requests.total"
 )
 
 // RegisterStatsd registers the metrics pusher if this didn't happen yet and creates a statsd Registry instance.
@@ -99,6 +101,8 @@ func RegisterStatsd(ctx context.Context, config *types.Statsd) Registry {
 		registry.serviceServerUpGauge = statsdClient.NewGauge(statsdServiceServerUpName)
 		registry.serviceReqsBytesCounter = statsdClient.NewCounter(statsdServiceReqsBytesName, 1.0)
 		registry.serviceRespsBytesCounter = statsdClient.NewCounter(statsdServiceRespsBytesName, 1.0)
+		registry.serviceMirrorMismatchesCounter = statsdClient.NewCounter(statsdServiceMirrorMismatchesName, 1.0)
+		registry.serviceHedgedReqsCounter = statsdClient.NewCounter(statsdServiceHedgedReqsName, 1.0)
 	}
 
 	return registry
@@ -77,6 +77,8 @@ func testRegistry(t *testing.T, metricsPrefix string, registry Registry) {
 		metricsPrefix + ".service.server.up:1.000000|g\n",
 		metricsPrefix + ".service.requests.bytes.total:1.000000|c\n",
 		metricsPrefix + ".service.responses.bytes.total:1.000000|c\n",
+		metricsPrefix + ".service.mirror.mismatches.total:1.000000|c\n",
+		metricsPrefix + ".service.hedged.requests.total:1.000000|c\n",
 	}
 
 	udp.ShouldReceiveAll(t, expected, func() {
@@ -112,5 +114,7 @@ func testRegistry(t *testing.T, metricsPrefix string, registry Registry) {
 		registry.ServiceServerUpGauge().With("service:test", "url", "http://127.0.0.1").Set(1)
 		registry.ServiceReqsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
 		registry.ServiceRespsBytesCounter().With("service", "test", "code", strconv.Itoa(http.StatusOK), "method", http.MethodGet).Add(1)
+		registry.ServiceMirrorMismatchesCounter().With("service", "test", "mirror", "shadow", "field", "status").Add(1)
+		registry.ServiceHedgedReqsCounter().With("service", "test", "result", "won").Add(1)
 	})
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 	"github.com/traefik/traefik/v2/pkg/tracing"
 )
 
@@ -48,12 +49,12 @@ func (a *addPrefix) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	logger := middlewares.GetLogger(req.Context(), a.name, typeName)
 
 	oldURLPath := req.URL.Path
-	req.URL.Path = ensureLeadingSlash(a.prefix + req.URL.Path)
+	req.URL.Path = ensureLeadingSlash(requestdecorator.ReplacePathParams(req.Context(), a.prefix, nil) + req.URL.Path)
 	logger.Debug().Msgf("URL.Path is now %s (was %s).", req.URL.Path, oldURLPath)
 
 	if req.URL.RawPath != "" {
 		oldURLRawPath := req.URL.RawPath
-		req.URL.RawPath = ensureLeadingSlash(a.prefix + req.URL.RawPath)
+		req.URL.RawPath = ensureLeadingSlash(requestdecorator.ReplacePathParams(req.Context(), a.prefix, requestdecorator.EscapePathParam) + req.URL.RawPath)
 		logger.Debug().Msgf("URL.RawPath is now %s (was %s).", req.URL.RawPath, oldURLRawPath)
 	}
 	req.RequestURI = req.URL.RequestURI()
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 	"github.com/traefik/traefik/v2/pkg/testhelpers"
 )
 
@@ -50,6 +51,7 @@ func TestAddPrefix(t *testing.T) {
 		desc            string
 		prefix          dynamic.AddPrefix
 		path            string
+		params          map[string]string
 		expectedPath    string
 		expectedRawPath string
 	}{
@@ -72,6 +74,21 @@ func TestAddPrefix(t *testing.T) {
 			expectedPath:    "/a/b/c",
 			expectedRawPath: "/a/b%2Fc",
 		},
+		{
+			desc:         "Works with path parameters",
+			prefix:       dynamic.AddPrefix{Prefix: "/tenants/{tenant}"},
+			path:         "/b",
+			params:       map[string]string{"tenant": "foo"},
+			expectedPath: "/tenants/foo/b",
+		},
+		{
+			desc:            "Works with path parameters and a raw path",
+			prefix:          dynamic.AddPrefix{Prefix: "/tenants/{tenant}"},
+			path:            "/b%2Fc",
+			params:          map[string]string{"tenant": "foo bar"},
+			expectedPath:    "/tenants/foo bar/b/c",
+			expectedRawPath: "/tenants/foo%20bar/b%2Fc",
+		},
 	}
 
 	for _, test := range testCases {
@@ -88,6 +105,9 @@ func TestAddPrefix(t *testing.T) {
 			})
 
 			req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			if test.params != nil {
+				req = req.WithContext(requestdecorator.WithPathParams(req.Context(), test.params))
+			}
 
 			handler, err := New(context.Background(), next, test.prefix, "foo-add-prefix")
 			require.NoError(t, err)
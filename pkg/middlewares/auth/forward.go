@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -24,8 +25,13 @@ const (
 	xForwardedURI     = "X-Forwarded-Uri"
 	xForwardedMethod  = "X-Forwarded-Method"
 	forwardedTypeName = "ForwardedAuthType"
+
+	// defaultForwardAuthMaxBodySize is the default maximum size of the request body forwarded to the authentication server.
+	defaultForwardAuthMaxBodySize = 1024 * 1024
 )
 
+var errBodyTooLarge = errors.New("request body too large")
+
 // hopHeaders Hop-by-hop headers to be removed in the authentication request.
 // http://www.w3.org/Protocols/rfc2616/rfc2616-sec13.html
 // Proxy-Authorization header is forwarded to the authentication server (see https://tools.ietf.org/html/rfc7235#section-4.4).
@@ -47,6 +53,9 @@ type forwardAuth struct {
 	client                   http.Client
 	trustForwardHeader       bool
 	authRequestHeaders       []string
+	forwardBody              bool
+	maxBodySize              int64
+	cache                    *forwardAuthCache
 }
 
 // NewForward creates a forward auth middleware.
@@ -60,6 +69,12 @@ func NewForward(ctx context.Context, next http.Handler, config dynamic.ForwardAu
 		name:                name,
 		trustForwardHeader:  config.TrustForwardHeader,
 		authRequestHeaders:  config.AuthRequestHeaders,
+		forwardBody:         config.ForwardBody,
+		maxBodySize:         config.MaxBodySize,
+	}
+
+	if fa.maxBodySize <= 0 {
+		fa.maxBodySize = defaultForwardAuthMaxBodySize
 	}
 
 	// Ensure our request client does not follow redirects
@@ -89,6 +104,19 @@ func NewForward(ctx context.Context, next http.Handler, config dynamic.ForwardAu
 		fa.authResponseHeadersRegex = re
 	}
 
+	if config.Cache != nil {
+		// The decisions depending on the request body cannot be shared between requests.
+		if config.ForwardBody {
+			return nil, errors.New("cache cannot be used with forwardBody")
+		}
+
+		cache, err := newForwardAuthCache(*config.Cache)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create cache: %w", err)
+		}
+		fa.cache = cache
+	}
+
 	return connectionheader.Remover(fa), nil
 }
 
@@ -99,7 +127,108 @@ func (fa *forwardAuth) GetTracingInformation() (string, ext.SpanKindEnum) {
 func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	logger := middlewares.GetLogger(req.Context(), fa.name, forwardedTypeName)
 
-	forwardReq, err := http.NewRequest(http.MethodGet, fa.address, nil)
+	var cacheKey string
+	var decision *forwardAuthDecision
+	if fa.cache != nil {
+		var ok bool
+		if cacheKey, ok = fa.cache.key(req); ok {
+			decision, _ = fa.cache.get(cacheKey)
+		}
+	}
+
+	if decision != nil {
+		logger.Debug().Msg("Using cached authentication decision")
+	} else {
+		var ok bool
+		decision, ok = fa.authenticate(rw, req)
+		if !ok {
+			return
+		}
+
+		if cacheKey != "" && decision.cacheable() {
+			if err := fa.cache.set(cacheKey, decision); err != nil {
+				logger.Error().Err(err).Msg("Unable to cache authentication decision")
+			}
+		}
+	}
+
+	// Pass the forward response's body and selected headers if it
+	// didn't return a response within the range of [200, 300).
+	if !decision.allowed() {
+		logger.Debug().Msgf("Remote error %s. StatusCode: %d", fa.address, decision.statusCode)
+
+		utils.CopyHeaders(rw.Header(), decision.header)
+		utils.RemoveHeaders(rw.Header(), hopHeaders...)
+
+		if decision.location != "" {
+			// Set the location in our response if one was sent back.
+			rw.Header().Set("Location", decision.location)
+		}
+
+		tracing.LogResponseCode(tracing.GetSpan(req), decision.statusCode)
+		rw.WriteHeader(decision.statusCode)
+
+		if _, err := rw.Write(decision.body); err != nil {
+			logger.Error().Err(err).Send()
+		}
+		return
+	}
+
+	for _, headerName := range fa.authResponseHeaders {
+		headerKey := http.CanonicalHeaderKey(headerName)
+		req.Header.Del(headerKey)
+		if len(decision.header[headerKey]) > 0 {
+			req.Header[headerKey] = append([]string(nil), decision.header[headerKey]...)
+		}
+	}
+
+	if fa.authResponseHeadersRegex != nil {
+		for headerKey := range req.Header {
+			if fa.authResponseHeadersRegex.MatchString(headerKey) {
+				req.Header.Del(headerKey)
+			}
+		}
+
+		for headerKey, headerValues := range decision.header {
+			if fa.authResponseHeadersRegex.MatchString(headerKey) {
+				req.Header[headerKey] = append([]string(nil), headerValues...)
+			}
+		}
+	}
+
+	req.RequestURI = req.URL.RequestURI()
+	fa.next.ServeHTTP(rw, req)
+}
+
+// authenticate calls the authentication server, and returns its decision.
+// If the authentication server cannot be called, an error response is written, and false is returned.
+func (fa *forwardAuth) authenticate(rw http.ResponseWriter, req *http.Request) (*forwardAuthDecision, bool) {
+	logger := middlewares.GetLogger(req.Context(), fa.name, forwardedTypeName)
+
+	var forwardBody io.Reader
+	if fa.forwardBody {
+		body, err := fa.readBody(req)
+		if err != nil {
+			logMessage := fmt.Sprintf("Error reading request body. Cause: %s", err)
+			logger.Debug().Msg(logMessage)
+			tracing.SetErrorWithEvent(req, logMessage)
+
+			if errors.Is(err, errBodyTooLarge) {
+				rw.WriteHeader(http.StatusRequestEntityTooLarge)
+				return nil, false
+			}
+
+			rw.WriteHeader(http.StatusBadRequest)
+			return nil, false
+		}
+
+		// The body is nil when the request has no body.
+		if body != nil {
+			forwardBody = bytes.NewReader(body)
+		}
+	}
+
+	forwardReq, err := http.NewRequest(http.MethodGet, fa.address, forwardBody)
 	tracing.LogRequest(tracing.GetSpan(req), forwardReq)
 	if err != nil {
 		logMessage := fmt.Sprintf("Error calling %s. Cause %s", fa.address, err)
@@ -107,7 +236,7 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		tracing.SetErrorWithEvent(req, logMessage)
 
 		rw.WriteHeader(http.StatusInternalServerError)
-		return
+		return nil, false
 	}
 
 	// Ensure tracing headers are in the request before we copy the headers to the
@@ -123,7 +252,7 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		tracing.SetErrorWithEvent(req, logMessage)
 
 		rw.WriteHeader(http.StatusInternalServerError)
-		return
+		return nil, false
 	}
 
 	body, readError := io.ReadAll(forwardResponse.Body)
@@ -133,68 +262,62 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		tracing.SetErrorWithEvent(req, logMessage)
 
 		rw.WriteHeader(http.StatusInternalServerError)
-		return
+		return nil, false
 	}
 	defer forwardResponse.Body.Close()
 
-	// Pass the forward response's body and selected headers if it
-	// didn't return a response within the range of [200, 300).
-	if forwardResponse.StatusCode < http.StatusOK || forwardResponse.StatusCode >= http.StatusMultipleChoices {
-		logger.Debug().Msgf("Remote error %s. StatusCode: %d", fa.address, forwardResponse.StatusCode)
+	decision := &forwardAuthDecision{
+		statusCode: forwardResponse.StatusCode,
+		header:     forwardResponse.Header,
+		body:       body,
+	}
 
-		utils.CopyHeaders(rw.Header(), forwardResponse.Header)
-		utils.RemoveHeaders(rw.Header(), hopHeaders...)
+	if decision.allowed() {
+		return decision, true
+	}
 
-		// Grab the location header, if any.
-		redirectURL, err := forwardResponse.Location()
+	// Grab the location header, if any.
+	redirectURL, err := forwardResponse.Location()
 
-		if err != nil {
-			if !errors.Is(err, http.ErrNoLocation) {
-				logMessage := fmt.Sprintf("Error reading response location header %s. Cause: %s", fa.address, err)
-				logger.Debug().Msg(logMessage)
-				tracing.SetErrorWithEvent(req, logMessage)
+	if err != nil {
+		if !errors.Is(err, http.ErrNoLocation) {
+			logMessage := fmt.Sprintf("Error reading response location header %s. Cause: %s", fa.address, err)
+			logger.Debug().Msg(logMessage)
+			tracing.SetErrorWithEvent(req, logMessage)
 
-				rw.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-		} else if redirectURL.String() != "" {
-			// Set the location in our response if one was sent back.
-			rw.Header().Set("Location", redirectURL.String())
+			rw.WriteHeader(http.StatusInternalServerError)
+			return nil, false
 		}
+	} else {
+		decision.location = redirectURL.String()
+	}
 
-		tracing.LogResponseCode(tracing.GetSpan(req), forwardResponse.StatusCode)
-		rw.WriteHeader(forwardResponse.StatusCode)
+	return decision, true
+}
 
-		if _, err = rw.Write(body); err != nil {
-			logger.Error().Err(err).Send()
-		}
-		return
+// readBody reads the request body, up to the maximum body size,
+// and replaces it so that it can be read again by the next handler.
+func (fa *forwardAuth) readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
 	}
 
-	for _, headerName := range fa.authResponseHeaders {
-		headerKey := http.CanonicalHeaderKey(headerName)
-		req.Header.Del(headerKey)
-		if len(forwardResponse.Header[headerKey]) > 0 {
-			req.Header[headerKey] = append([]string(nil), forwardResponse.Header[headerKey]...)
-		}
+	if req.ContentLength > fa.maxBodySize {
+		return nil, errBodyTooLarge
 	}
 
-	if fa.authResponseHeadersRegex != nil {
-		for headerKey := range req.Header {
-			if fa.authResponseHeadersRegex.MatchString(headerKey) {
-				req.Header.Del(headerKey)
-			}
-		}
+	body, err := io.ReadAll(io.LimitReader(req.Body, fa.maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
 
-		for headerKey, headerValues := range forwardResponse.Header {
-			if fa.authResponseHeadersRegex.MatchString(headerKey) {
-				req.Header[headerKey] = append([]string(nil), headerValues...)
-			}
-		}
+	if int64(len(body)) > fa.maxBodySize {
+		return nil, errBodyTooLarge
 	}
 
-	req.RequestURI = req.URL.RequestURI()
-	fa.next.ServeHTTP(rw, req)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
 }
 
 func writeHeader(req, forwardReq *http.Request, trustForwardHeader bool, allowedHeaders []string) {
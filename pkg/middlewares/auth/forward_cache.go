@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/mailgun/ttlmap"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+// maxForwardAuthCacheEntries is the maximum number of decisions cached by a forward auth middleware.
+// When the cache is full, the decisions closest to expiry are evicted first.
+const maxForwardAuthCacheEntries = 10000
+
+// forwardAuthDecision holds the response of the authentication server to an authentication request.
+type forwardAuthDecision struct {
+	statusCode int
+	header     http.Header
+	location   string
+	body       []byte
+}
+
+// allowed returns whether the authentication server allowed the request.
+func (d *forwardAuthDecision) allowed() bool {
+	return d.statusCode >= http.StatusOK && d.statusCode < http.StatusMultipleChoices
+}
+
+// cacheable returns whether the decision can be reused for other requests.
+// The server errors are transient, and are never cached.
+func (d *forwardAuthDecision) cacheable() bool {
+	return d.statusCode < http.StatusInternalServerError
+}
+
+// forwardAuthCache caches the decisions of the authentication server,
+// keyed by the method, host and URI of the requests, and by the values of their key headers.
+type forwardAuthCache struct {
+	decisions  *ttlmap.TtlMap
+	ttlSeconds int
+	keyHeaders []string
+}
+
+func newForwardAuthCache(config dynamic.ForwardAuthCache) (*forwardAuthCache, error) {
+	ttl := time.Duration(config.TTL)
+	if ttl <= 0 {
+		return nil, fmt.Errorf("invalid cache TTL: %s", ttl)
+	}
+
+	if len(config.KeyHeaders) == 0 {
+		return nil, errors.New("at least one cache key header must be defined")
+	}
+
+	decisions, err := ttlmap.NewConcurrent(maxForwardAuthCacheEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &forwardAuthCache{
+		decisions: decisions,
+		// The cache has a one second granularity.
+		ttlSeconds: int(math.Ceil(ttl.Seconds())),
+		keyHeaders: config.KeyHeaders,
+	}, nil
+}
+
+// key returns the cache key of the request.
+// The requests without any of the key headers are not cached,
+// as nothing identifies the client they come from.
+func (c *forwardAuthCache) key(req *http.Request) (string, bool) {
+	var found bool
+
+	// The values are hashed, so that the credentials they may contain are not kept as is in memory.
+	// They are length-prefixed, so that different values cannot produce the same key.
+	hash := sha256.New()
+
+	// The decisions of the authentication server may depend on the method, host and URI of the request,
+	// which are forwarded to it in the X-Forwarded-* headers.
+	for _, value := range []string{req.Method, req.Host, req.URL.RequestURI()} {
+		_, _ = fmt.Fprintf(hash, "%d:%s", len(value), value)
+	}
+
+	for _, name := range c.keyHeaders {
+		values := req.Header.Values(name)
+		found = found || len(values) > 0
+
+		for _, value := range values {
+			_, _ = fmt.Fprintf(hash, "%d:%s", len(value), value)
+		}
+		_, _ = hash.Write([]byte{0})
+	}
+
+	if !found {
+		return "", false
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+func (c *forwardAuthCache) get(key string) (*forwardAuthDecision, bool) {
+	value, ok := c.decisions.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	decision, ok := value.(*forwardAuthDecision)
+	return decision, ok
+}
+
+func (c *forwardAuthCache) set(key string, decision *forwardAuthDecision) error {
+	return c.decisions.Set(key, decision, c.ttlSeconds)
+}
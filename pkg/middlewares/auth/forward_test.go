@@ -6,12 +6,16 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	tracingMiddleware "github.com/traefik/traefik/v2/pkg/middlewares/tracing"
 	"github.com/traefik/traefik/v2/pkg/testhelpers"
@@ -490,3 +494,340 @@ type mockBackend struct {
 func (b *mockBackend) Setup(componentName string) (opentracing.Tracer, io.Closer, error) {
 	return b.Tracer, io.NopCloser(nil), nil
 }
+
+func TestForwardAuthForwardBody(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		forwardBody        bool
+		maxBodySize        int64
+		body               string
+		expectedStatusCode int
+		expectedAuthBody   string
+	}{
+		{
+			desc:               "body not forwarded",
+			body:               "foobar",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			desc:               "body forwarded",
+			forwardBody:        true,
+			body:               "foobar",
+			expectedStatusCode: http.StatusOK,
+			expectedAuthBody:   "foobar",
+		},
+		{
+			desc:               "body forwarded at the maximum size",
+			forwardBody:        true,
+			maxBodySize:        6,
+			body:               "foobar",
+			expectedStatusCode: http.StatusOK,
+			expectedAuthBody:   "foobar",
+		},
+		{
+			desc:               "body too large",
+			forwardBody:        true,
+			maxBodySize:        5,
+			body:               "foobar",
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:               "empty body",
+			forwardBody:        true,
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var authBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				authBody = string(body)
+			}))
+			t.Cleanup(server.Close)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The body must still be available to the backend.
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, test.body, string(body))
+			})
+
+			middleware, err := NewForward(context.Background(), next, dynamic.ForwardAuth{
+				Address:     server.URL,
+				ForwardBody: test.forwardBody,
+				MaxBodySize: test.maxBodySize,
+			}, "authTest")
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(middleware)
+			t.Cleanup(ts.Close)
+
+			req := testhelpers.MustNewRequest(http.MethodPost, ts.URL, strings.NewReader(test.body))
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.expectedStatusCode, res.StatusCode)
+			assert.Equal(t, test.expectedAuthBody, authBody)
+		})
+	}
+}
+
+func TestNewForward_cache(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      dynamic.ForwardAuth
+		expectedErr string
+	}{
+		{
+			desc: "valid cache",
+			config: dynamic.ForwardAuth{
+				Cache: &dynamic.ForwardAuthCache{
+					TTL:        ptypes.Duration(time.Second),
+					KeyHeaders: []string{"Authorization"},
+				},
+			},
+		},
+		{
+			desc: "cache with body forwarding",
+			config: dynamic.ForwardAuth{
+				ForwardBody: true,
+				Cache: &dynamic.ForwardAuthCache{
+					TTL:        ptypes.Duration(time.Second),
+					KeyHeaders: []string{"Authorization"},
+				},
+			},
+			expectedErr: "cache cannot be used with forwardBody",
+		},
+		{
+			desc: "no TTL",
+			config: dynamic.ForwardAuth{
+				Cache: &dynamic.ForwardAuthCache{
+					KeyHeaders: []string{"Authorization"},
+				},
+			},
+			expectedErr: "unable to create cache: invalid cache TTL: 0s",
+		},
+		{
+			desc: "no key headers",
+			config: dynamic.ForwardAuth{
+				Cache: &dynamic.ForwardAuthCache{
+					TTL: ptypes.Duration(time.Second),
+				},
+			},
+			expectedErr: "unable to create cache: at least one cache key header must be defined",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			test.config.Address = "http://localhost"
+
+			_, err := NewForward(context.Background(), http.NotFoundHandler(), test.config, "authTest")
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestForwardAuthCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		switch r.Header.Get("Authorization") {
+		case "valid", "":
+			w.Header().Set("X-Auth-User", "user@example.com")
+		case "unavailable":
+			http.Error(w, "Unavailable", http.StatusServiceUnavailable)
+		default:
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Header.Get("X-Auth-User"))
+	})
+
+	config := dynamic.ForwardAuth{
+		Address:             server.URL,
+		AuthResponseHeaders: []string{"X-Auth-User"},
+		Cache:               &dynamic.ForwardAuthCache{},
+	}
+	config.Cache.SetDefaults()
+
+	middleware, err := NewForward(context.Background(), next, config, "authTest")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc               string
+		method             string
+		url                string
+		authorization      string
+		expectedStatusCode int
+		expectedBody       string
+		expectedCalls      int32
+	}{
+		{
+			desc:               "first allowed request",
+			authorization:      "valid",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "user@example.com",
+			expectedCalls:      1,
+		},
+		{
+			desc:               "cached allowed request",
+			authorization:      "valid",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "user@example.com",
+			expectedCalls:      1,
+		},
+		{
+			desc:               "allowed request with another method",
+			method:             http.MethodPost,
+			authorization:      "valid",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "user@example.com",
+			expectedCalls:      2,
+		},
+		{
+			desc:               "allowed request on another host",
+			url:                "http://baz.bar",
+			authorization:      "valid",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "user@example.com",
+			expectedCalls:      3,
+		},
+		{
+			desc:               "allowed request on another path",
+			url:                "http://foo.bar/admin",
+			authorization:      "valid",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "user@example.com",
+			expectedCalls:      4,
+		},
+		{
+			desc:               "allowed request with another query",
+			url:                "http://foo.bar/?admin=true",
+			authorization:      "valid",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "user@example.com",
+			expectedCalls:      5,
+		},
+		{
+			desc:               "first denied request",
+			authorization:      "invalid",
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       "Forbidden\n",
+			expectedCalls:      6,
+		},
+		{
+			desc:               "cached denied request",
+			authorization:      "invalid",
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       "Forbidden\n",
+			expectedCalls:      6,
+		},
+		{
+			desc:               "first server error",
+			authorization:      "unavailable",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "Unavailable\n",
+			expectedCalls:      7,
+		},
+		{
+			desc:               "server error not cached",
+			authorization:      "unavailable",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "Unavailable\n",
+			expectedCalls:      8,
+		},
+		{
+			desc:               "first request without key header",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "user@example.com",
+			expectedCalls:      9,
+		},
+		{
+			desc:               "request without key header not cached",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "user@example.com",
+			expectedCalls:      10,
+		},
+	}
+
+	// The test cases are run in order, as they share the cache.
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			method := http.MethodGet
+			if test.method != "" {
+				method = test.method
+			}
+
+			target := "http://foo.bar"
+			if test.url != "" {
+				target = test.url
+			}
+
+			req := httptest.NewRequest(method, target, nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			// The auth response headers must not be forged by the client.
+			req.Header.Set("X-Auth-User", "forged")
+
+			rw := httptest.NewRecorder()
+			middleware.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+			assert.Equal(t, test.expectedBody, rw.Body.String())
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestForwardAuthCache_expiry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	t.Cleanup(server.Close)
+
+	middleware, err := NewForward(context.Background(), http.NotFoundHandler(), dynamic.ForwardAuth{
+		Address: server.URL,
+		Cache: &dynamic.ForwardAuthCache{
+			TTL:        ptypes.Duration(time.Second),
+			KeyHeaders: []string{"Authorization"},
+		},
+	}, "authTest")
+	require.NoError(t, err)
+
+	serve := func() {
+		req := httptest.NewRequest(http.MethodGet, "http://foo.bar", nil)
+		req.Header.Set("Authorization", "valid")
+		middleware.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve()
+	serve()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// The cache has a one second granularity.
+	time.Sleep(2 * time.Second)
+
+	serve()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// jwksMinRefreshInterval is the minimum duration between two fetches of a JSON Web Key Set,
+// so that tokens signed with unknown keys cannot make the middleware flood the key server.
+const jwksMinRefreshInterval = 10 * time.Second
+
+// jwks caches the public keys of a JSON Web Key Set, by key ID.
+// The set is fetched again when it is stale, or when a token is signed with an unknown key,
+// so that the rotation of the keys is followed without waiting for the refresh interval.
+// The set is fetched by a single request at a time, without holding the lock used by the lookups,
+// and independently of the requests waiting for it, so that a canceled request does not fail the fetch.
+type jwks struct {
+	url                string
+	client             *http.Client
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	now                func() time.Time
+
+	fetches singleflight.Group
+
+	mu        sync.RWMutex
+	keys      map[string]jose.JSONWebKey
+	fetchedAt time.Time
+	// attemptedAt is the time of the last fetch, whether it succeeded or not.
+	attemptedAt time.Time
+}
+
+func newJWKS(url string, refreshInterval time.Duration) *jwks {
+	return &jwks{
+		url:                url,
+		client:             &http.Client{Timeout: 10 * time.Second},
+		refreshInterval:    refreshInterval,
+		minRefreshInterval: jwksMinRefreshInterval,
+		now:                time.Now,
+	}
+}
+
+// key returns the key with the given ID, suitable for the given algorithm.
+// If the ID is empty, the key is only found if the set holds a single suitable key.
+func (k *jwks) key(ctx context.Context, kid, alg string) (interface{}, error) {
+	k.mu.RLock()
+	now := k.now()
+	stale := now.Sub(k.fetchedAt) >= k.refreshInterval
+	throttled := now.Sub(k.attemptedAt) < k.minRefreshInterval
+	k.mu.RUnlock()
+
+	if stale && !throttled {
+		if err := k.refresh(ctx); err != nil {
+			// The cached keys are kept while the key server is unavailable.
+			log.Ctx(ctx).Error().Err(err).Str("url", k.url).Msg("Unable to fetch JSON Web Key Set")
+		}
+		throttled = true
+	}
+
+	if key, ok := k.lookup(kid, alg); ok {
+		return key, nil
+	}
+
+	if throttled {
+		return nil, fmt.Errorf("no key found for key ID %q", kid)
+	}
+
+	// The keys may have been rotated since the last fetch.
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	if key, ok := k.lookup(kid, alg); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("no key found for key ID %q", kid)
+}
+
+// refresh fetches the key set, unless it is already being fetched, in which case it waits for the ongoing fetch,
+// or it has been fetched less than the minimum refresh interval ago.
+func (k *jwks) refresh(ctx context.Context) error {
+	_, err, _ := k.fetches.Do(k.url, func() (interface{}, error) {
+		k.mu.Lock()
+		if k.now().Sub(k.attemptedAt) < k.minRefreshInterval {
+			// The set has just been fetched by a concurrent request.
+			k.mu.Unlock()
+			return nil, nil
+		}
+		k.attemptedAt = k.now()
+		k.mu.Unlock()
+
+		return nil, k.fetch(ctx)
+	})
+
+	return err
+}
+
+func (k *jwks) lookup(kid, alg string) (interface{}, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if kid != "" {
+		key, ok := k.keys[kid]
+		if !ok || (key.Algorithm != "" && key.Algorithm != alg) {
+			return nil, false
+		}
+		return key.Key, true
+	}
+
+	var found interface{}
+	for _, key := range k.keys {
+		if key.Algorithm != "" && key.Algorithm != alg {
+			continue
+		}
+
+		if found != nil {
+			// The key cannot be chosen without its ID.
+			return nil, false
+		}
+		found = key.Key
+	}
+
+	return found, found != nil
+}
+
+// fetch replaces the cached keys with the ones of the key set served at the URL.
+// The given context is only used for logging, as the fetch is shared by several requests,
+// and is bounded by the timeout of the client instead.
+func (k *jwks) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, k.url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &set); err != nil {
+		return fmt.Errorf("unable to parse JSON Web Key Set: %w", err)
+	}
+
+	keys := make(map[string]jose.JSONWebKey)
+	for _, raw := range set.Keys {
+		var key jose.JSONWebKey
+		if err := key.UnmarshalJSON(raw); err != nil {
+			// The keys of unsupported types are ignored, so that they do not prevent the use of the others.
+			log.Ctx(ctx).Debug().Err(err).Str("url", k.url).Msg("Ignoring JSON Web Key")
+			continue
+		}
+
+		if key.Use == "enc" || !key.IsPublic() {
+			continue
+		}
+
+		keys[key.KeyID] = key
+	}
+
+	if len(keys) == 0 {
+		return errors.New("no signature keys found in JSON Web Key Set")
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.fetchedAt = k.now()
+	k.mu.Unlock()
+
+	return nil
+}
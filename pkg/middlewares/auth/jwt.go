@@ -0,0 +1,391 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/middlewares/accesslog"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
+	"github.com/traefik/traefik/v2/pkg/tracing"
+)
+
+const (
+	jwtTypeName = "JWT"
+)
+
+var (
+	hmacAlgorithms       = []string{"HS256", "HS384", "HS512"}
+	asymmetricAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+)
+
+type jwtAuth struct {
+	next          http.Handler
+	parser        *jwt.Parser
+	secret        []byte
+	publicKey     interface{}
+	jwks          *jwks
+	issuer        string
+	audience      []string
+	clockSkew     time.Duration
+	allowNoExp    bool
+	claimsHeaders map[string]string
+	headerField   string
+	removeHeader  bool
+	name          string
+	now           func() time.Time
+}
+
+// NewJWT creates a JWT middleware.
+func NewJWT(ctx context.Context, next http.Handler, config dynamic.JWT, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, jwtTypeName).Debug().Msg("Creating middleware")
+
+	ja, err := newJWTAuth(next, config, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ja, nil
+}
+
+// NewJWTClaimsDecorator creates a handler which validates the bearer token of the requests, if any,
+// and stores its claims in the request context before routing, for the JWTClaim matcher.
+// The requests are forwarded whether they have a valid token or not, as the routing decides what to do with them.
+func NewJWTClaimsDecorator(ctx context.Context, next http.Handler, config dynamic.JWT, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, jwtTypeName).Debug().Msg("Creating JWT claims decorator")
+
+	verifier, err := newJWTAuth(next, config, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwtClaimsDecorator{next: next, verifier: verifier}, nil
+}
+
+func newJWTAuth(next http.Handler, config dynamic.JWT, name string) (*jwtAuth, error) {
+	if config.Secret == "" && config.PublicKey == "" && config.JWKSURL == "" {
+		return nil, errors.New("one of secret, publicKey or jwksUrl must be defined")
+	}
+
+	ja := &jwtAuth{
+		next:          next,
+		issuer:        config.Issuer,
+		audience:      config.Audience,
+		clockSkew:     time.Duration(config.ClockSkew),
+		allowNoExp:    config.AllowMissingExpiration,
+		claimsHeaders: config.ClaimsHeaders,
+		headerField:   config.HeaderField,
+		removeHeader:  config.RemoveHeader,
+		name:          name,
+		now:           time.Now,
+	}
+
+	if config.Secret != "" {
+		ja.secret = []byte(config.Secret)
+	}
+
+	if config.PublicKey != "" {
+		publicKey, err := parsePublicKey(config.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse public key: %w", err)
+		}
+		ja.publicKey = publicKey
+	}
+
+	if config.JWKSURL != "" {
+		if _, err := url.ParseRequestURI(config.JWKSURL); err != nil {
+			return nil, fmt.Errorf("invalid JWKS URL: %w", err)
+		}
+
+		refreshInterval := time.Duration(config.JWKSRefreshInterval)
+		if refreshInterval <= 0 {
+			refreshInterval = time.Hour
+		}
+		ja.jwks = newJWKS(config.JWKSURL, refreshInterval)
+	}
+
+	algorithms := config.Algorithms
+	if len(algorithms) == 0 {
+		if ja.secret != nil {
+			algorithms = append(algorithms, hmacAlgorithms...)
+		}
+		if ja.publicKey != nil || ja.jwks != nil {
+			algorithms = append(algorithms, asymmetricAlgorithms...)
+		}
+	}
+
+	for _, alg := range algorithms {
+		if jwt.GetSigningMethod(alg) == nil || alg == jwt.SigningMethodNone.Alg() {
+			return nil, fmt.Errorf("unsupported algorithm: %s", alg)
+		}
+	}
+
+	ja.parser = jwt.NewParser(jwt.WithValidMethods(algorithms), jwt.WithoutClaimsValidation())
+
+	return ja, nil
+}
+
+func (j *jwtAuth) GetTracingInformation() (string, ext.SpanKindEnum) {
+	return j.name, tracing.SpanKindNoneEnum
+}
+
+func (j *jwtAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), j.name, jwtTypeName)
+
+	// The headers set from the claims must not be forged by the client.
+	for header := range j.claimsHeaders {
+		req.Header.Del(header)
+	}
+	if j.headerField != "" {
+		req.Header.Del(j.headerField)
+	}
+
+	raw, ok := bearerToken(req)
+	if !ok {
+		logger.Debug().Msg("Authentication failed: no bearer token")
+		tracing.SetErrorWithEvent(req, "Authentication failed")
+
+		rw.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := j.parse(req.Context(), raw)
+	if err != nil {
+		logger.Debug().Err(err).Msg("Authentication failed")
+		tracing.SetErrorWithEvent(req, "Authentication failed")
+
+		rw.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	subject, _ := claims["sub"].(string)
+
+	logData := accesslog.GetLogData(req)
+	if logData != nil {
+		logData.Core[accesslog.ClientUsername] = subject
+	}
+
+	logger.Debug().Msg("Authentication succeeded")
+
+	for header, claim := range j.claimsHeaders {
+		if value, ok := formatClaim(requestdecorator.LookupJWTClaim(claims, claim)); ok {
+			req.Header.Set(header, value)
+		}
+	}
+
+	if j.headerField != "" && subject != "" {
+		req.Header[j.headerField] = []string{subject}
+	}
+
+	if j.removeHeader {
+		logger.Debug().Msg("Removing authorization header")
+		req.Header.Del(authorizationHeader)
+	}
+
+	j.next.ServeHTTP(rw, req.WithContext(requestdecorator.WithJWTClaims(req.Context(), claims)))
+}
+
+type jwtClaimsDecorator struct {
+	next     http.Handler
+	verifier *jwtAuth
+}
+
+func (d *jwtClaimsDecorator) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	raw, ok := bearerToken(req)
+	if !ok {
+		d.next.ServeHTTP(rw, req)
+		return
+	}
+
+	claims, err := d.verifier.parse(req.Context(), raw)
+	if err != nil {
+		middlewares.GetLogger(req.Context(), d.verifier.name, jwtTypeName).Debug().Err(err).Msg("Ignoring invalid bearer token")
+		d.next.ServeHTTP(rw, req)
+		return
+	}
+
+	d.next.ServeHTTP(rw, req.WithContext(requestdecorator.WithJWTClaims(req.Context(), claims)))
+}
+
+// parse verifies the signature and the registered claims of the token, and returns its claims.
+func (j *jwtAuth) parse(ctx context.Context, raw string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := j.parser.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		return j.key(ctx, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := j.validate(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// key returns the key used to verify the signature of the token.
+// The key is chosen by the family of the signing algorithm,
+// so that a public key can never be used as an HMAC secret.
+func (j *jwtAuth) key(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if j.secret == nil {
+			return nil, errors.New("no secret defined")
+		}
+		return j.secret, nil
+
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA, *jwt.SigningMethodEd25519:
+		if j.publicKey != nil && keyMatches(token.Method, j.publicKey) {
+			return j.publicKey, nil
+		}
+
+		if j.jwks == nil {
+			return nil, errors.New("no public key defined for the algorithm")
+		}
+
+		kid, _ := token.Header["kid"].(string)
+
+		key, err := j.jwks.key(ctx, kid, token.Method.Alg())
+		if err != nil {
+			return nil, err
+		}
+
+		if !keyMatches(token.Method, key) {
+			return nil, fmt.Errorf("key %q does not match the algorithm", kid)
+		}
+		return key, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", token.Method.Alg())
+	}
+}
+
+// validate checks the registered claims of the token.
+func (j *jwtAuth) validate(claims jwt.MapClaims) error {
+	now := j.now()
+
+	if _, ok := claims["exp"]; !ok && !j.allowNoExp {
+		return errors.New("token has no expiration time")
+	}
+
+	if !claims.VerifyExpiresAt(now.Add(-j.clockSkew).Unix(), false) {
+		return errors.New("token is expired")
+	}
+
+	if !claims.VerifyNotBefore(now.Add(j.clockSkew).Unix(), false) {
+		return errors.New("token is not valid yet")
+	}
+
+	if j.issuer != "" && !claims.VerifyIssuer(j.issuer, true) {
+		return errors.New("invalid issuer")
+	}
+
+	if len(j.audience) == 0 {
+		return nil
+	}
+
+	for _, audience := range j.audience {
+		if claims.VerifyAudience(audience, true) {
+			return nil
+		}
+	}
+
+	return errors.New("invalid audience")
+}
+
+// bearerToken returns the token of the bearer authorization header of the request.
+func bearerToken(req *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(req.Header.Get(authorizationHeader), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+// keyMatches returns whether the key can be used with the signing method.
+func keyMatches(method jwt.SigningMethod, key interface{}) bool {
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, ok := key.(*rsa.PublicKey)
+		return ok
+	case *jwt.SigningMethodECDSA:
+		_, ok := key.(*ecdsa.PublicKey)
+		return ok
+	case *jwt.SigningMethodEd25519:
+		_, ok := key.(ed25519.PublicKey)
+		return ok
+	default:
+		return false
+	}
+}
+
+// parsePublicKey parses a PEM encoded PKIX or PKCS #1 public key.
+func parsePublicKey(data string) (interface{}, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	default:
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+}
+
+// formatClaim returns the value of a claim as a header value.
+// Arrays are joined with commas, and objects are encoded as JSON.
+func formatClaim(claim interface{}) (string, bool) {
+	switch c := claim.(type) {
+	case nil:
+		return "", false
+	case string:
+		return c, true
+	case bool:
+		return strconv.FormatBool(c), true
+	case float64:
+		return strconv.FormatFloat(c, 'f', -1, 64), true
+	case []interface{}:
+		values := make([]string, 0, len(c))
+		for _, elem := range c {
+			if value, ok := formatClaim(elem); ok {
+				values = append(values, value)
+			}
+		}
+		return strings.Join(values, ","), true
+	default:
+		data, err := json.Marshal(c)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+}
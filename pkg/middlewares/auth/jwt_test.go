@@ -0,0 +1,610 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
+	httpmuxer "github.com/traefik/traefik/v2/pkg/muxer/http"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestNewJWT(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      dynamic.JWT
+		expectedErr bool
+	}{
+		{
+			desc:   "secret",
+			config: dynamic.JWT{Secret: "secret"},
+		},
+		{
+			desc:        "no key",
+			config:      dynamic.JWT{Issuer: "issuer"},
+			expectedErr: true,
+		},
+		{
+			desc:        "invalid public key",
+			config:      dynamic.JWT{PublicKey: "foo"},
+			expectedErr: true,
+		},
+		{
+			desc:        "invalid JWKS URL",
+			config:      dynamic.JWT{JWKSURL: "foo"},
+			expectedErr: true,
+		},
+		{
+			desc:        "unknown algorithm",
+			config:      dynamic.JWT{Secret: "secret", Algorithms: []string{"HS1024"}},
+			expectedErr: true,
+		},
+		{
+			desc:        "none algorithm",
+			config:      dynamic.JWT{Secret: "secret", Algorithms: []string{"none"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewJWT(context.Background(), http.NotFoundHandler(), test.config, "jwt")
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJWT(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ed25519PublicKey, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	rsaPEM := publicKeyPEM(t, &rsaKey.PublicKey)
+
+	now := time.Now()
+
+	noExpToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "foo"}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc           string
+		config         dynamic.JWT
+		authorization  string
+		expectedStatus int
+		expectedAuth   string
+	}{
+		{
+			desc:           "HS256",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "RS256",
+			config:         dynamic.JWT{PublicKey: rsaPEM},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodRS256, rsaKey, jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "PS256",
+			config:         dynamic.JWT{PublicKey: rsaPEM},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodPS256, rsaKey, jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "ES256",
+			config:         dynamic.JWT{PublicKey: publicKeyPEM(t, &ecdsaKey.PublicKey)},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodES256, ecdsaKey, jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "EdDSA",
+			config:         dynamic.JWT{PublicKey: publicKeyPEM(t, ed25519PublicKey)},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodEdDSA, ed25519Key, jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "case insensitive scheme",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "no token",
+			config:         dynamic.JWT{Secret: "secret"},
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   "Bearer",
+		},
+		{
+			desc:           "basic authorization",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Basic dGVzdDp0ZXN0",
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   "Bearer",
+		},
+		{
+			desc:           "malformed token",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Bearer foo",
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "bad signature",
+			config:         dynamic.JWT{PublicKey: rsaPEM},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodRS256, otherRSAKey, jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "wrong secret",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("other"), jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "public key used as HMAC secret",
+			config:         dynamic.JWT{PublicKey: rsaPEM},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(rsaPEM), jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "algorithm not allowed",
+			config:         dynamic.JWT{Secret: "secret", PublicKey: rsaPEM, Algorithms: []string{"RS256"}},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "none algorithm",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "expired",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "expired within clock skew",
+			config:         dynamic.JWT{Secret: "secret", ClockSkew: ptypes.Duration(2 * time.Minute)},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "not expired",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"exp": now.Add(time.Minute).Unix()}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "missing expiration",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Bearer " + noExpToken,
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "missing expiration allowed",
+			config:         dynamic.JWT{Secret: "secret", AllowMissingExpiration: true},
+			authorization:  "Bearer " + noExpToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "not valid yet",
+			config:         dynamic.JWT{Secret: "secret"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"nbf": now.Add(time.Minute).Unix()}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "not valid yet within clock skew",
+			config:         dynamic.JWT{Secret: "secret", ClockSkew: ptypes.Duration(2 * time.Minute)},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"nbf": now.Add(time.Minute).Unix()}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "issuer",
+			config:         dynamic.JWT{Secret: "secret", Issuer: "https://issuer.localhost"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"iss": "https://issuer.localhost"}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "wrong issuer",
+			config:         dynamic.JWT{Secret: "secret", Issuer: "https://issuer.localhost"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"iss": "https://other.localhost"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "missing issuer",
+			config:         dynamic.JWT{Secret: "secret", Issuer: "https://issuer.localhost"},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "audience",
+			config:         dynamic.JWT{Secret: "secret", Audience: []string{"foo", "bar"}},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"aud": []string{"baz", "bar"}}),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "wrong audience",
+			config:         dynamic.JWT{Secret: "secret", Audience: []string{"foo"}},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"aud": "bar"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+		{
+			desc:           "missing audience",
+			config:         dynamic.JWT{Secret: "secret", Audience: []string{"foo"}},
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": "foo"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedAuth:   `Bearer error="invalid_token"`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+			handler, err := NewJWT(context.Background(), next, test.config, "jwt")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			if test.authorization != "" {
+				req.Header.Set(authorizationHeader, test.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.Equal(t, test.expectedAuth, recorder.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestJWT_headers(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		config          dynamic.JWT
+		expectedHeaders map[string]string
+	}{
+		{
+			desc: "claims headers",
+			config: dynamic.JWT{
+				Secret: "secret",
+				ClaimsHeaders: map[string]string{
+					"X-Email":   "email",
+					"X-Groups":  "groups",
+					"X-Tenant":  "org.tenant",
+					"X-Admin":   "admin",
+					"X-Level":   "level",
+					"X-Org":     "org",
+					"X-Missing": "missing",
+				},
+			},
+			expectedHeaders: map[string]string{
+				"Authorization": "Bearer",
+				"X-Email":       "foo@localhost",
+				"X-Groups":      "dev,ops",
+				"X-Tenant":      "acme",
+				"X-Admin":       "true",
+				"X-Level":       "42",
+				"X-Org":         `{"tenant":"acme"}`,
+				"X-Missing":     "",
+			},
+		},
+		{
+			desc: "header field and remove header",
+			config: dynamic.JWT{
+				Secret:       "secret",
+				HeaderField:  "X-Subject",
+				RemoveHeader: true,
+			},
+			expectedHeaders: map[string]string{
+				"Authorization": "",
+				"X-Subject":     "foo",
+				"X-Email":       "",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			token := signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{
+				"sub":    "foo",
+				"email":  "foo@localhost",
+				"groups": []string{"dev", "ops"},
+				"org":    map[string]interface{}{"tenant": "acme"},
+				"admin":  true,
+				"level":  42,
+			})
+
+			var forwarded http.Header
+			var claims map[string]interface{}
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwarded = req.Header
+				claims = requestdecorator.GetJWTClaims(req.Context())
+			})
+
+			handler, err := NewJWT(context.Background(), next, test.config, "jwt")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+			// Headers forged by the client are not forwarded.
+			req.Header.Set("X-Missing", "forged")
+			req.Header.Set("X-Subject", "forged")
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			for name, value := range test.expectedHeaders {
+				if name == authorizationHeader && value != "" {
+					assert.Contains(t, forwarded.Get(name), value)
+					continue
+				}
+				assert.Equal(t, value, forwarded.Get(name), name)
+			}
+
+			assert.Equal(t, "foo", claims["sub"])
+		})
+	}
+}
+
+func TestJWT_jwks(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var fetches int
+	keys := []jose.JSONWebKey{{Key: &key1.PublicKey, KeyID: "key1", Algorithm: "RS256", Use: "sig"}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fetches++
+		require.NoError(t, json.NewEncoder(rw).Encode(jose.JSONWebKeySet{Keys: keys}))
+	}))
+	t.Cleanup(server.Close)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	handler, err := NewJWT(context.Background(), next, dynamic.JWT{JWKSURL: server.URL, JWKSRefreshInterval: ptypes.Duration(time.Hour)}, "jwt")
+	require.NoError(t, err)
+
+	now := time.Now()
+	handler.(*jwtAuth).jwks.now = func() time.Time { return now }
+
+	serve := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+		req.Header.Set(authorizationHeader, "Bearer "+token)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		return recorder.Code
+	}
+
+	token1 := signToken(t, jwt.SigningMethodRS256, key1, jwt.MapClaims{"sub": "foo"}, "key1")
+	token2 := signToken(t, jwt.SigningMethodES256, key2, jwt.MapClaims{"sub": "foo"}, "key2")
+
+	assert.Equal(t, http.StatusOK, serve(token1))
+	assert.Equal(t, http.StatusOK, serve(token1))
+	assert.Equal(t, 1, fetches)
+
+	// The keys are rotated.
+	mu.Lock()
+	keys = []jose.JSONWebKey{{Key: &key2.PublicKey, KeyID: "key2", Algorithm: "ES256", Use: "sig"}}
+	mu.Unlock()
+
+	// The unknown key is not fetched again before the minimum refresh interval.
+	assert.Equal(t, http.StatusUnauthorized, serve(token2))
+	assert.Equal(t, 1, fetches)
+
+	now = now.Add(jwksMinRefreshInterval)
+
+	assert.Equal(t, http.StatusOK, serve(token2))
+	assert.Equal(t, 2, fetches)
+
+	// The removed key is not used anymore.
+	assert.Equal(t, http.StatusUnauthorized, serve(token1))
+	assert.Equal(t, 2, fetches)
+
+	// The key set is fetched again when stale.
+	now = now.Add(time.Hour)
+
+	assert.Equal(t, http.StatusOK, serve(token2))
+	assert.Equal(t, 3, fetches)
+}
+
+func TestJWT_jwksUnavailable(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	available := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !available {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, Algorithm: "RS256"}}}
+		require.NoError(t, json.NewEncoder(rw).Encode(set))
+	}))
+	t.Cleanup(server.Close)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	handler, err := NewJWT(context.Background(), next, dynamic.JWT{JWKSURL: server.URL}, "jwt")
+	require.NoError(t, err)
+
+	now := time.Now()
+	handler.(*jwtAuth).jwks.now = func() time.Time { return now }
+
+	// The token has no key ID, and the key set holds a single key.
+	token := signToken(t, jwt.SigningMethodRS256, key, jwt.MapClaims{"sub": "foo"})
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	req.Header.Set(authorizationHeader, "Bearer "+token)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	mu.Lock()
+	available = false
+	mu.Unlock()
+
+	// The cached keys are still used when the key set cannot be fetched again.
+	now = now.Add(2 * time.Hour)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestJWTClaimsDecorator(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		authorization string
+		expected      int
+	}{
+		{
+			desc:     "no token",
+			expected: http.StatusNotFound,
+		},
+		{
+			desc:          "valid token with matching claim",
+			authorization: "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"tenant": "acme"}),
+			expected:      http.StatusOK,
+		},
+		{
+			desc:          "valid token with non matching claim",
+			authorization: "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"tenant": "other"}),
+			expected:      http.StatusNotFound,
+		},
+		{
+			desc:          "invalid signature",
+			authorization: "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("other"), jwt.MapClaims{"tenant": "acme"}),
+			expected:      http.StatusNotFound,
+		},
+		{
+			desc:          "expired token",
+			authorization: "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"tenant": "acme", "exp": time.Now().Add(-time.Minute).Unix()}),
+			expected:      http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := httpmuxer.NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute("JWTClaim(`tenant`, `acme`)", 0, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+			require.NoError(t, err)
+
+			handler, err := NewJWTClaimsDecorator(context.Background(), muxer, dynamic.JWT{Secret: "secret"}, "jwt")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+			if test.authorization != "" {
+				req.Header.Set(authorizationHeader, test.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expected, recorder.Code)
+		})
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims, kid ...string) string {
+	t.Helper()
+
+	// The tokens without expiration time are refused by default.
+	if _, ok := claims["exp"]; !ok {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	if len(kid) > 0 {
+		token.Header["kid"] = kid[0]
+	}
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func publicKeyPEM(t *testing.T, key interface{}) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
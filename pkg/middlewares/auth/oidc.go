@@ -0,0 +1,662 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/middlewares/accesslog"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
+	"github.com/traefik/traefik/v2/pkg/tracing"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	oidcTypeName = "OIDC"
+
+	// oidcStateMaxAge is how long the users have to authenticate with the provider.
+	oidcStateMaxAge = 10 * time.Minute
+	// oidcDefaultSessionDuration is the duration of a session when the provider does not tell when the tokens expire.
+	oidcDefaultSessionDuration = time.Hour
+	// oidcDiscoveryRetryInterval is the minimum duration between two attempts to discover the provider configuration.
+	oidcDiscoveryRetryInterval = 10 * time.Second
+)
+
+// oidcProvider holds the OpenID Connect provider metadata.
+// More info: https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+// oidcTokens holds the response of the token endpoint.
+type oidcTokens struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	IDToken          string `json:"id_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type oidcAuth struct {
+	next                  http.Handler
+	issuer                string
+	clientID              string
+	clientSecret          string
+	scopes                []string
+	redirectURL           *url.URL
+	logoutURL             string
+	postLogoutRedirectURL string
+	sessionName           string
+	store                 *cookieStore
+	forwardAccessToken    bool
+	claimsHeaders         map[string]string
+	headerField           string
+	name                  string
+	client                *http.Client
+	now                   func() time.Time
+
+	discoveries singleflight.Group
+
+	mu          sync.RWMutex
+	provider    *oidcProvider
+	verifier    *jwtAuth
+	attemptedAt time.Time
+}
+
+// NewOIDC creates an OpenID Connect middleware.
+func NewOIDC(ctx context.Context, next http.Handler, config dynamic.OIDC, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, oidcTypeName).Debug().Msg("Creating middleware")
+
+	if config.Issuer == "" {
+		return nil, errors.New("issuer must be defined")
+	}
+
+	if config.ClientID == "" {
+		return nil, errors.New("clientId must be defined")
+	}
+
+	if config.Secret == "" {
+		return nil, errors.New("secret must be defined")
+	}
+
+	redirectURL, err := url.Parse(config.RedirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect URL: %w", err)
+	}
+	if redirectURL.Path == "" {
+		return nil, errors.New("redirectUrl must be defined")
+	}
+
+	session := config.Session
+	if session == nil {
+		session = &dynamic.OIDCSession{}
+		session.SetDefaults()
+	}
+
+	sessionName := session.Name
+	if sessionName == "" {
+		sessionName = "traefik_oidc"
+	}
+
+	store, err := newCookieStore(config.Secret, session.Path, session.Domain, session.Secure, session.SameSite)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := config.Scopes
+	if !containsString(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	oa := &oidcAuth{
+		next:                  next,
+		issuer:                strings.TrimSuffix(config.Issuer, "/"),
+		clientID:              config.ClientID,
+		clientSecret:          config.ClientSecret,
+		scopes:                scopes,
+		redirectURL:           redirectURL,
+		logoutURL:             config.LogoutURL,
+		postLogoutRedirectURL: config.PostLogoutRedirectURL,
+		sessionName:           sessionName,
+		store:                 store,
+		forwardAccessToken:    config.ForwardAccessToken,
+		claimsHeaders:         config.ClaimsHeaders,
+		headerField:           config.HeaderField,
+		name:                  name,
+		client:                &http.Client{Timeout: 10 * time.Second},
+		now:                   time.Now,
+	}
+
+	if config.TLS != nil {
+		tlsConfig, err := config.TLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create client TLS configuration: %w", err)
+		}
+
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = tlsConfig
+		oa.client.Transport = tr
+	}
+
+	return oa, nil
+}
+
+func (o *oidcAuth) GetTracingInformation() (string, ext.SpanKindEnum) {
+	return o.name, tracing.SpanKindNoneEnum
+}
+
+func (o *oidcAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), o.name, oidcTypeName)
+
+	// The headers set from the claims must not be forged by the client.
+	for header := range o.claimsHeaders {
+		req.Header.Del(header)
+	}
+	if o.headerField != "" {
+		req.Header.Del(o.headerField)
+	}
+
+	provider, verifier, err := o.discover()
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to discover the OpenID Connect provider configuration")
+		tracing.SetErrorWithEvent(req, "Unable to discover the OpenID Connect provider configuration")
+
+		http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+
+	switch {
+	case req.URL.Path == o.redirectURL.Path:
+		o.callback(rw, req, provider, verifier)
+		return
+	case o.logoutURL != "" && req.URL.Path == o.logoutURL:
+		o.logout(rw, req, provider)
+		return
+	}
+
+	var session oidcSession
+	if err := o.store.load(req, o.sessionName, &session); err != nil {
+		if !errors.Is(err, http.ErrNoCookie) {
+			logger.Debug().Err(err).Msg("Invalid session")
+		}
+		o.authenticate(rw, req, provider)
+		return
+	}
+
+	if !o.now().Before(session.Expiry) {
+		if err := o.refresh(req.Context(), provider, verifier, &session); err != nil {
+			logger.Debug().Err(err).Msg("Unable to refresh the session")
+			o.authenticate(rw, req, provider)
+			return
+		}
+
+		if err := o.store.save(rw, req, o.sessionName, session, 0); err != nil {
+			logger.Error().Err(err).Msg("Unable to save the session")
+			http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// The ID token was verified when the session was created, and the session cookie is authenticated.
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(session.IDToken, claims); err != nil {
+		logger.Debug().Err(err).Msg("Invalid session")
+		o.authenticate(rw, req, provider)
+		return
+	}
+
+	subject, _ := claims["sub"].(string)
+
+	logData := accesslog.GetLogData(req)
+	if logData != nil {
+		logData.Core[accesslog.ClientUsername] = subject
+	}
+
+	for header, claim := range o.claimsHeaders {
+		if value, ok := formatClaim(requestdecorator.LookupJWTClaim(claims, claim)); ok {
+			req.Header.Set(header, value)
+		}
+	}
+
+	if o.headerField != "" && subject != "" {
+		req.Header[o.headerField] = []string{subject}
+	}
+
+	if o.forwardAccessToken && session.AccessToken != "" {
+		req.Header.Set(authorizationHeader, "Bearer "+session.AccessToken)
+	}
+
+	o.next.ServeHTTP(rw, req.WithContext(requestdecorator.WithJWTClaims(req.Context(), claims)))
+}
+
+// authenticate redirects the user to the authorization endpoint of the provider,
+// with the authorization code flow and PKCE.
+func (o *oidcAuth) authenticate(rw http.ResponseWriter, req *http.Request, provider *oidcProvider) {
+	logger := middlewares.GetLogger(req.Context(), o.name, oidcTypeName)
+
+	// The other requests, such as form submissions, cannot be replayed after the authentication.
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		tracing.SetErrorWithEvent(req, "Authentication required")
+		http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	state, err := newOIDCState(req.URL.RequestURI())
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to create the authentication state")
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if err := o.store.save(rw, req, o.stateName(), state, oidcStateMaxAge); err != nil {
+		logger.Error().Err(err).Msg("Unable to save the authentication state")
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	authURL, err := url.Parse(provider.AuthorizationEndpoint)
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid authorization endpoint")
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(state.CodeVerifier))
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", o.clientID)
+	query.Set("redirect_uri", o.redirectURI(req))
+	query.Set("scope", strings.Join(o.scopes, " "))
+	query.Set("state", state.State)
+	query.Set("nonce", state.Nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	logger.Debug().Msg("Redirecting to the OpenID Connect provider")
+	http.Redirect(rw, req, authURL.String(), http.StatusFound)
+}
+
+// callback creates the session of the user redirected by the provider after authentication,
+// and redirects the user to the originally requested URL.
+func (o *oidcAuth) callback(rw http.ResponseWriter, req *http.Request, provider *oidcProvider, verifier *jwtAuth) {
+	logger := middlewares.GetLogger(req.Context(), o.name, oidcTypeName)
+
+	var state oidcState
+	if err := o.store.load(req, o.stateName(), &state); err != nil {
+		o.fail(rw, req, fmt.Errorf("invalid authentication state: %w", err))
+		return
+	}
+
+	o.store.clear(rw, req, o.stateName())
+
+	query := req.URL.Query()
+	if query.Get("error") != "" {
+		o.fail(rw, req, fmt.Errorf("authentication error: %s: %s", query.Get("error"), query.Get("error_description")))
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state.State)) != 1 {
+		o.fail(rw, req, errors.New("state mismatch"))
+		return
+	}
+
+	tokens, err := o.exchange(req.Context(), provider, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {query.Get("code")},
+		"redirect_uri":  {o.redirectURI(req)},
+		"code_verifier": {state.CodeVerifier},
+	})
+	if err != nil {
+		o.fail(rw, req, fmt.Errorf("unable to exchange the authorization code: %w", err))
+		return
+	}
+
+	claims, err := verifier.parse(req.Context(), tokens.IDToken)
+	if err != nil {
+		o.fail(rw, req, fmt.Errorf("invalid ID token: %w", err))
+		return
+	}
+
+	if nonce, _ := claims["nonce"].(string); subtle.ConstantTimeCompare([]byte(nonce), []byte(state.Nonce)) != 1 {
+		o.fail(rw, req, errors.New("nonce mismatch"))
+		return
+	}
+
+	session := oidcSession{
+		IDToken:      tokens.IDToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		Expiry:       o.expiry(tokens, claims),
+	}
+
+	if err := o.store.save(rw, req, o.sessionName, session, 0); err != nil {
+		logger.Error().Err(err).Msg("Unable to save the session")
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Debug().Msg("Authentication succeeded")
+
+	http.Redirect(rw, req, localRedirect(state.RedirectURL), http.StatusFound)
+}
+
+// logout removes the session of the user, and redirects the user to the end session endpoint of the provider, if any.
+func (o *oidcAuth) logout(rw http.ResponseWriter, req *http.Request, provider *oidcProvider) {
+	var session oidcSession
+	_ = o.store.load(req, o.sessionName, &session)
+
+	o.store.clear(rw, req, o.sessionName)
+
+	if provider.EndSessionEndpoint == "" {
+		redirectURL := o.postLogoutRedirectURL
+		if redirectURL == "" {
+			redirectURL = "/"
+		}
+
+		http.Redirect(rw, req, redirectURL, http.StatusFound)
+		return
+	}
+
+	logoutURL, err := url.Parse(provider.EndSessionEndpoint)
+	if err != nil {
+		middlewares.GetLogger(req.Context(), o.name, oidcTypeName).Error().Err(err).Msg("Invalid end session endpoint")
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	query := logoutURL.Query()
+	query.Set("client_id", o.clientID)
+	if session.IDToken != "" {
+		query.Set("id_token_hint", session.IDToken)
+	}
+	if o.postLogoutRedirectURL != "" {
+		query.Set("post_logout_redirect_uri", o.postLogoutRedirectURL)
+	}
+	logoutURL.RawQuery = query.Encode()
+
+	http.Redirect(rw, req, logoutURL.String(), http.StatusFound)
+}
+
+// refresh renews the tokens of the session with its refresh token.
+func (o *oidcAuth) refresh(ctx context.Context, provider *oidcProvider, verifier *jwtAuth, session *oidcSession) error {
+	if session.RefreshToken == "" {
+		return errors.New("session expired")
+	}
+
+	tokens, err := o.exchange(ctx, provider, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {session.RefreshToken},
+	})
+	if err != nil {
+		return err
+	}
+
+	var claims jwt.MapClaims
+	if tokens.IDToken != "" {
+		claims, err = verifier.parse(ctx, tokens.IDToken)
+		if err != nil {
+			return fmt.Errorf("invalid ID token: %w", err)
+		}
+		session.IDToken = tokens.IDToken
+	}
+
+	session.AccessToken = tokens.AccessToken
+	if tokens.RefreshToken != "" {
+		session.RefreshToken = tokens.RefreshToken
+	}
+	session.Expiry = o.expiry(tokens, claims)
+
+	return nil
+}
+
+// exchange requests tokens to the token endpoint of the provider.
+func (o *oidcAuth) exchange(ctx context.Context, provider *oidcProvider, form url.Values) (*oidcTokens, error) {
+	if o.clientSecret == "" {
+		form.Set("client_id", o.clientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(o.clientID), url.QueryEscape(o.clientSecret))
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens oidcTokens
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("unable to parse token response (status code %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d: %s: %s", resp.StatusCode, tokens.Error, tokens.ErrorDescription)
+	}
+
+	if tokens.IDToken == "" && form.Get("grant_type") == "authorization_code" {
+		return nil, errors.New("no ID token in token response")
+	}
+
+	return &tokens, nil
+}
+
+// discover returns the provider metadata, and the verifier of the ID tokens,
+// fetched from the discovery endpoint of the issuer on first use.
+// The configuration is fetched by a single request at a time, without holding the lock used by the other requests,
+// and independently of the requests waiting for it, so that a canceled request does not fail the discovery.
+func (o *oidcAuth) discover() (*oidcProvider, *jwtAuth, error) {
+	o.mu.RLock()
+	provider, verifier := o.provider, o.verifier
+	o.mu.RUnlock()
+
+	if provider != nil {
+		return provider, verifier, nil
+	}
+
+	_, err, _ := o.discoveries.Do(o.issuer, func() (interface{}, error) {
+		o.mu.Lock()
+		if o.provider != nil {
+			// The configuration has just been discovered by a concurrent request.
+			o.mu.Unlock()
+			return nil, nil
+		}
+
+		if o.now().Sub(o.attemptedAt) < oidcDiscoveryRetryInterval {
+			o.mu.Unlock()
+			return nil, errors.New("provider configuration not available yet")
+		}
+		o.attemptedAt = o.now()
+		o.mu.Unlock()
+
+		provider, verifier, err := o.fetchProvider()
+		if err != nil {
+			return nil, err
+		}
+
+		o.mu.Lock()
+		o.provider = provider
+		o.verifier = verifier
+		o.mu.Unlock()
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.provider, o.verifier, nil
+}
+
+// fetchProvider fetches the provider metadata from the discovery endpoint of the issuer,
+// and builds the verifier of the ID tokens.
+// The fetch is bounded by the timeout of the client, rather than by the context of a request.
+func (o *oidcAuth) fetchProvider() (*oidcProvider, *jwtAuth, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, o.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var provider oidcProvider
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&provider); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse provider configuration: %w", err)
+	}
+
+	if strings.TrimSuffix(provider.Issuer, "/") != o.issuer {
+		return nil, nil, fmt.Errorf("issuer mismatch: %q", provider.Issuer)
+	}
+
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, nil, errors.New("incomplete provider configuration")
+	}
+
+	algorithms := append([]string{}, asymmetricAlgorithms...)
+
+	verifier := &jwtAuth{
+		jwks:     newJWKS(provider.JWKSURI, time.Hour),
+		issuer:   provider.Issuer,
+		audience: []string{o.clientID},
+		now:      func() time.Time { return o.now() },
+	}
+	verifier.jwks.client = o.client
+
+	// The ID tokens can be signed with the client secret.
+	if o.clientSecret != "" {
+		verifier.secret = []byte(o.clientSecret)
+		algorithms = append(algorithms, hmacAlgorithms...)
+	}
+
+	verifier.parser = jwt.NewParser(jwt.WithValidMethods(algorithms), jwt.WithoutClaimsValidation())
+
+	return &provider, verifier, nil
+}
+
+// fail responds to an authentication which could not be completed.
+func (o *oidcAuth) fail(rw http.ResponseWriter, req *http.Request, err error) {
+	middlewares.GetLogger(req.Context(), o.name, oidcTypeName).Debug().Err(err).Msg("Authentication failed")
+	tracing.SetErrorWithEvent(req, "Authentication failed")
+
+	http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+// expiry returns the expiration time of the session, from the expiration of the access token, or of the ID token.
+func (o *oidcAuth) expiry(tokens *oidcTokens, claims jwt.MapClaims) time.Time {
+	if tokens.ExpiresIn > 0 {
+		return o.now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
+	}
+
+	if exp, ok := claims["exp"].(float64); ok {
+		return time.Unix(int64(exp), 0)
+	}
+
+	return o.now().Add(oidcDefaultSessionDuration)
+}
+
+// redirectURI returns the absolute redirect URL, on the host of the request if the configured one is a path.
+func (o *oidcAuth) redirectURI(req *http.Request) string {
+	if o.redirectURL.IsAbs() {
+		return o.redirectURL.String()
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	u := url.URL{Scheme: scheme, Host: req.Host, Path: o.redirectURL.Path, RawQuery: o.redirectURL.RawQuery}
+
+	return u.String()
+}
+
+func (o *oidcAuth) stateName() string {
+	return o.sessionName + "_state"
+}
+
+// localRedirect returns the given URL if it is a path on the same host, and the root path otherwise,
+// so that the users cannot be redirected to another site.
+func localRedirect(redirectURL string) string {
+	if !strings.HasPrefix(redirectURL, "/") || strings.HasPrefix(redirectURL, "//") || strings.HasPrefix(redirectURL, "/\\") {
+		return "/"
+	}
+
+	return redirectURL
+}
+
+// newOIDCState returns the state of a new authentication, with random state, nonce and PKCE code verifier.
+func newOIDCState(redirectURL string) (oidcState, error) {
+	values := make([]string, 3)
+	for i := range values {
+		data := make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, data); err != nil {
+			return oidcState{}, err
+		}
+		values[i] = base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	return oidcState{
+		State:        values[0],
+		Nonce:        values[1],
+		CodeVerifier: values[2],
+		RedirectURL:  redirectURL,
+	}, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
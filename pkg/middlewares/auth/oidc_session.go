@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCookieChunkSize is the maximum size of the value of a cookie,
+// above which the value is split across several cookies, as the browsers limit the size of the cookies to 4KB.
+const maxCookieChunkSize = 3800
+
+// oidcSession holds the tokens of an authenticated user.
+type oidcSession struct {
+	IDToken      string    `json:"idToken"`
+	AccessToken  string    `json:"accessToken,omitempty"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// oidcState holds the state of an authentication in progress.
+type oidcState struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+	RedirectURL  string `json:"redirectUrl"`
+}
+
+// cookieStore stores values in encrypted cookies.
+type cookieStore struct {
+	aead     cipher.AEAD
+	path     string
+	domain   string
+	secure   bool
+	sameSite http.SameSite
+}
+
+func newCookieStore(secret, path, domain string, secure bool, sameSite string) (*cookieStore, error) {
+	// The key is derived from the secret, so that secrets of any length can be used.
+	key := sha256.Sum256([]byte(secret))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cookieStore{
+		aead:     aead,
+		path:     path,
+		domain:   domain,
+		secure:   secure,
+		sameSite: convertSameSite(sameSite),
+	}, nil
+}
+
+// load decrypts the value stored in the cookie with the given name, which may be split across several cookies.
+func (s *cookieStore) load(req *http.Request, name string, value interface{}) error {
+	cookie, err := req.Cookie(name)
+	if err != nil {
+		return err
+	}
+
+	encoded := cookie.Value
+	for i := 1; ; i++ {
+		chunk, err := req.Cookie(chunkName(name, i))
+		if err != nil {
+			break
+		}
+		encoded += chunk.Value
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(data) < nonceSize {
+		return errors.New("cookie value too short")
+	}
+
+	// The name of the cookie is authenticated, so that the value of a cookie cannot be used as the value of another.
+	plaintext, err := s.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(name))
+	if err != nil {
+		return fmt.Errorf("unable to decrypt cookie: %w", err)
+	}
+
+	return json.Unmarshal(plaintext, value)
+}
+
+// save encrypts the value in the cookie with the given name, split across several cookies if needed.
+// The chunks of the previous value which are not used anymore are removed.
+func (s *cookieStore) save(rw http.ResponseWriter, req *http.Request, name string, value interface{}, maxAge time.Duration) error {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(s.aead.Seal(nonce, nonce, plaintext, []byte(name)))
+
+	var i int
+	for ; len(encoded) > 0; i++ {
+		size := len(encoded)
+		if size > maxCookieChunkSize {
+			size = maxCookieChunkSize
+		}
+
+		http.SetCookie(rw, s.cookie(chunkName(name, i), encoded[:size], maxAge))
+		encoded = encoded[size:]
+	}
+
+	s.clearChunks(rw, req, name, i)
+
+	return nil
+}
+
+// clear removes the cookie with the given name, and all its chunks.
+func (s *cookieStore) clear(rw http.ResponseWriter, req *http.Request, name string) {
+	s.clearChunks(rw, req, name, 0)
+}
+
+// clearChunks removes the chunks of the cookie with the given name, from the given index.
+func (s *cookieStore) clearChunks(rw http.ResponseWriter, req *http.Request, name string, from int) {
+	for _, cookie := range req.Cookies() {
+		index, ok := chunkIndex(name, cookie.Name)
+		if ok && index >= from {
+			http.SetCookie(rw, s.cookie(cookie.Name, "", -1))
+		}
+	}
+}
+
+func (s *cookieStore) cookie(name, value string, maxAge time.Duration) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     s.path,
+		Domain:   s.domain,
+		Secure:   s.secure,
+		HttpOnly: true,
+		SameSite: s.sameSite,
+	}
+
+	switch {
+	case maxAge < 0:
+		cookie.MaxAge = -1
+	case maxAge > 0:
+		cookie.MaxAge = int(maxAge.Seconds())
+	}
+
+	return cookie
+}
+
+// chunkName returns the name of the cookie holding the chunk of the given index.
+func chunkName(name string, index int) string {
+	if index == 0 {
+		return name
+	}
+	return name + "_" + strconv.Itoa(index)
+}
+
+// chunkIndex returns the index of the chunk held by the cookie with the given name.
+func chunkIndex(name, cookieName string) (int, bool) {
+	if cookieName == name {
+		return 0, true
+	}
+
+	suffix := strings.TrimPrefix(cookieName, name+"_")
+	if suffix == cookieName {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(suffix)
+	if err != nil || index < 1 {
+		return 0, false
+	}
+
+	return index, true
+}
+
+func convertSameSite(sameSite string) http.SameSite {
+	switch strings.ToLower(sameSite) {
+	case "none":
+		return http.SameSiteNoneMode
+	case "strict":
+		return http.SameSiteStrictMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
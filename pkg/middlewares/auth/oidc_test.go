@@ -0,0 +1,610 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestNewOIDC(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      dynamic.OIDC
+		expectedErr bool
+	}{
+		{
+			desc: "valid",
+			config: dynamic.OIDC{
+				Issuer:      "https://issuer.localhost",
+				ClientID:    "client",
+				Secret:      "secret",
+				RedirectURL: "/oidc/callback",
+			},
+		},
+		{
+			desc: "no issuer",
+			config: dynamic.OIDC{
+				ClientID:    "client",
+				Secret:      "secret",
+				RedirectURL: "/oidc/callback",
+			},
+			expectedErr: true,
+		},
+		{
+			desc: "no client ID",
+			config: dynamic.OIDC{
+				Issuer:      "https://issuer.localhost",
+				Secret:      "secret",
+				RedirectURL: "/oidc/callback",
+			},
+			expectedErr: true,
+		},
+		{
+			desc: "no secret",
+			config: dynamic.OIDC{
+				Issuer:      "https://issuer.localhost",
+				ClientID:    "client",
+				RedirectURL: "/oidc/callback",
+			},
+			expectedErr: true,
+		},
+		{
+			desc: "no redirect URL",
+			config: dynamic.OIDC{
+				Issuer:   "https://issuer.localhost",
+				ClientID: "client",
+				Secret:   "secret",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewOIDC(context.Background(), http.NotFoundHandler(), test.config, "oidc")
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOIDC(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	var forwarded *http.Request
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		forwarded = req
+	})
+
+	handler, err := NewOIDC(context.Background(), next, issuer.config(), "oidc")
+	require.NoError(t, err)
+
+	browser := newMockBrowser(t, handler)
+
+	// The unauthenticated user is redirected to the provider.
+	recorder := browser.get("http://app.localhost/dashboard?tab=1")
+	require.Equal(t, http.StatusFound, recorder.Code)
+
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, issuer.server.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+
+	query := location.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "client", query.Get("client_id"))
+	assert.Equal(t, "http://app.localhost/oidc/callback", query.Get("redirect_uri"))
+	assert.Equal(t, "openid profile email", query.Get("scope"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.NotEmpty(t, query.Get("code_challenge"))
+	assert.NotEmpty(t, query.Get("state"))
+	assert.NotEmpty(t, query.Get("nonce"))
+
+	// The user is redirected back with an authorization code.
+	callback := issuer.authorize(location)
+
+	recorder = browser.get(callback)
+	require.Equal(t, http.StatusFound, recorder.Code)
+	assert.Equal(t, "/dashboard?tab=1", recorder.Header().Get("Location"))
+	assert.Nil(t, forwarded)
+
+	// The authenticated user reaches the backend, with the identity claims.
+	recorder = browser.get("http://app.localhost/dashboard?tab=1")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NotNil(t, forwarded)
+
+	assert.Equal(t, "foo@localhost", forwarded.Header.Get("X-Email"))
+	assert.Equal(t, "dev,ops", forwarded.Header.Get("X-Groups"))
+	assert.Equal(t, "foo", forwarded.Header.Get("X-Subject"))
+	assert.Equal(t, "Bearer access-1", forwarded.Header.Get("Authorization"))
+
+	// The headers forged by the client are not forwarded.
+	forwarded = nil
+	browser.header.Set("X-Email", "admin@localhost")
+
+	recorder = browser.get("http://app.localhost/")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "foo@localhost", forwarded.Header.Get("X-Email"))
+}
+
+func TestOIDC_callback(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		tamper func(callback *url.URL)
+	}{
+		{
+			desc: "state mismatch",
+			tamper: func(callback *url.URL) {
+				query := callback.Query()
+				query.Set("state", "forged")
+				callback.RawQuery = query.Encode()
+			},
+		},
+		{
+			desc: "invalid code",
+			tamper: func(callback *url.URL) {
+				query := callback.Query()
+				query.Set("code", "forged")
+				callback.RawQuery = query.Encode()
+			},
+		},
+		{
+			desc: "provider error",
+			tamper: func(callback *url.URL) {
+				callback.RawQuery = url.Values{"error": {"access_denied"}, "state": {callback.Query().Get("state")}}.Encode()
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			issuer := newMockIssuer(t)
+
+			handler, err := NewOIDC(context.Background(), http.NotFoundHandler(), issuer.config(), "oidc")
+			require.NoError(t, err)
+
+			browser := newMockBrowser(t, handler)
+
+			recorder := browser.get("http://app.localhost/")
+			require.Equal(t, http.StatusFound, recorder.Code)
+
+			location, err := url.Parse(recorder.Header().Get("Location"))
+			require.NoError(t, err)
+
+			callback, err := url.Parse(issuer.authorize(location))
+			require.NoError(t, err)
+
+			test.tamper(callback)
+
+			recorder = browser.get(callback.String())
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+			// The session is not created.
+			recorder = browser.get("http://app.localhost/")
+			assert.Equal(t, http.StatusFound, recorder.Code)
+		})
+	}
+}
+
+func TestOIDC_callbackWithoutState(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	handler, err := NewOIDC(context.Background(), http.NotFoundHandler(), issuer.config(), "oidc")
+	require.NoError(t, err)
+
+	recorder := newMockBrowser(t, handler).get("http://app.localhost/oidc/callback?code=foo&state=bar")
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestOIDC_refresh(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	handler, err := NewOIDC(context.Background(), next, issuer.config(), "oidc")
+	require.NoError(t, err)
+
+	now := time.Now()
+	handler.(*oidcAuth).now = func() time.Time { return now }
+
+	browser := newMockBrowser(t, handler)
+	browser.login(issuer)
+
+	// The access token expires.
+	now = now.Add(2 * time.Hour)
+
+	recorder := browser.get("http://app.localhost/")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, 1, issuer.refreshes())
+	assert.NotEmpty(t, recorder.Header().Values("Set-Cookie"))
+
+	// The refreshed session is used.
+	recorder = browser.get("http://app.localhost/")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, 1, issuer.refreshes())
+
+	// The refresh token is revoked.
+	now = now.Add(2 * time.Hour)
+	issuer.revoke()
+
+	recorder = browser.get("http://app.localhost/")
+	assert.Equal(t, http.StatusFound, recorder.Code)
+	assert.True(t, strings.HasPrefix(recorder.Header().Get("Location"), issuer.server.URL+"/authorize"))
+}
+
+func TestOIDC_logout(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	handler, err := NewOIDC(context.Background(), next, issuer.config(), "oidc")
+	require.NoError(t, err)
+
+	browser := newMockBrowser(t, handler)
+	browser.login(issuer)
+
+	recorder := browser.get("http://app.localhost/oidc/logout")
+	require.Equal(t, http.StatusFound, recorder.Code)
+
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, issuer.server.URL+"/logout", location.Scheme+"://"+location.Host+location.Path)
+	assert.NotEmpty(t, location.Query().Get("id_token_hint"))
+	assert.Equal(t, "http://app.localhost/bye", location.Query().Get("post_logout_redirect_uri"))
+
+	// The session is removed.
+	recorder = browser.get("http://app.localhost/")
+	assert.Equal(t, http.StatusFound, recorder.Code)
+}
+
+func TestOIDC_unauthenticated(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	handler, err := NewOIDC(context.Background(), http.NotFoundHandler(), issuer.config(), "oidc")
+	require.NoError(t, err)
+
+	browser := newMockBrowser(t, handler)
+
+	// The requests which cannot be replayed after the authentication are refused.
+	req := httptest.NewRequest(http.MethodPost, "http://app.localhost/form", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	// A tampered session is ignored.
+	browser.cookies["traefik_oidc"] = &http.Cookie{Name: "traefik_oidc", Value: "forged"}
+
+	recorder = browser.get("http://app.localhost/")
+	assert.Equal(t, http.StatusFound, recorder.Code)
+}
+
+func TestOIDC_discoveryFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	config := dynamic.OIDC{
+		Issuer:      server.URL,
+		ClientID:    "client",
+		Secret:      "secret",
+		RedirectURL: "/oidc/callback",
+	}
+
+	handler, err := NewOIDC(context.Background(), http.NotFoundHandler(), config, "oidc")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://app.localhost/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
+
+func TestCookieStore(t *testing.T) {
+	store, err := newCookieStore("secret", "/", "", true, "lax")
+	require.NoError(t, err)
+
+	// The value does not fit in a single cookie.
+	value := oidcSession{IDToken: strings.Repeat("a", 2*maxCookieChunkSize)}
+
+	recorder := httptest.NewRecorder()
+	require.NoError(t, store.save(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil), "session", value, 0))
+
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 3)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	for _, cookie := range cookies {
+		assert.True(t, cookie.HttpOnly)
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+		req.AddCookie(cookie)
+	}
+
+	var loaded oidcSession
+	require.NoError(t, store.load(req, "session", &loaded))
+	assert.Equal(t, value, loaded)
+
+	// The value of a cookie cannot be used as the value of another.
+	var state oidcState
+	renamed := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+	for _, cookie := range cookies {
+		renamed.AddCookie(&http.Cookie{Name: strings.Replace(cookie.Name, "session", "state", 1), Value: cookie.Value})
+	}
+	require.Error(t, store.load(renamed, "state", &state))
+
+	// The unused chunks are removed when a smaller value is saved.
+	recorder = httptest.NewRecorder()
+	require.NoError(t, store.save(recorder, req, "session", oidcSession{IDToken: "a"}, 0))
+
+	var removed []string
+	for _, cookie := range recorder.Result().Cookies() {
+		if cookie.MaxAge < 0 {
+			removed = append(removed, cookie.Name)
+		}
+	}
+	assert.ElementsMatch(t, []string{"session_1", "session_2"}, removed)
+}
+
+// mockIssuer is an OpenID Connect provider, for the tests.
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu              sync.Mutex
+	codes           map[string]url.Values
+	refreshTokens   map[string]bool
+	refreshCount    int
+	issuedResponses int
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &mockIssuer{
+		t:             t,
+		key:           key,
+		codes:         make(map[string]url.Values),
+		refreshTokens: make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	mux.HandleFunc("/token", issuer.token)
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+func (m *mockIssuer) config() dynamic.OIDC {
+	config := dynamic.OIDC{
+		Issuer:                m.server.URL,
+		ClientID:              "client",
+		ClientSecret:          "client-secret",
+		LogoutURL:             "/oidc/logout",
+		PostLogoutRedirectURL: "http://app.localhost/bye",
+		Secret:                "secret",
+		ForwardAccessToken:    true,
+		ClaimsHeaders:         map[string]string{"X-Email": "email", "X-Groups": "groups"},
+		HeaderField:           "X-Subject",
+	}
+	config.SetDefaults()
+
+	return config
+}
+
+func (m *mockIssuer) discovery(rw http.ResponseWriter, req *http.Request) {
+	_ = json.NewEncoder(rw).Encode(oidcProvider{
+		Issuer:                m.server.URL,
+		AuthorizationEndpoint: m.server.URL + "/authorize",
+		TokenEndpoint:         m.server.URL + "/token",
+		JWKSURI:               m.server.URL + "/jwks",
+		EndSessionEndpoint:    m.server.URL + "/logout",
+	})
+}
+
+func (m *mockIssuer) jwks(rw http.ResponseWriter, req *http.Request) {
+	_ = json.NewEncoder(rw).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &m.key.PublicKey, KeyID: "key", Algorithm: "RS256", Use: "sig"},
+	}})
+}
+
+// authorize authenticates the user, as the authorization endpoint would, and returns the callback URL.
+func (m *mockIssuer) authorize(location *url.URL) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	query := location.Query()
+
+	code := fmt.Sprintf("code-%d", len(m.codes))
+	m.codes[code] = query
+
+	callback, err := url.Parse(query.Get("redirect_uri"))
+	require.NoError(m.t, err)
+
+	callback.RawQuery = url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+
+	return callback.String()
+}
+
+func (m *mockIssuer) token(rw http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clientID, clientSecret, ok := req.BasicAuth()
+	if !ok || clientID != "client" || clientSecret != "client-secret" {
+		m.error(rw, "invalid_client")
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		m.error(rw, "invalid_request")
+		return
+	}
+
+	var nonce string
+	switch req.PostForm.Get("grant_type") {
+	case "authorization_code":
+		authorization, ok := m.codes[req.PostForm.Get("code")]
+		if !ok {
+			m.error(rw, "invalid_grant")
+			return
+		}
+		delete(m.codes, req.PostForm.Get("code"))
+
+		challenge := sha256.Sum256([]byte(req.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(challenge[:]) != authorization.Get("code_challenge") ||
+			req.PostForm.Get("redirect_uri") != authorization.Get("redirect_uri") {
+			m.error(rw, "invalid_grant")
+			return
+		}
+
+		nonce = authorization.Get("nonce")
+
+	case "refresh_token":
+		if !m.refreshTokens[req.PostForm.Get("refresh_token")] {
+			m.error(rw, "invalid_grant")
+			return
+		}
+		delete(m.refreshTokens, req.PostForm.Get("refresh_token"))
+		m.refreshCount++
+
+	default:
+		m.error(rw, "unsupported_grant_type")
+		return
+	}
+
+	m.issuedResponses++
+
+	claims := jwt.MapClaims{
+		"iss":    m.server.URL,
+		"aud":    "client",
+		"sub":    "foo",
+		"email":  "foo@localhost",
+		"groups": []string{"dev", "ops"},
+		"exp":    time.Now().Add(24 * time.Hour).Unix(),
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key"
+
+	idToken, err := token.SignedString(m.key)
+	require.NoError(m.t, err)
+
+	refreshToken := fmt.Sprintf("refresh-%d", m.issuedResponses)
+	m.refreshTokens[refreshToken] = true
+
+	_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+		"access_token":  fmt.Sprintf("access-%d", m.issuedResponses),
+		"token_type":    "Bearer",
+		"refresh_token": refreshToken,
+		"id_token":      idToken,
+		"expires_in":    3600,
+	})
+}
+
+func (m *mockIssuer) error(rw http.ResponseWriter, code string) {
+	rw.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(rw).Encode(map[string]string{"error": code})
+}
+
+func (m *mockIssuer) refreshes() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.refreshCount
+}
+
+// revoke revokes all the refresh tokens.
+func (m *mockIssuer) revoke() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.refreshTokens = make(map[string]bool)
+}
+
+// mockBrowser sends requests to a handler, keeping the cookies it sets.
+type mockBrowser struct {
+	t       *testing.T
+	handler http.Handler
+	header  http.Header
+	cookies map[string]*http.Cookie
+}
+
+func newMockBrowser(t *testing.T, handler http.Handler) *mockBrowser {
+	t.Helper()
+
+	return &mockBrowser{
+		t:       t,
+		handler: handler,
+		header:  make(http.Header),
+		cookies: make(map[string]*http.Cookie),
+	}
+}
+
+func (b *mockBrowser) get(target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, values := range b.header {
+		req.Header[name] = values
+	}
+	for _, cookie := range b.cookies {
+		req.AddCookie(cookie)
+	}
+
+	recorder := httptest.NewRecorder()
+	b.handler.ServeHTTP(recorder, req)
+
+	for _, cookie := range recorder.Result().Cookies() {
+		if cookie.MaxAge < 0 {
+			delete(b.cookies, cookie.Name)
+			continue
+		}
+		b.cookies[cookie.Name] = cookie
+	}
+
+	return recorder
+}
+
+// login authenticates the user with the issuer.
+func (b *mockBrowser) login(issuer *mockIssuer) {
+	recorder := b.get("http://app.localhost/")
+	require.Equal(b.t, http.StatusFound, recorder.Code)
+
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	require.NoError(b.t, err)
+
+	recorder = b.get(issuer.authorize(location))
+	require.Equal(b.t, http.StatusFound, recorder.Code)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/logs"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 )
 
 // Header is a middleware that helps setup a few basic security features.
@@ -76,10 +77,10 @@ func (s *Header) modifyCustomRequestHeaders(req *http.Request) {
 			req.Header.Del(header)
 
 		case strings.EqualFold(header, "Host"):
-			req.Host = value
+			req.Host = requestdecorator.ReplacePathParams(req.Context(), value, nil)
 
 		default:
-			req.Header.Set(header, value)
+			req.Header.Set(header, requestdecorator.ReplacePathParams(req.Context(), value, nil))
 		}
 	}
 }
@@ -90,9 +91,14 @@ func (s *Header) modifyCustomRequestHeaders(req *http.Request) {
 func (s *Header) PostRequestModifyResponseHeaders(res *http.Response) error {
 	// Loop through Custom response headers
 	for header, value := range s.headers.CustomResponseHeaders {
-		if value == "" {
+		switch {
+		case value == "":
 			res.Header.Del(header)
-		} else {
+
+		case res.Request != nil:
+			res.Header.Set(header, requestdecorator.ReplacePathParams(res.Request.Context(), value, nil))
+
+		default:
 			res.Header.Set(header, value)
 		}
 	}
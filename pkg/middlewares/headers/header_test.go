@@ -8,12 +8,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 )
 
 func TestNewHeader_customRequestHeader(t *testing.T) {
 	testCases := []struct {
 		desc     string
 		cfg      dynamic.Headers
+		params   map[string]string
 		expected http.Header
 	}{
 		{
@@ -44,6 +46,16 @@ func TestNewHeader_customRequestHeader(t *testing.T) {
 			},
 			expected: http.Header{"Foo": []string{"test"}},
 		},
+		{
+			desc: "adds a header with path parameters",
+			cfg: dynamic.Headers{
+				CustomRequestHeaders: map[string]string{
+					"X-User-Id": "user-{id}{unknown}",
+				},
+			},
+			params:   map[string]string{"id": "42"},
+			expected: http.Header{"Foo": []string{"bar"}, "X-User-Id": []string{"user-42{unknown}"}},
+		},
 	}
 
 	emptyHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
@@ -59,6 +71,10 @@ func TestNewHeader_customRequestHeader(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/foo", nil)
 			req.Header.Set("Foo", "bar")
 
+			if test.params != nil {
+				req = req.WithContext(requestdecorator.WithPathParams(req.Context(), test.params))
+			}
+
 			rw := httptest.NewRecorder()
 
 			mid.ServeHTTP(rw, req)
@@ -492,6 +508,7 @@ func TestNewHeader_customResponseHeaders(t *testing.T) {
 	testCases := []struct {
 		desc     string
 		config   map[string]string
+		params   map[string]string
 		expected http.Header
 	}{
 		{
@@ -527,6 +544,17 @@ func TestNewHeader_customResponseHeaders(t *testing.T) {
 				"Testing": {"foo"},
 			},
 		},
+		{
+			desc: "Custom Header with path parameters",
+			config: map[string]string{
+				"Testing": "foo-{id}",
+			},
+			params: map[string]string{"id": "42"},
+			expected: map[string][]string{
+				"Foo":     {"bar"},
+				"Testing": {"foo-42"},
+			},
+		},
 	}
 
 	emptyHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -540,6 +568,9 @@ func TestNewHeader_customResponseHeaders(t *testing.T) {
 			require.NoError(t, err)
 
 			req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+			if test.params != nil {
+				req = req.WithContext(requestdecorator.WithPathParams(req.Context(), test.params))
+			}
 
 			rw := httptest.NewRecorder()
 
@@ -0,0 +1,107 @@
+package ratelimiter
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// decision is the result of the rate limiting of a request.
+type decision struct {
+	allowed bool
+	// delay is the duration after which a request of the source would be allowed, when it is not.
+	delay time.Duration
+	quota quota
+}
+
+// quota is the state of the rate limit of a source, as reported to the clients with the RateLimit headers.
+type quota struct {
+	// limit is the number of requests which can be sent at once.
+	limit int64
+	// remaining is the number of requests which can still be sent at once.
+	remaining int64
+	// reset is the duration after which the remaining requests are back to the limit.
+	reset time.Duration
+}
+
+func (q quota) setHeaders(header http.Header) {
+	header.Set("RateLimit-Limit", strconv.FormatInt(q.limit, 10))
+	header.Set("RateLimit-Remaining", strconv.FormatInt(q.remaining, 10))
+	header.Set("RateLimit-Reset", strconv.FormatFloat(math.Ceil(q.reset.Seconds()), 'f', 0, 64))
+}
+
+// bucket is the token bucket of a source.
+// It keeps track of its tokens, which the rate.Limiter does not expose, to report the quota of the source.
+type bucket struct {
+	limiter *rate.Limiter
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit rate.Limit, burst int64) *bucket {
+	return &bucket{
+		limiter: rate.NewLimiter(limit, int(burst)),
+		tokens:  float64(burst),
+	}
+}
+
+// reserve reserves a token at the given time.
+func (b *bucket) reserve(now time.Time) *rate.Reservation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := b.limiter.ReserveN(now, 1)
+	if res.OK() {
+		b.tokens = b.tokensAt(now) - 1
+		b.last = now
+	}
+
+	return res
+}
+
+// cancel cancels the given reservation, made at the given time.
+func (b *bucket) cancel(res *rate.Reservation, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res.CancelAt(now)
+	b.tokens = math.Min(b.tokens+1, float64(b.limiter.Burst()))
+}
+
+// quota returns the quota of the source at the given time.
+func (b *bucket) quota(now time.Time) quota {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	burst := float64(b.limiter.Burst())
+	tokens := b.tokensAt(now)
+
+	q := quota{limit: int64(burst)}
+	if tokens > 0 {
+		q.remaining = int64(math.Floor(tokens))
+	}
+
+	if limit := float64(b.limiter.Limit()); limit > 0 {
+		q.reset = time.Duration((burst - tokens) / limit * float64(time.Second))
+	}
+
+	return q
+}
+
+// tokensAt returns the number of tokens of the bucket at the given time,
+// computed as the rate.Limiter does.
+func (b *bucket) tokensAt(now time.Time) float64 {
+	if b.last.IsZero() || !now.After(b.last) {
+		return b.tokens
+	}
+
+	tokens := b.tokens + now.Sub(b.last).Seconds()*float64(b.limiter.Limit())
+
+	return math.Min(tokens, float64(b.limiter.Burst()))
+}
@@ -3,6 +3,7 @@ package ratelimiter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -24,9 +25,22 @@ const (
 )
 
 // rateLimiter implements rate limiting and traffic shaping with a set of token buckets;
-// one for each traffic source. The same parameters are applied to all the buckets.
+// one for each traffic source. The parameters of the plan of a source are applied to its bucket.
 type rateLimiter struct {
-	name  string
+	name          string
+	sourceMatcher utils.SourceExtractor
+	next          http.Handler
+
+	// defaultPlan is applied to the sources which are not in any plan.
+	defaultPlan *plan
+	// plans are the plans, keyed by source.
+	plans map[string]*plan
+
+	buckets *ttlmap.TtlMap // actual buckets, keyed by source.
+}
+
+// plan holds the rate limiting parameters of a quota tier.
+type plan struct {
 	rate  rate.Limit // reqs/s
 	burst int64
 	// maxDelay is the maximum duration we're willing to wait for a bucket reservation to become effective, in nanoseconds.
@@ -36,11 +50,10 @@ type rateLimiter struct {
 	// To keep this ttlmap constrained in size,
 	// each ratelimiter is "garbage collected" when it is considered expired.
 	// It is considered expired after it hasn't been used for ttl seconds.
-	ttl           int
-	sourceMatcher utils.SourceExtractor
-	next          http.Handler
-
-	buckets *ttlmap.TtlMap // actual buckets, keyed by source.
+	ttl int
+	// store, when defined, limits the rate across the Traefik instances sharing it.
+	// The buckets are used when the store is unavailable.
+	store *redisLimiter
 }
 
 // New returns a rate limiter middleware.
@@ -68,12 +81,45 @@ func New(ctx context.Context, next http.Handler, config dynamic.RateLimit, name
 		return nil, err
 	}
 
-	burst := config.Burst
+	defaultPlan, err := newPlan(ctxLog, name, config.Average, time.Duration(config.Period), config.Burst, config.Redis)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make(map[string]*plan)
+	for planName, planConfig := range config.Plans {
+		if planConfig == nil {
+			continue
+		}
+
+		p, err := newPlan(ctxLog, name, planConfig.Average, time.Duration(planConfig.Period), planConfig.Burst, config.Redis)
+		if err != nil {
+			return nil, fmt.Errorf("plan %s: %w", planName, err)
+		}
+
+		for _, source := range planConfig.Sources {
+			if _, exists := plans[source]; exists {
+				return nil, fmt.Errorf("plan %s: source %q is already in another plan", planName, source)
+			}
+			plans[source] = p
+		}
+	}
+
+	return &rateLimiter{
+		name:          name,
+		next:          next,
+		sourceMatcher: sourceMatcher,
+		defaultPlan:   defaultPlan,
+		plans:         plans,
+		buckets:       buckets,
+	}, nil
+}
+
+func newPlan(ctx context.Context, name string, average int64, period time.Duration, burst int64, redis *dynamic.RateLimitRedis) (*plan, error) {
 	if burst < 1 {
 		burst = 1
 	}
 
-	period := time.Duration(config.Period)
 	if period < 0 {
 		return nil, fmt.Errorf("negative value not valid for period: %v", period)
 	}
@@ -81,12 +127,12 @@ func New(ctx context.Context, next http.Handler, config dynamic.RateLimit, name
 		period = time.Second
 	}
 
-	// if config.Average == 0, in that case,
+	// if average == 0, in that case,
 	// the value of maxDelay does not matter since the reservation will (buggily) give us a delay of 0 anyway.
 	var maxDelay time.Duration
 	var rtl float64
-	if config.Average > 0 {
-		rtl = float64(config.Average*int64(time.Second)) / float64(period)
+	if average > 0 {
+		rtl = float64(average*int64(time.Second)) / float64(period)
 		// maxDelay does not scale well for rates below 1,
 		// so we just cap it to the corresponding value, i.e. 0.5s, in order to keep the effective rate predictable.
 		// One alternative would be to switch to a no-reservation mode (Allow() method) whenever we are in such a low rate regime.
@@ -108,15 +154,27 @@ func New(ctx context.Context, next http.Handler, config dynamic.RateLimit, name
 		ttl += int(1 / rtl)
 	}
 
-	return &rateLimiter{
-		name:          name,
-		rate:          rate.Limit(rtl),
-		burst:         burst,
-		maxDelay:      maxDelay,
-		next:          next,
-		sourceMatcher: sourceMatcher,
-		buckets:       buckets,
-		ttl:           ttl,
+	var store *redisLimiter
+	if redis != nil && average > 0 {
+		// The sliding window of the store allows the average requests of a period at once,
+		// and has no notion of burst.
+		if burst > 1 {
+			return nil, errors.New("burst is not supported with the Redis store")
+		}
+
+		var err error
+		store, err = newRedisLimiter(ctx, name, redis, average, period)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &plan{
+		rate:     rate.Limit(rtl),
+		burst:    burst,
+		maxDelay: maxDelay,
+		ttl:      ttl,
+		store:    store,
 	}, nil
 }
 
@@ -139,17 +197,40 @@ func (rl *rateLimiter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		logger.Info().Msgf("ignoring token bucket amount > 1: %d", amount)
 	}
 
-	var bucket *rate.Limiter
+	p := rl.defaultPlan
+	if sourcePlan, ok := rl.plans[source]; ok {
+		p = sourcePlan
+	}
+
+	if p.store != nil && p.store.available() {
+		d, err := p.store.allow(ctx, source)
+		if err == nil {
+			d.quota.setHeaders(rw.Header())
+
+			if !d.allowed {
+				rl.serveDelayError(ctx, rw, d.delay)
+				return
+			}
+
+			rl.next.ServeHTTP(rw, req)
+			return
+		}
+
+		p.store.fail()
+		logger.Warn().Err(err).Msg("Rate limiting store is unavailable, falling back to local rate limiting")
+	}
+
+	var b *bucket
 	if rlSource, exists := rl.buckets.Get(source); exists {
-		bucket = rlSource.(*rate.Limiter)
+		b = rlSource.(*bucket)
 	} else {
-		bucket = rate.NewLimiter(rl.rate, int(rl.burst))
+		b = newBucket(p.rate, p.burst)
 	}
 
 	// We Set even in the case where the source already exists,
 	// because we want to update the expiryTime everytime we get the source,
 	// as the expiryTime is supposed to reflect the activity (or lack thereof) on that source.
-	if err := rl.buckets.Set(source, bucket, rl.ttl); err != nil {
+	if err := rl.buckets.Set(source, b, p.ttl); err != nil {
 		logger.Error().Err(err).Msg("Could not insert/update bucket")
 		http.Error(rw, "could not insert/update bucket", http.StatusInternalServerError)
 		return
@@ -159,19 +240,28 @@ func (rl *rateLimiter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	// but also gives a 0 delay below (because of a division by zero, followed by a multiplication that flips into the negatives),
 	// regardless of the current load.
 	// However, for now we take advantage of this behavior to provide the no-limit ratelimiter when config.Average is 0.
-	res := bucket.Reserve()
+	now := time.Now()
+	res := b.reserve(now)
 	if !res.OK() {
 		http.Error(rw, "No bursty traffic allowed", http.StatusTooManyRequests)
 		return
 	}
 
-	delay := res.Delay()
-	if delay > rl.maxDelay {
-		res.Cancel()
+	delay := res.DelayFrom(now)
+	if delay > p.maxDelay {
+		b.cancel(res, now)
+		if p.rate > 0 {
+			b.quota(now).setHeaders(rw.Header())
+		}
 		rl.serveDelayError(ctx, rw, delay)
 		return
 	}
 
+	// The quota is not reported without rate limiting.
+	if p.rate > 0 {
+		b.quota(now).setHeaders(rw.Header())
+	}
+
 	time.Sleep(delay)
 	rl.next.ServeHTTP(rw, req)
 }
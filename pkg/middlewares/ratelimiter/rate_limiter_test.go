@@ -92,7 +92,7 @@ func TestNewRateLimiter(t *testing.T) {
 
 			rtl, _ := h.(*rateLimiter)
 			if test.expectedMaxDelay != 0 {
-				assert.Equal(t, test.expectedMaxDelay, rtl.maxDelay)
+				assert.Equal(t, test.expectedMaxDelay, rtl.defaultPlan.maxDelay)
 			}
 
 			if test.expectedSourceIP != "" {
@@ -329,3 +329,128 @@ func computeMinCount(wantCount int) int {
 
 	return wantCount * 95 / 100
 }
+
+func TestRateLimitHeaders(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	h, err := New(context.Background(), next, dynamic.RateLimit{Average: 10, Burst: 3}, "rate-limiter")
+	require.NoError(t, err)
+
+	for _, remaining := range []string{"2", "1", "0"} {
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "3", recorder.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, remaining, recorder.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", recorder.Header().Get("RateLimit-Reset"))
+	}
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "3", recorder.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", recorder.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+}
+
+func TestRateLimitHeaders_noLimit(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	h, err := New(context.Background(), next, dynamic.RateLimit{}, "rate-limiter")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitPlans(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		apiKey        string
+		expectedLimit string
+		expectedOK    int
+	}{
+		{
+			desc:          "source in plan",
+			apiKey:        "gold-key",
+			expectedLimit: "5",
+			expectedOK:    5,
+		},
+		{
+			desc:          "other source in plan",
+			apiKey:        "other-gold-key",
+			expectedLimit: "5",
+			expectedOK:    5,
+		},
+		{
+			desc:          "unknown source",
+			apiKey:        "unknown-key",
+			expectedLimit: "1",
+			expectedOK:    1,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			config := dynamic.RateLimit{
+				Average:         1,
+				Period:          ptypes.Duration(time.Minute),
+				Burst:           1,
+				SourceCriterion: &dynamic.SourceCriterion{RequestHeaderName: "X-Api-Key"},
+				Plans: map[string]*dynamic.RateLimitPlan{
+					"gold": {
+						Sources: []string{"gold-key", "other-gold-key"},
+						Average: 5,
+						Period:  ptypes.Duration(time.Minute),
+						Burst:   5,
+					},
+				},
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+			h, err := New(context.Background(), next, config, "rate-limiter")
+			require.NoError(t, err)
+
+			var ok int
+			for i := 0; i < 10; i++ {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+				req.Header.Set("X-Api-Key", test.apiKey)
+
+				recorder := httptest.NewRecorder()
+				h.ServeHTTP(recorder, req)
+
+				assert.Equal(t, test.expectedLimit, recorder.Header().Get("RateLimit-Limit"))
+				if recorder.Code == http.StatusOK {
+					ok++
+				}
+			}
+
+			assert.Equal(t, test.expectedOK, ok)
+		})
+	}
+}
+
+func TestNewRateLimiter_plans(t *testing.T) {
+	config := dynamic.RateLimit{
+		Average: 1,
+		Plans: map[string]*dynamic.RateLimitPlan{
+			"gold":   {Sources: []string{"foo"}, Average: 10},
+			"silver": {Sources: []string{"foo"}, Average: 5},
+		},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err := New(context.Background(), next, config, "rate-limiter")
+	assert.ErrorContains(t, err, `source "foo" is already in another plan`)
+}
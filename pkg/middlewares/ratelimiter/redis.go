@@ -0,0 +1,275 @@
+package ratelimiter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+const (
+	// redisKeyPrefix is the prefix of the keys of the request counters in the Redis store.
+	redisKeyPrefix = "traefik:ratelimit:"
+	// storeRecoveryDelay is how long the requests are limited locally after a failure of the store.
+	storeRecoveryDelay = time.Second
+	// redisClientCloseDelay is how long an unused Redis client is kept open,
+	// so that its connections are reused by the rate limiters of the next configuration.
+	redisClientCloseDelay = 10 * time.Second
+)
+
+// sharedRedisClient is a Redis client shared by the rate limiters with the same store configuration.
+type sharedRedisClient struct {
+	client redis.UniversalClient
+	// refs is the number of rate limiters using the client.
+	refs int
+	// closeTimer closes the client once it is not used anymore.
+	closeTimer *time.Timer
+}
+
+// redisClients holds the shared Redis clients, keyed by a hash of their configuration.
+var redisClients = struct {
+	sync.Mutex
+	byKey map[string]*sharedRedisClient
+}{byKey: make(map[string]*sharedRedisClient)}
+
+// acquireRedisClient returns the Redis client for the given configuration,
+// which is released once the given context is done, i.e. once the rate limiter is not used anymore.
+func acquireRedisClient(ctx context.Context, config *dynamic.RateLimitRedis) (redis.UniversalClient, error) {
+	if len(config.Endpoints) == 0 {
+		return nil, errors.New("no Redis endpoints defined")
+	}
+
+	key, err := redisClientKey(config)
+	if err != nil {
+		return nil, err
+	}
+
+	redisClients.Lock()
+	defer redisClients.Unlock()
+
+	shared, ok := redisClients.byKey[key]
+	if !ok {
+		client, err := newRedisClient(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+
+		shared = &sharedRedisClient{client: client}
+		redisClients.byKey[key] = shared
+	}
+
+	shared.refs++
+	if shared.closeTimer != nil {
+		shared.closeTimer.Stop()
+		shared.closeTimer = nil
+	}
+
+	go func() {
+		<-ctx.Done()
+		releaseRedisClient(key, shared)
+	}()
+
+	return shared.client, nil
+}
+
+// releaseRedisClient releases a reference to the given shared client,
+// and closes it after the redisClientCloseDelay if it is not used anymore by then.
+func releaseRedisClient(key string, shared *sharedRedisClient) {
+	redisClients.Lock()
+	defer redisClients.Unlock()
+
+	shared.refs--
+	if shared.refs > 0 {
+		return
+	}
+
+	shared.closeTimer = time.AfterFunc(redisClientCloseDelay, func() {
+		redisClients.Lock()
+		defer redisClients.Unlock()
+
+		if shared.refs > 0 || redisClients.byKey[key] != shared {
+			return
+		}
+
+		delete(redisClients.byKey, key)
+		_ = shared.client.Close()
+	})
+}
+
+// redisClientKey returns the key of the shared client for the given configuration.
+// The configuration is hashed, so that the credentials are not kept as is in the key.
+func redisClientKey(config *dynamic.RateLimitRedis) (string, error) {
+	// The timeout only applies to the operations, and the clients with different timeouts can be shared.
+	keyConfig := *config
+	keyConfig.Timeout = 0
+
+	data, err := json.Marshal(keyConfig)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func newRedisClient(ctx context.Context, config *dynamic.RateLimitRedis) (redis.UniversalClient, error) {
+
+	options := &redis.UniversalOptions{
+		Addrs:    config.Endpoints,
+		Username: config.Username,
+		Password: config.Password,
+		DB:       config.DB,
+	}
+
+	if config.TLS != nil {
+		tlsConfig, err := config.TLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create client TLS configuration: %w", err)
+		}
+		options.TLSConfig = tlsConfig
+	}
+
+	return redis.NewUniversalClient(options), nil
+}
+
+// redisLimiter limits the rate of the requests with counters shared in a Redis store,
+// with the sliding window algorithm: the requests of the previous window are added to the ones of the current window,
+// weighted by the part of the previous window which is still within the sliding window.
+type redisLimiter struct {
+	name    string
+	client  redis.UniversalClient
+	limit   int64
+	period  time.Duration
+	timeout time.Duration
+
+	now func() time.Time
+	// unavailableUntil is the time, in Unix nanoseconds, until which the store is not used after a failure.
+	unavailableUntil int64
+}
+
+func newRedisLimiter(ctx context.Context, name string, config *dynamic.RateLimitRedis, limit int64, period time.Duration) (*redisLimiter, error) {
+	client, err := acquireRedisClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(config.Timeout)
+	if timeout <= 0 {
+		timeout = 500 * time.Millisecond
+	}
+
+	return &redisLimiter{
+		name:    name,
+		client:  client,
+		limit:   limit,
+		period:  period,
+		timeout: timeout,
+		now:     time.Now,
+	}, nil
+}
+
+// available returns whether the store can be used, i.e. whether it did not fail recently.
+func (l *redisLimiter) available() bool {
+	return l.now().UnixNano() >= atomic.LoadInt64(&l.unavailableUntil)
+}
+
+// fail marks the store as unavailable for the storeRecoveryDelay.
+func (l *redisLimiter) fail() {
+	atomic.StoreInt64(&l.unavailableUntil, l.now().Add(storeRecoveryDelay).UnixNano())
+}
+
+// allow counts the request of the given source, and returns whether it is allowed.
+// As the counters are read and incremented in separate operations,
+// concurrent requests of a source may slightly exceed the limit.
+func (l *redisLimiter) allow(ctx context.Context, source string) (decision, error) {
+	now := l.now().UnixNano()
+	window := now / int64(l.period)
+	elapsed := time.Duration(now % int64(l.period))
+
+	// The source is a hash tag, so that both counters are stored in the same slot of a Redis cluster.
+	key := redisKeyPrefix + l.name + ":{" + source + "}:"
+	previousKey := key + strconv.FormatInt(window-1, 10)
+	currentKey := key + strconv.FormatInt(window, 10)
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	values, err := l.client.MGet(ctx, previousKey, currentKey).Result()
+	if err != nil {
+		return decision{}, err
+	}
+
+	previous, err := parseCounter(values[0])
+	if err != nil {
+		return decision{}, err
+	}
+
+	current, err := parseCounter(values[1])
+	if err != nil {
+		return decision{}, err
+	}
+
+	count := float64(previous)*(1-float64(elapsed)/float64(l.period)) + float64(current)
+	if count >= float64(l.limit) {
+		delay := l.delay(previous, count, elapsed)
+		return decision{delay: delay, quota: quota{limit: l.limit, reset: delay}}, nil
+	}
+
+	pipe := l.client.Pipeline()
+	pipe.Incr(ctx, currentKey)
+	// The counter is still used during the next window.
+	pipe.PExpire(ctx, currentKey, 2*l.period)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return decision{}, err
+	}
+
+	q := quota{
+		limit:     l.limit,
+		remaining: l.limit - int64(math.Ceil(count)) - 1,
+		reset:     l.period - elapsed,
+	}
+	if q.remaining < 0 {
+		q.remaining = 0
+	}
+
+	return decision{allowed: true, quota: q}, nil
+}
+
+// delay returns the estimated duration after which the count of the sliding window is below the limit.
+func (l *redisLimiter) delay(previous int64, count float64, elapsed time.Duration) time.Duration {
+	remaining := l.period - elapsed
+
+	if previous > 0 {
+		// The weight of the requests of the previous window decreases as the window slides.
+		delay := time.Duration((count - float64(l.limit) + 1) / float64(previous) * float64(l.period))
+		if delay < remaining {
+			return delay
+		}
+	}
+
+	return remaining
+}
+
+func parseCounter(value interface{}) (int64, error) {
+	if value == nil {
+		return 0, nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected counter value type %T", value)
+	}
+
+	return strconv.ParseInt(str, 10, 64)
+}
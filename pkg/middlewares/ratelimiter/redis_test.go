@@ -0,0 +1,370 @@
+package ratelimiter
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+func TestRateLimitRedis(t *testing.T) {
+	store := newRedisStandIn(t)
+
+	config := dynamic.RateLimit{
+		Average: 5,
+		Period:  ptypes.Duration(time.Minute),
+		Redis:   &dynamic.RateLimitRedis{Endpoints: []string{store.addr()}},
+	}
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// Two rate limiters with the same name, as in two Traefik instances sharing the store.
+	var handlers []http.Handler
+	for i := 0; i < 2; i++ {
+		h, err := New(context.Background(), next, config, "shared@file")
+		require.NoError(t, err)
+
+		h.(*rateLimiter).defaultPlan.store.now = func() time.Time { return now }
+		handlers = append(handlers, h)
+	}
+
+	var allowed, limited int
+	for i := 0; i < 10; i++ {
+		recorder := httptest.NewRecorder()
+		handlers[i%2].ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+		switch recorder.Code {
+		case http.StatusOK:
+			allowed++
+		case http.StatusTooManyRequests:
+			limited++
+			assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+			assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+		default:
+			t.Fatalf("unexpected status code %d", recorder.Code)
+		}
+	}
+
+	assert.Equal(t, 5, allowed)
+	assert.Equal(t, 5, limited)
+}
+
+func TestRedisLimiter_slidingWindow(t *testing.T) {
+	store := newRedisStandIn(t)
+
+	limiter, err := newRedisLimiter(context.Background(), "sliding", &dynamic.RateLimitRedis{Endpoints: []string{store.addr()}}, 4, time.Minute)
+	require.NoError(t, err)
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		d, err := limiter.allow(ctx, "source")
+		require.NoError(t, err)
+		assert.True(t, d.allowed)
+		assert.Equal(t, quota{limit: 4, remaining: int64(3 - i), reset: time.Minute}, d.quota)
+	}
+
+	d, err := limiter.allow(ctx, "source")
+	require.NoError(t, err)
+	assert.False(t, d.allowed)
+	assert.Equal(t, time.Minute, d.delay)
+	assert.Equal(t, quota{limit: 4, reset: time.Minute}, d.quota)
+
+	// Other sources have their own counters.
+	d, err = limiter.allow(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, d.allowed)
+
+	// A quarter into the next window, the previous requests weigh 3.
+	now = now.Add(time.Minute + 15*time.Second)
+
+	d, err = limiter.allow(ctx, "source")
+	require.NoError(t, err)
+	assert.True(t, d.allowed)
+
+	d, err = limiter.allow(ctx, "source")
+	require.NoError(t, err)
+	assert.False(t, d.allowed)
+	assert.Equal(t, 15*time.Second, d.delay)
+
+	// Two windows later, the previous requests are not counted anymore.
+	now = now.Add(2 * time.Minute)
+
+	d, err = limiter.allow(ctx, "source")
+	require.NoError(t, err)
+	assert.True(t, d.allowed)
+}
+
+func TestRateLimitRedis_fallback(t *testing.T) {
+	store := newRedisStandIn(t)
+	addr := store.addr()
+	store.close()
+
+	config := dynamic.RateLimit{
+		Average: 5,
+		Period:  ptypes.Duration(time.Minute),
+		Burst:   1,
+		Redis:   &dynamic.RateLimitRedis{Endpoints: []string{addr}, Timeout: ptypes.Duration(100 * time.Millisecond)},
+	}
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	h, err := New(context.Background(), next, config, "fallback@file")
+	require.NoError(t, err)
+
+	// The requests are limited by the local bucket.
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	assert.False(t, h.(*rateLimiter).defaultPlan.store.available())
+
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+}
+
+func TestRateLimitRedis_burst(t *testing.T) {
+	config := dynamic.RateLimit{
+		Average: 5,
+		Burst:   10,
+		Redis:   &dynamic.RateLimitRedis{Endpoints: []string{"127.0.0.1:6379"}},
+	}
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	_, err := New(context.Background(), next, config, "burst@file")
+	require.Error(t, err)
+}
+
+func TestAcquireRedisClient(t *testing.T) {
+	store := newRedisStandIn(t)
+
+	config := &dynamic.RateLimitRedis{Endpoints: []string{store.addr()}, Password: "secret"}
+
+	key, err := redisClientKey(config)
+	require.NoError(t, err)
+	assert.NotContains(t, key, "secret")
+
+	refs := func() (int, bool) {
+		redisClients.Lock()
+		defer redisClients.Unlock()
+
+		shared := redisClients.byKey[key]
+		return shared.refs, shared.closeTimer != nil
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+
+	client1, err := acquireRedisClient(ctx1, config)
+	require.NoError(t, err)
+
+	// The clients are shared by the rate limiters with the same store, whatever their timeout.
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	client2, err := acquireRedisClient(ctx2, &dynamic.RateLimitRedis{Endpoints: []string{store.addr()}, Password: "secret", Timeout: ptypes.Duration(time.Second)})
+	require.NoError(t, err)
+	assert.Same(t, client1, client2)
+
+	cancel1()
+	assert.Eventually(t, func() bool {
+		count, closing := refs()
+		return count == 1 && !closing
+	}, time.Second, 10*time.Millisecond)
+
+	// Once not used anymore, the client is closed after a delay.
+	cancel2()
+	assert.Eventually(t, func() bool {
+		count, closing := refs()
+		return count == 0 && closing
+	}, time.Second, 10*time.Millisecond)
+
+	// The client is reused if it is acquired again in the meantime.
+	ctx3, cancel3 := context.WithCancel(context.Background())
+	defer cancel3()
+
+	client3, err := acquireRedisClient(ctx3, config)
+	require.NoError(t, err)
+	assert.Same(t, client1, client3)
+
+	count, closing := refs()
+	assert.Equal(t, 1, count)
+	assert.False(t, closing)
+}
+
+// redisStandIn is an in-process stand-in for a Redis server,
+// implementing the subset of the Redis protocol used by the rate limiter.
+type redisStandIn struct {
+	listener net.Listener
+
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+func newRedisStandIn(t *testing.T) *redisStandIn {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &redisStandIn{
+		listener: listener,
+		values:   make(map[string]int64),
+	}
+
+	t.Cleanup(s.close)
+
+	go s.serve()
+
+	return s
+}
+
+func (s *redisStandIn) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *redisStandIn) close() {
+	_ = s.listener.Close()
+}
+
+func (s *redisStandIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.serveConn(conn)
+	}
+}
+
+func (s *redisStandIn) serveConn(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		if _, err := io.WriteString(conn, s.execute(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *redisStandIn) execute(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+
+	case "MGET":
+		reply := fmt.Sprintf("*%d\r\n", len(args)-1)
+		for _, key := range args[1:] {
+			value, ok := s.values[key]
+			if !ok {
+				reply += "$-1\r\n"
+				continue
+			}
+
+			str := strconv.FormatInt(value, 10)
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(str), str)
+		}
+		return reply
+
+	case "INCR":
+		s.values[args[1]]++
+		return fmt.Sprintf(":%d\r\n", s.values[args[1]])
+
+	case "PEXPIRE":
+		// The expirations are ignored, as the keys of the rate limiter are named after the windows.
+		if _, ok := s.values[args[1]]; !ok {
+			return ":0\r\n"
+		}
+		return ":1\r\n"
+
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+// readCommand reads a command, sent as an array of bulk strings.
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(line, "*") {
+		return nil, errors.New("command is not an array")
+	}
+
+	count, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.HasPrefix(line, "$") {
+			return nil, errors.New("argument is not a bulk string")
+		}
+
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+
+		args[i] = string(buf[:size])
+	}
+
+	if count == 0 {
+		return nil, errors.New("empty command")
+	}
+
+	return args, nil
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/opentracing/opentracing-go/ext"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 	"github.com/traefik/traefik/v2/pkg/tracing"
 	"github.com/vulcand/oxy/v2/utils"
 )
@@ -58,8 +60,12 @@ func (r *redirect) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// The captured variables are URL escaped, so that they cannot alter the structure of the redirect URL,
+	// and escaped to not be expanded as regexp submatches.
+	replacement := requestdecorator.ReplacePathParams(req.Context(), r.replacement, escapeParam)
+
 	// Apply a rewrite regexp to the URL.
-	newURL := r.regex.ReplaceAllString(oldURL, r.replacement)
+	newURL := r.regex.ReplaceAllString(oldURL, replacement)
 
 	// Parse the rewritten URL and replace request URL with it.
 	parsedURL, err := url.Parse(newURL)
@@ -81,6 +87,14 @@ func (r *redirect) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	r.next.ServeHTTP(rw, req)
 }
 
+func escapeParam(value string) string {
+	return escapeDollar(requestdecorator.EscapePathParam(value))
+}
+
+func escapeDollar(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
+
 type moveHandler struct {
 	location  *url.URL
 	permanent bool
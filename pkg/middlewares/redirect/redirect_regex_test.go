@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 )
 
 func TestRedirectRegexHandler(t *testing.T) {
@@ -19,6 +20,7 @@ func TestRedirectRegexHandler(t *testing.T) {
 		method         string
 		url            string
 		headers        map[string]string
+		params         map[string]string
 		secured        bool
 		expectedURL    string
 		expectedStatus int
@@ -151,6 +153,28 @@ func TestRedirectRegexHandler(t *testing.T) {
 			expectedURL:    "https://foo",
 			expectedStatus: http.StatusPermanentRedirect,
 		},
+		{
+			desc: "redirection with path parameters",
+			config: dynamic.RedirectRegex{
+				Regex:       `^http://foo\.com/users/.*$`,
+				Replacement: "https://bar.com/{lang}/profiles/{id}/{unknown}",
+			},
+			url:            "http://foo.com/users/42",
+			params:         map[string]string{"id": "42", "lang": "$1"},
+			expectedURL:    "https://bar.com/$1/profiles/42/%7Bunknown%7D",
+			expectedStatus: http.StatusFound,
+		},
+		{
+			desc: "redirection with escaped path parameters",
+			config: dynamic.RedirectRegex{
+				Regex:       `^http://foo\.com/users/.*$`,
+				Replacement: "https://bar.com/profiles/{id}",
+			},
+			url:            "http://foo.com/users/42",
+			params:         map[string]string{"id": "42?next=https://evil.com#top"},
+			expectedURL:    "https://bar.com/profiles/42%3Fnext=https://evil.com%23top",
+			expectedStatus: http.StatusFound,
+		},
 	}
 
 	for _, test := range testCases {
@@ -184,6 +208,10 @@ func TestRedirectRegexHandler(t *testing.T) {
 					req.Header.Set(k, v)
 				}
 
+				if test.params != nil {
+					req = req.WithContext(requestdecorator.WithPathParams(req.Context(), test.params))
+				}
+
 				req.Header.Set("X-Foo", "bar")
 				handler.ServeHTTP(recorder, req)
 
@@ -8,6 +8,7 @@ import (
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 	"github.com/traefik/traefik/v2/pkg/tracing"
 )
 
@@ -46,7 +47,7 @@ func (r *replacePath) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	}
 
 	req.Header.Add(ReplacedPathHeader, currentPath)
-	req.URL.RawPath = r.path
+	req.URL.RawPath = requestdecorator.ReplacePathParams(req.Context(), r.path, requestdecorator.EscapePathParam)
 
 	var err error
 	req.URL.Path, err = url.PathUnescape(req.URL.RawPath)
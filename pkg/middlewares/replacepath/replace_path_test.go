@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
 )
 
 func TestReplacePath(t *testing.T) {
@@ -16,6 +17,7 @@ func TestReplacePath(t *testing.T) {
 		desc            string
 		path            string
 		config          dynamic.ReplacePath
+		params          map[string]string
 		expectedPath    string
 		expectedRawPath string
 		expectedHeader  string
@@ -70,6 +72,17 @@ func TestReplacePath(t *testing.T) {
 			expectedRawPath: "/path/%08bar",
 			expectedHeader:  "/path/%08bar",
 		},
+		{
+			desc:   "replacement with path parameters",
+			path:   "/users/42",
+			params: map[string]string{"id": "42", "name": "foo bar/baz"},
+			config: dynamic.ReplacePath{
+				Path: "/api/users/{id}/{name}",
+			},
+			expectedPath:    "/api/users/42/foo bar/baz",
+			expectedRawPath: "/api/users/42/foo%20bar/baz",
+			expectedHeader:  "/users/42",
+		},
 	}
 
 	for _, test := range testCases {
@@ -82,9 +95,16 @@ func TestReplacePath(t *testing.T) {
 				requestURI = r.RequestURI
 			})
 
-			handler, err := New(context.Background(), next, test.config, "foo-replace-path")
+			replacePath, err := New(context.Background(), next, test.config, "foo-replace-path")
 			require.NoError(t, err)
 
+			handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if test.params != nil {
+					req = req.WithContext(requestdecorator.WithPathParams(req.Context(), test.params))
+				}
+				replacePath.ServeHTTP(rw, req)
+			})
+
 			server := httptest.NewServer(handler)
 			defer server.Close()
 
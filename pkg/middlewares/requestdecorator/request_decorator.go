@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/containous/alice"
@@ -13,6 +14,8 @@ import (
 const (
 	canonicalKey key = "canonical"
 	flattenKey   key = "flatten"
+	claimsKey    key = "jwtClaims"
+	paramsKey    key = "pathParams"
 )
 
 type key string
@@ -82,6 +85,106 @@ func GetCNAMEFlatten(ctx context.Context) string {
 	return ""
 }
 
+// WithJWTClaims returns a copy of ctx carrying the claims of an already validated JWT.
+// Only components which verified the token signature are expected to call it.
+func WithJWTClaims(ctx context.Context, claims map[string]interface{}) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// GetJWTClaims retrieves the validated JWT claims from the given context (previously stored with WithJWTClaims).
+func GetJWTClaims(ctx context.Context) map[string]interface{} {
+	if val, ok := ctx.Value(claimsKey).(map[string]interface{}); ok {
+		return val
+	}
+
+	return nil
+}
+
+// LookupJWTClaim returns the claim found by following the given dot-separated path in nested claims objects.
+func LookupJWTClaim(claims map[string]interface{}, path string) interface{} {
+	var current interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		current, ok = obj[key]
+		if !ok {
+			return nil
+		}
+	}
+
+	return current
+}
+
+// WithPathParams returns a copy of ctx carrying the variables captured by the rule of the matching router.
+func WithPathParams(ctx context.Context, params map[string]string) context.Context {
+	return context.WithValue(ctx, paramsKey, params)
+}
+
+// GetPathParams retrieves the variables captured by the rule of the matching router from the given context.
+func GetPathParams(ctx context.Context) map[string]string {
+	if val, ok := ctx.Value(paramsKey).(map[string]string); ok {
+		return val
+	}
+
+	return nil
+}
+
+// ReplacePathParams replaces the {name} placeholders of the given template by the values of the captured variables
+// stored in the given context, escaped with the escape function if it is not nil.
+// Placeholders which do not name a captured variable are left untouched.
+func ReplacePathParams(ctx context.Context, template string, escape func(string) string) string {
+	params := GetPathParams(ctx)
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	var result strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		value, ok := params[template[start+1:end]]
+		if !ok {
+			result.WriteString(template[:start+1])
+			template = template[start+1:]
+			continue
+		}
+
+		if escape != nil {
+			value = escape(value)
+		}
+
+		result.WriteString(template[:start])
+		result.WriteString(value)
+		template = template[end+1:]
+	}
+
+	result.WriteString(template)
+
+	return result.String()
+}
+
+// EscapePathParam escapes a captured variable to be used in an URL path, which can span several path segments.
+func EscapePathParam(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
 // WrapHandler Wraps a ServeHTTP with next to an alice.Constructor.
 func WrapHandler(handler *RequestDecorator) alice.Constructor {
 	return func(next http.Handler) (http.Handler, error) {
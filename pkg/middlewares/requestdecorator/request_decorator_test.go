@@ -1,6 +1,7 @@
 package requestdecorator
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -104,6 +105,55 @@ func TestRequestFlattening(t *testing.T) {
 	}
 }
 
+func TestReplacePathParams(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		template string
+		params   map[string]string
+		escape   func(string) string
+		expected string
+	}{
+		{
+			desc:     "no params",
+			template: "/users/{id}",
+			expected: "/users/{id}",
+		},
+		{
+			desc:     "several placeholders",
+			template: "/{lang}/users/{id}/{id}",
+			params:   map[string]string{"id": "42", "lang": "fr"},
+			expected: "/fr/users/42/42",
+		},
+		{
+			desc:     "unknown placeholders",
+			template: "/{{id}}/{foo}/{",
+			params:   map[string]string{"id": "42"},
+			expected: "/{42}/{foo}/{",
+		},
+		{
+			desc:     "escaped values",
+			template: "/users/{name}",
+			params:   map[string]string{"name": "foo bar/baz?"},
+			escape:   EscapePathParam,
+			expected: "/users/foo%20bar/baz%3F",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if test.params != nil {
+				ctx = WithPathParams(ctx, test.params)
+			}
+
+			assert.Equal(t, test.expected, ReplacePathParams(ctx, test.template, test.escape))
+		})
+	}
+}
+
 func Test_parseHost(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -0,0 +1,153 @@
+package retry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+// budgetWindow is the number of seconds over which the retries and requests are counted.
+const budgetWindow = 10
+
+// budget limits the retries to a percentage of the requests, over a sliding window of budgetWindow seconds.
+// A nil budget allows all the retries.
+type budget struct {
+	percent             int
+	minRetriesPerSecond int
+
+	mu      sync.Mutex
+	buckets [budgetWindow]budgetBucket
+	now     func() time.Time
+}
+
+// budgetBucket holds the counts of a second of the window.
+type budgetBucket struct {
+	second   int64
+	requests int
+	retries  int
+}
+
+// newBudget returns the retry budget defined by the given configuration, or nil if there is none.
+func newBudget(config *dynamic.RetryBudget) *budget {
+	if config == nil {
+		return nil
+	}
+
+	return &budget{
+		percent:             config.Percent,
+		minRetriesPerSecond: config.MinRetriesPerSecond,
+		now:                 time.Now,
+	}
+}
+
+// Budgets holds the retry budgets, by middleware name,
+// so that the budget of a middleware is shared by all the routers using it,
+// and kept across the dynamic configuration reloads.
+type Budgets struct {
+	mu      sync.Mutex
+	budgets map[string]*budget
+}
+
+// NewBudgets creates the retry budgets.
+func NewBudgets() *Budgets {
+	return &Budgets{budgets: make(map[string]*budget)}
+}
+
+// get returns the budget of the given middleware, defined by the given configuration, or nil if there is none.
+// When the configuration of the middleware changed, the budget follows the new configuration,
+// but keeps the requests and retries it already counted.
+// A nil Budgets returns a budget of its own to each caller.
+func (b *Budgets) get(name string, config *dynamic.RetryBudget) *budget {
+	if b == nil || config == nil {
+		return newBudget(config)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing, ok := b.budgets[name]
+	if !ok {
+		existing = newBudget(config)
+		b.budgets[name] = existing
+		return existing
+	}
+
+	existing.mu.Lock()
+	existing.percent = config.Percent
+	existing.minRetriesPerSecond = config.MinRetriesPerSecond
+	existing.mu.Unlock()
+
+	return existing
+}
+
+// Retain forgets the budgets of the middlewares for which exists returns false,
+// i.e. of the middlewares which are not part of the dynamic configuration anymore.
+func (b *Budgets) Retain(exists func(name string) bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for name := range b.budgets {
+		if !exists(name) {
+			delete(b.budgets, name)
+		}
+	}
+}
+
+// deposit records a request.
+func (b *budget) deposit() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bucket().requests++
+}
+
+// allows returns whether a retry is allowed by the budget.
+func (b *budget) allows() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	oldest := b.now().Unix() - budgetWindow
+
+	var requests, retries int
+	for _, bucket := range b.buckets {
+		if bucket.second > oldest {
+			requests += bucket.requests
+			retries += bucket.retries
+		}
+	}
+
+	return retries < b.minRetriesPerSecond*budgetWindow+requests*b.percent/100
+}
+
+// withdraw records a retry.
+func (b *budget) withdraw() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bucket().retries++
+}
+
+// bucket returns the bucket of the current second, resetting it if it holds the counts of a previous window.
+func (b *budget) bucket() *budgetBucket {
+	second := b.now().Unix()
+
+	bucket := &b.buckets[second%budgetWindow]
+	if bucket.second != second {
+		*bucket = budgetBucket{second: second}
+	}
+
+	return bucket
+}
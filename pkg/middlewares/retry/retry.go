@@ -9,13 +9,17 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptrace"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/opentracing/opentracing-go/ext"
+	"github.com/traefik/traefik/v2/pkg/attempt"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/middlewares"
 	"github.com/traefik/traefik/v2/pkg/tracing"
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 // Compile time validation that the response writer implements http interfaces correctly.
@@ -23,6 +27,10 @@ var _ middlewares.Stateful = &responseWriter{}
 
 const typeName = "Retry"
 
+// maxRetryAfter is the longest Retry-After delay which is waited for before retrying,
+// responses asking for a longer delay are not retried.
+const maxRetryAfter = 10 * time.Second
+
 // Listener is used to inform about retry attempts.
 type Listener interface {
 	// Retried will be called when a retry happens, with the request attempt passed to it.
@@ -41,23 +49,46 @@ type retry struct {
 	next            http.Handler
 	listener        Listener
 	name            string
+	statusCodes     types.HTTPCodeRanges
+	methods         map[string]struct{}
+	budget          *budget
 }
 
 // New returns a new retry middleware.
-func New(ctx context.Context, next http.Handler, config dynamic.Retry, listener Listener, name string) (http.Handler, error) {
+// The retry budget of the middleware, if any, is held by the given budgets, shared by the instances of the middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.Retry, budgets *Budgets, listener Listener, name string) (http.Handler, error) {
 	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
 
 	if config.Attempts <= 0 {
 		return nil, fmt.Errorf("incorrect (or empty) value for attempt (%d)", config.Attempts)
 	}
 
-	return &retry{
+	statusCodes, err := types.NewHTTPCodeRanges(config.Status)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry status: %w", err)
+	}
+
+	methods := config.Methods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete}
+	}
+
+	r := &retry{
 		attempts:        config.Attempts,
 		initialInterval: time.Duration(config.InitialInterval),
 		next:            next,
 		listener:        listener,
 		name:            name,
-	}, nil
+		statusCodes:     statusCodes,
+		methods:         make(map[string]struct{}),
+		budget:          budgets.get(name, config.Budget),
+	}
+
+	for _, method := range methods {
+		r.methods[strings.ToUpper(method)] = struct{}{}
+	}
+
+	return r, nil
 }
 
 func (r *retry) GetTracingInformation() (string, ext.SpanKindEnum) {
@@ -70,6 +101,10 @@ func (r *retry) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	r.budget.deposit()
+
+	retryOnStatus := r.retryableOnStatus(req)
+
 	closableBody := req.Body
 	defer closableBody.Close()
 
@@ -77,11 +112,19 @@ func (r *retry) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	// cf https://github.com/traefik/traefik/issues/1008
 	req.Body = io.NopCloser(closableBody)
 
+	// The servers of the attempts are recorded, so that the retries are forwarded to other servers.
+	req = req.WithContext(attempt.WithRecord(req.Context()))
+
 	attempts := 1
 
+	backOff := &retryAfterBackOff{BackOff: r.newBackOff()}
+
 	operation := func() error {
-		shouldRetry := attempts < r.attempts
+		shouldRetry := attempts < r.attempts && r.budget.allows()
 		retryResponseWriter := newResponseWriter(rw, shouldRetry)
+		if shouldRetry && retryOnStatus {
+			retryResponseWriter.retryStatus = r.statusCodes
+		}
 
 		// Disable retries when the backend already received request data
 		trace := &httptrace.ClientTrace{
@@ -96,31 +139,45 @@ func (r *retry) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 
 		r.next.ServeHTTP(retryResponseWriter, req.WithContext(newCtx))
 
-		if !retryResponseWriter.ShouldRetry() {
+		if !retryResponseWriter.ShouldRetry() && !retryResponseWriter.statusRetried {
 			return nil
 		}
 
 		attempts++
+		r.budget.withdraw()
+		backOff.retryAfter = retryResponseWriter.retryAfter
 
 		return fmt.Errorf("attempt %d failed", attempts-1)
 	}
 
 	logger := middlewares.GetLogger(req.Context(), r.name, typeName)
 
-	backOff := backoff.WithContext(r.newBackOff(), req.Context())
-
 	notify := func(err error, d time.Duration) {
 		logger.Debug().Msgf("New attempt %d for request: %v", attempts, req.URL)
 
 		r.listener.Retried(req, attempts)
 	}
 
-	err := backoff.RetryNotify(operation, backOff, notify)
+	err := backoff.RetryNotify(operation, backoff.WithContext(backOff, req.Context()), notify)
 	if err != nil {
 		logger.Debug().Err(err).Msg("Final retry attempt failed")
 	}
 }
 
+// retryableOnStatus returns whether the given request can be retried on the status of its response,
+// i.e. whether it is a request without body, of one of the retried methods.
+func (r *retry) retryableOnStatus(req *http.Request) bool {
+	if len(r.statusCodes) == 0 {
+		return false
+	}
+
+	if _, ok := r.methods[req.Method]; !ok {
+		return false
+	}
+
+	return req.ContentLength == 0 && (req.Body == nil || req.Body == http.NoBody)
+}
+
 func (r *retry) newBackOff() backoff.BackOff {
 	if r.attempts < 2 || r.initialInterval <= 0 {
 		return &backoff.ZeroBackOff{}
@@ -139,6 +196,49 @@ func (r *retry) newBackOff() backoff.BackOff {
 	return b
 }
 
+// retryAfterBackOff is a backoff.BackOff which waits at least for the Retry-After delay of the last response.
+type retryAfterBackOff struct {
+	backoff.BackOff
+
+	retryAfter time.Duration
+}
+
+func (b *retryAfterBackOff) NextBackOff() time.Duration {
+	next := b.BackOff.NextBackOff()
+	if next == backoff.Stop || next >= b.retryAfter {
+		return next
+	}
+
+	return b.retryAfter
+}
+
+// parseRetryAfter returns the delay of the given Retry-After header value,
+// which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := date.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay, true
+}
+
 // Retried exists to implement the Listener interface. It calls Retried on each of its slice entries.
 func (l Listeners) Retried(req *http.Request, attempt int) {
 	for _, listener := range l {
@@ -159,6 +259,14 @@ type responseWriter struct {
 	headers        http.Header
 	shouldRetry    bool
 	written        bool
+
+	// retryStatus are the status codes of the responses which are retried,
+	// it is nil when the response cannot be retried on its status.
+	retryStatus types.HTTPCodeRanges
+	// statusRetried is whether the response was discarded to be retried on its status.
+	statusRetried bool
+	// retryAfter is the Retry-After delay of the discarded response.
+	retryAfter time.Duration
 }
 
 func (r *responseWriter) ShouldRetry() bool {
@@ -177,7 +285,7 @@ func (r *responseWriter) Header() http.Header {
 }
 
 func (r *responseWriter) Write(buf []byte) (int, error) {
-	if r.ShouldRetry() {
+	if r.ShouldRetry() || r.statusRetried {
 		return len(buf), nil
 	}
 	return r.responseWriter.Write(buf)
@@ -191,12 +299,22 @@ func (r *responseWriter) WriteHeader(code int) {
 		// the backend server and so we can be sure that the 503 was produced
 		// inside Traefik already and we don't have to retry in this cases.
 		r.DisableRetries()
+		r.retryStatus = nil
 	}
 
-	if r.ShouldRetry() {
+	if r.ShouldRetry() || r.statusRetried {
 		return
 	}
 
+	if r.retryStatus.Contains(code) {
+		delay, ok := parseRetryAfter(r.headers.Get("Retry-After"), time.Now())
+		if !ok || delay <= maxRetryAfter {
+			r.statusRetried = true
+			r.retryAfter = delay
+			return
+		}
+	}
+
 	// In that case retry case is set to false which means we at least managed
 	// to write headers to the backend : we are not going to perform any further retry.
 	// So it is now safe to alter current response headers with headers collected during
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/attempt"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/testhelpers"
 )
@@ -120,7 +121,7 @@ func TestRetry(t *testing.T) {
 			})
 
 			retryListener := &countingRetryListener{}
-			retry, err := New(context.Background(), next, test.config, retryListener, "traefikTest")
+			retry, err := New(context.Background(), next, test.config, nil, retryListener, "traefikTest")
 			require.NoError(t, err)
 
 			recorder := httptest.NewRecorder()
@@ -140,7 +141,7 @@ func TestRetryEmptyServerList(t *testing.T) {
 	})
 
 	retryListener := &countingRetryListener{}
-	retry, err := New(context.Background(), next, dynamic.Retry{Attempts: 3}, retryListener, "traefikTest")
+	retry, err := New(context.Background(), next, dynamic.Retry{Attempts: 3}, nil, retryListener, "traefikTest")
 	require.NoError(t, err)
 
 	recorder := httptest.NewRecorder()
@@ -188,7 +189,7 @@ func TestMultipleRetriesShouldNotLooseHeaders(t *testing.T) {
 		rw.WriteHeader(http.StatusNoContent)
 	})
 
-	retry, err := New(context.Background(), next, dynamic.Retry{Attempts: 3}, &countingRetryListener{}, "traefikTest")
+	retry, err := New(context.Background(), next, dynamic.Retry{Attempts: 3}, nil, &countingRetryListener{}, "traefikTest")
 	require.NoError(t, err)
 
 	responseRecorder := httptest.NewRecorder()
@@ -234,7 +235,7 @@ func TestRetryWithFlush(t *testing.T) {
 		}
 	})
 
-	retry, err := New(context.Background(), next, dynamic.Retry{Attempts: 1}, &countingRetryListener{}, "traefikTest")
+	retry, err := New(context.Background(), next, dynamic.Retry{Attempts: 1}, nil, &countingRetryListener{}, "traefikTest")
 	require.NoError(t, err)
 
 	responseRecorder := httptest.NewRecorder()
@@ -292,7 +293,7 @@ func TestRetryWebsocket(t *testing.T) {
 			})
 
 			retryListener := &countingRetryListener{}
-			retryH, err := New(context.Background(), next, dynamic.Retry{Attempts: test.maxRequestAttempts}, retryListener, "traefikTest")
+			retryH, err := New(context.Background(), next, dynamic.Retry{Attempts: test.maxRequestAttempts}, nil, retryListener, "traefikTest")
 			require.NoError(t, err)
 
 			retryServer := httptest.NewServer(retryH)
@@ -309,3 +310,285 @@ func TestRetryWebsocket(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryOnStatus(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		config             dynamic.Retry
+		method             string
+		body               string
+		retryAfter         string
+		wantRetryAttempts  int
+		wantResponseStatus int
+	}{
+		{
+			desc:               "retry on configured status",
+			config:             dynamic.Retry{Attempts: 3, Status: []string{"502-503"}},
+			method:             http.MethodGet,
+			wantRetryAttempts:  2,
+			wantResponseStatus: http.StatusOK,
+		},
+		{
+			desc:               "no retry on other status",
+			config:             dynamic.Retry{Attempts: 3, Status: []string{"500"}},
+			method:             http.MethodGet,
+			wantRetryAttempts:  0,
+			wantResponseStatus: http.StatusBadGateway,
+		},
+		{
+			desc:               "no retry without status",
+			config:             dynamic.Retry{Attempts: 3},
+			method:             http.MethodGet,
+			wantRetryAttempts:  0,
+			wantResponseStatus: http.StatusBadGateway,
+		},
+		{
+			desc:               "no retry on non idempotent method",
+			config:             dynamic.Retry{Attempts: 3, Status: []string{"502"}},
+			method:             http.MethodPost,
+			wantRetryAttempts:  0,
+			wantResponseStatus: http.StatusBadGateway,
+		},
+		{
+			desc:               "retry on configured method",
+			config:             dynamic.Retry{Attempts: 3, Status: []string{"502"}, Methods: []string{"post"}},
+			method:             http.MethodPost,
+			wantRetryAttempts:  2,
+			wantResponseStatus: http.StatusOK,
+		},
+		{
+			desc:               "no retry of request with body",
+			config:             dynamic.Retry{Attempts: 3, Status: []string{"502"}},
+			method:             http.MethodPut,
+			body:               "foo",
+			wantRetryAttempts:  0,
+			wantResponseStatus: http.StatusBadGateway,
+		},
+		{
+			desc:               "retry after short delay",
+			config:             dynamic.Retry{Attempts: 3, Status: []string{"502"}},
+			method:             http.MethodGet,
+			retryAfter:         "0",
+			wantRetryAttempts:  2,
+			wantResponseStatus: http.StatusOK,
+		},
+		{
+			desc:               "no retry after long delay",
+			config:             dynamic.Retry{Attempts: 3, Status: []string{"502"}},
+			method:             http.MethodGet,
+			retryAfter:         "3600",
+			wantRetryAttempts:  0,
+			wantResponseStatus: http.StatusBadGateway,
+		},
+		{
+			desc:               "attempts exhausted delivers the last response",
+			config:             dynamic.Retry{Attempts: 2, Status: []string{"502"}},
+			method:             http.MethodGet,
+			wantRetryAttempts:  1,
+			wantResponseStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var servers []string
+			attempts := 0
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				attempts++
+
+				// calls WroteHeaders on httptrace.
+				_ = r.Write(io.Discard)
+
+				retryAttempts := attempt.FromContext(r.Context())
+				require.NotNil(t, retryAttempts)
+				servers = retryAttempts.Servers()
+				retryAttempts.Add(fmt.Sprintf("server-%d", attempts))
+
+				if attempts > 2 {
+					rw.WriteHeader(http.StatusOK)
+					return
+				}
+
+				if test.retryAfter != "" {
+					rw.Header().Set("Retry-After", test.retryAfter)
+				}
+				rw.WriteHeader(http.StatusBadGateway)
+				_, _ = rw.Write([]byte("bad gateway"))
+			})
+
+			retryListener := &countingRetryListener{}
+			retry, err := New(context.Background(), next, test.config, nil, retryListener, "traefikTest")
+			require.NoError(t, err)
+
+			var body io.Reader
+			if test.body != "" {
+				body = strings.NewReader(test.body)
+			}
+
+			recorder := httptest.NewRecorder()
+			retry.ServeHTTP(recorder, httptest.NewRequest(test.method, "http://localhost:3000/ok", body))
+
+			assert.Equal(t, test.wantResponseStatus, recorder.Code)
+			assert.Equal(t, test.wantRetryAttempts, retryListener.timesCalled)
+
+			// The servers of the previous attempts are known by the last attempt.
+			assert.Len(t, servers, test.wantRetryAttempts)
+
+			if test.wantResponseStatus == http.StatusOK {
+				assert.Empty(t, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	b := newBudget(&dynamic.RetryBudget{Percent: 50, MinRetriesPerSecond: 0})
+	b.now = func() time.Time { return now }
+
+	assert.False(t, b.allows())
+
+	for i := 0; i < 4; i++ {
+		b.deposit()
+	}
+
+	assert.True(t, b.allows())
+	b.withdraw()
+	assert.True(t, b.allows())
+	b.withdraw()
+	assert.False(t, b.allows())
+
+	// The counts leave the window after budgetWindow seconds.
+	now = now.Add(budgetWindow * time.Second)
+	b.deposit()
+	b.deposit()
+
+	assert.True(t, b.allows())
+	b.withdraw()
+	assert.False(t, b.allows())
+
+	var nilBudget *budget
+	assert.True(t, nilBudget.allows())
+}
+
+func TestRetryBudgetMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		// calls WroteHeaders on httptrace.
+		_ = r.Write(io.Discard)
+
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	config := dynamic.Retry{
+		Attempts: 3,
+		Status:   []string{"503"},
+		Budget:   &dynamic.RetryBudget{Percent: 100, MinRetriesPerSecond: 0},
+	}
+
+	retryListener := &countingRetryListener{}
+	retry, err := New(context.Background(), next, config, nil, retryListener, "traefikTestBudget")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	retry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:3000/ok", nil))
+
+	// A single request allows a single retry.
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, 1, retryListener.timesCalled)
+}
+
+func TestRetryBudgetSharedMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		// calls WroteHeaders on httptrace.
+		_ = r.Write(io.Discard)
+
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	config := dynamic.Retry{
+		Attempts: 3,
+		Status:   []string{"503"},
+		Budget:   &dynamic.RetryBudget{Percent: 50, MinRetriesPerSecond: 0},
+	}
+
+	budgets := NewBudgets()
+
+	retryListener := &countingRetryListener{}
+	first, err := New(context.Background(), next, config, budgets, retryListener, "traefikTestBudget")
+	require.NoError(t, err)
+
+	// The second instance stands for another router using the middleware, or for the middleware after a reload.
+	second, err := New(context.Background(), next, config, budgets, retryListener, "traefikTestBudget")
+	require.NoError(t, err)
+
+	first.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost:3000/ok", nil))
+	second.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost:3000/ok", nil))
+
+	// The two requests, counted by the same budget, allow a single retry.
+	assert.Equal(t, 1, retryListener.timesCalled)
+
+	b := budgets.get("traefikTestBudget", config.Budget)
+	assert.False(t, b.allows())
+
+	// The budget follows the new configuration, and keeps its counts.
+	b = budgets.get("traefikTestBudget", &dynamic.RetryBudget{Percent: 50, MinRetriesPerSecond: 1})
+	assert.True(t, b.allows())
+
+	budgets.Retain(func(name string) bool { return false })
+	assert.NotSame(t, b, budgets.get("traefikTestBudget", config.Budget))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc          string
+		value         string
+		expectedDelay time.Duration
+		expectedOK    bool
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc:          "seconds",
+			value:         "5",
+			expectedDelay: 5 * time.Second,
+			expectedOK:    true,
+		},
+		{
+			desc:  "negative seconds",
+			value: "-5",
+		},
+		{
+			desc:          "date",
+			value:         "Mon, 01 Jan 2024 00:00:03 GMT",
+			expectedDelay: 3 * time.Second,
+			expectedOK:    true,
+		},
+		{
+			desc:       "past date",
+			value:      "Sun, 31 Dec 2023 23:59:00 GMT",
+			expectedOK: true,
+		},
+		{
+			desc:  "invalid",
+			value: "foo",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			delay, ok := parseRetryAfter(test.value, now)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedDelay, delay)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package ipallowlist
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/udp"
+)
+
+func TestNewIPAllowLister(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		allowList     dynamic.UDPIPAllowList
+		expectedError bool
+	}{
+		{
+			desc:          "Empty config",
+			allowList:     dynamic.UDPIPAllowList{},
+			expectedError: true,
+		},
+		{
+			desc: "invalid IP",
+			allowList: dynamic.UDPIPAllowList{
+				SourceRange: []string{"foo"},
+			},
+			expectedError: true,
+		},
+		{
+			desc: "valid IP",
+			allowList: dynamic.UDPIPAllowList{
+				SourceRange: []string{"10.10.10.10"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := udp.HandlerFunc(func(conn *udp.Conn) {})
+			allowLister, err := New(context.Background(), next, test.allowList, "traefikTest")
+
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, allowLister)
+			}
+		})
+	}
+}
+
+func TestIPAllowLister_ServeUDP(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		allowList dynamic.UDPIPAllowList
+		expected  string
+	}{
+		{
+			desc: "authorized with remote address",
+			allowList: dynamic.UDPIPAllowList{
+				SourceRange: []string{"127.0.0.1"},
+			},
+			expected: "OK",
+		},
+		{
+			desc: "non authorized with remote address",
+			allowList: dynamic.UDPIPAllowList{
+				SourceRange: []string{"20.20.20.20"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := udp.HandlerFunc(func(conn *udp.Conn) {
+				_, err := conn.Write([]byte("OK"))
+				require.NoError(t, err)
+
+				require.NoError(t, conn.Close())
+			})
+
+			allowLister, err := New(context.Background(), next, test.allowList, "traefikTest")
+			require.NoError(t, err)
+
+			addr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
+			require.NoError(t, err)
+
+			ln, err := udp.Listen("udp", addr, 3*time.Second)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = ln.Close() })
+
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+
+				allowLister.ServeUDP(conn)
+			}()
+
+			client, err := net.Dial("udp", ln.Addr().String())
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+
+			_, err = client.Write([]byte("ping"))
+			require.NoError(t, err)
+
+			require.NoError(t, client.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+
+			buf := make([]byte, 16)
+			n, _ := client.Read(buf)
+
+			assert.Equal(t, test.expected, string(buf[:n]))
+		})
+	}
+}
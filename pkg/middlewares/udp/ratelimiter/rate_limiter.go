@@ -0,0 +1,127 @@
+// Package ratelimiter implements a UDP rate limiting middleware with a token bucket for each source IP.
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mailgun/ttlmap"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/udp"
+	"golang.org/x/time/rate"
+)
+
+const (
+	typeName   = "RateLimiterUDP"
+	maxSources = 65536
+)
+
+// rateLimiter drops the datagrams of a source IP exceeding its rate.
+// Unlike the HTTP rate limiter, datagrams are never delayed, as UDP clients are expected to cope with losses.
+type rateLimiter struct {
+	name  string
+	rate  rate.Limit // datagrams/s
+	burst int
+	// each rate limiter for a given source is stored in the buckets ttlmap,
+	// and is "garbage collected" after it hasn't been used for ttl seconds.
+	ttl  int
+	next udp.Handler
+
+	buckets *ttlmap.TtlMap // actual buckets, keyed by source IP.
+}
+
+// New returns a UDP rate limiter middleware.
+func New(ctx context.Context, next udp.Handler, config dynamic.UDPRateLimit, name string) (udp.Handler, error) {
+	logger := middlewares.GetLogger(ctx, name, typeName)
+	logger.Debug().Msg("Creating middleware")
+
+	buckets, err := ttlmap.NewConcurrent(maxSources)
+	if err != nil {
+		return nil, err
+	}
+
+	burst := config.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	period := time.Duration(config.Period)
+	if period < 0 {
+		return nil, fmt.Errorf("negative value not valid for period: %v", period)
+	}
+	if period == 0 {
+		period = time.Second
+	}
+
+	var rtl float64
+	if config.Average > 0 {
+		rtl = float64(config.Average*int64(time.Second)) / float64(period)
+	}
+
+	// Make the ttl inversely proportional to how often a rate limiter is supposed to see any activity (when maxed out),
+	// for low rate limiters.
+	ttl := 1
+	if rtl >= 1 {
+		ttl++
+	} else if rtl > 0 {
+		ttl += int(1 / rtl)
+	}
+
+	return &rateLimiter{
+		name:    name,
+		rate:    rate.Limit(rtl),
+		burst:   int(burst),
+		ttl:     ttl,
+		next:    next,
+		buckets: buckets,
+	}, nil
+}
+
+func (rl *rateLimiter) ServeUDP(conn *udp.Conn) {
+	// An Average of zero means no rate limiting.
+	if rl.rate == 0 {
+		rl.next.ServeUDP(conn)
+		return
+	}
+
+	logger := middlewares.GetLogger(context.Background(), rl.name, typeName)
+
+	source := conn.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(source); err == nil {
+		source = host
+	}
+
+	conn.AddReadFilter(func() bool {
+		if rl.bucket(source).Allow() {
+			return true
+		}
+
+		logger.Debug().Msgf("Datagram from %s dropped: rate limit exceeded", source)
+		return false
+	})
+
+	rl.next.ServeUDP(conn)
+}
+
+// bucket returns the token bucket of the source, which is shared by all its sessions.
+func (rl *rateLimiter) bucket(source string) *rate.Limiter {
+	var bucket *rate.Limiter
+	if rlSource, exists := rl.buckets.Get(source); exists {
+		bucket = rlSource.(*rate.Limiter)
+	} else {
+		bucket = rate.NewLimiter(rl.rate, rl.burst)
+	}
+
+	// We Set even in the case where the source already exists,
+	// because we want to update the expiryTime everytime we get the source,
+	// as the expiryTime is supposed to reflect the activity (or lack thereof) on that source.
+	if err := rl.buckets.Set(source, bucket, rl.ttl); err != nil {
+		logger := middlewares.GetLogger(context.Background(), rl.name, typeName)
+		logger.Error().Err(err).Msg("Could not insert/update bucket")
+	}
+
+	return bucket
+}
@@ -0,0 +1,127 @@
+package ratelimiter
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/udp"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        dynamic.UDPRateLimit
+		expectedRate  float64
+		expectedBurst int
+		expectedError bool
+	}{
+		{
+			desc:          "no limit",
+			config:        dynamic.UDPRateLimit{},
+			expectedBurst: 1,
+		},
+		{
+			desc:          "average per second",
+			config:        dynamic.UDPRateLimit{Average: 100, Burst: 10},
+			expectedRate:  100,
+			expectedBurst: 10,
+		},
+		{
+			desc:          "average per minute",
+			config:        dynamic.UDPRateLimit{Average: 30, Period: ptypes.Duration(time.Minute)},
+			expectedRate:  0.5,
+			expectedBurst: 1,
+		},
+		{
+			desc:          "negative period",
+			config:        dynamic.UDPRateLimit{Average: 30, Period: ptypes.Duration(-time.Second)},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := udp.HandlerFunc(func(conn *udp.Conn) {})
+			handler, err := New(context.Background(), next, test.config, "rate-limiter")
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			rtl := handler.(*rateLimiter)
+			assert.InDelta(t, test.expectedRate, float64(rtl.rate), 1e-9)
+			assert.Equal(t, test.expectedBurst, rtl.burst)
+		})
+	}
+}
+
+func TestRateLimiter_ServeUDP(t *testing.T) {
+	received := make(chan string, 10)
+
+	next := udp.HandlerFunc(func(conn *udp.Conn) {
+		buf := make([]byte, 16)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			received <- string(buf[:n])
+		}
+	})
+
+	// One datagram per minute, with a burst of 2.
+	handler, err := New(context.Background(), next, dynamic.UDPRateLimit{Average: 1, Period: ptypes.Duration(time.Minute), Burst: 2}, "rate-limiter")
+	require.NoError(t, err)
+
+	addr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ln, err := udp.Listen("udp", addr, 3*time.Second)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go handler.ServeUDP(conn)
+		}
+	}()
+
+	client, err := net.Dial("udp", ln.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		_, err = client.Write([]byte(msg))
+		require.NoError(t, err)
+	}
+
+	var got []string
+	timeout := time.After(500 * time.Millisecond)
+
+loop:
+	for {
+		select {
+		case msg := <-received:
+			got = append(got, msg)
+		case <-timeout:
+			break loop
+		}
+	}
+
+	assert.Equal(t, []string{"a", "b"}, got)
+}
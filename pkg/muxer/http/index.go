@@ -0,0 +1,237 @@
+package http
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
+	"github.com/traefik/traefik/v2/pkg/rules"
+)
+
+// anyHost is the index key of the routes which are not restricted to literal hosts.
+const anyHost = ""
+
+// routeKey is a pair of a literal host and a literal path prefix,
+// at least one of which is required by a route to match a request.
+type routeKey struct {
+	host   string
+	prefix string
+}
+
+// routeIndex indexes the routes by the literal hosts and path prefixes they require,
+// so that only the routes which can possibly match a request are evaluated.
+// The routes which cannot be indexed (e.g. HostRegexp or Headers only rules) are always evaluated.
+type routeIndex struct {
+	// routes are sorted in evaluation order.
+	routes []*mux.Route
+	// hosts holds a radix tree of path prefixes for each host.
+	hosts map[string]*radixNode
+}
+
+// newRouteIndex builds the index of the given routes, which must be in evaluation order.
+func newRouteIndex(routes []*mux.Route, keys map[*mux.Route][]routeKey) *routeIndex {
+	index := &routeIndex{
+		routes: routes,
+		hosts:  make(map[string]*radixNode),
+	}
+
+	for id, route := range routes {
+		routeKeys, ok := keys[route]
+		if !ok {
+			routeKeys = []routeKey{{host: anyHost}}
+		}
+
+		for _, key := range routeKeys {
+			tree, ok := index.hosts[key.host]
+			if !ok {
+				tree = &radixNode{}
+				index.hosts[key.host] = tree
+			}
+
+			tree.insert(key.prefix, id)
+		}
+	}
+
+	return index
+}
+
+// candidates returns the routes which can possibly match the request, in evaluation order.
+func (i *routeIndex) candidates(req *http.Request) []*mux.Route {
+	var ids []int
+
+	path := req.URL.Path
+
+	ids = i.hosts[anyHost].lookup(path, ids)
+
+	host := strings.TrimSuffix(requestdecorator.GetCanonizedHost(req.Context()), ".")
+	if host != anyHost {
+		ids = i.hosts[host].lookup(path, ids)
+	}
+
+	flatHost := strings.TrimSuffix(strings.ToLower(requestdecorator.GetCNAMEFlatten(req.Context())), ".")
+	if flatHost != anyHost && flatHost != host {
+		ids = i.hosts[flatHost].lookup(path, ids)
+	}
+
+	sort.Ints(ids)
+
+	routes := make([]*mux.Route, 0, len(ids))
+	for j, id := range ids {
+		// A route can be indexed under several keys.
+		if j > 0 && ids[j-1] == id {
+			continue
+		}
+
+		routes = append(routes, i.routes[id])
+	}
+
+	return routes
+}
+
+// routeKeys returns the keys of the given rule, or nil when the rule cannot be indexed.
+func routeKeys(tree *rules.Tree) []routeKey {
+	conjunctions := tree.Conjunctions()
+	if conjunctions == nil {
+		return nil
+	}
+
+	var keys []routeKey
+	for _, conj := range conjunctions {
+		hosts := []string{anyHost}
+		prefixes := []string{""}
+
+		var hasHost, hasPath bool
+		for _, atom := range conj {
+			if atom.Not {
+				continue
+			}
+
+			switch atom.Matcher {
+			case hostMatcher, "HostHeader":
+				if hasHost {
+					continue
+				}
+				hasHost = true
+
+				hosts = hosts[:0]
+				for _, value := range atom.Value {
+					hosts = append(hosts, strings.TrimSuffix(strings.ToLower(value), "."))
+				}
+			case "Path", "PathPrefix":
+				if hasPath {
+					continue
+				}
+				hasPath = true
+
+				prefixes = prefixes[:0]
+				for _, value := range atom.Value {
+					prefixes = append(prefixes, literalPrefix(value))
+				}
+			}
+		}
+
+		for _, host := range hosts {
+			for _, prefix := range prefixes {
+				keys = append(keys, routeKey{host: host, prefix: prefix})
+			}
+		}
+	}
+
+	return keys
+}
+
+// literalPrefix returns the literal part of a path template, before its first variable.
+func literalPrefix(template string) string {
+	if !strings.HasPrefix(template, "/") {
+		return ""
+	}
+
+	if i := strings.IndexByte(template, '{'); i >= 0 {
+		return template[:i]
+	}
+
+	return template
+}
+
+// radixNode is a node of a radix tree of path prefixes.
+type radixNode struct {
+	// prefix is the part of the path prefix held by this node, relative to its parent.
+	prefix   string
+	children []*radixNode
+	// ids are the identifiers of the routes requiring the path prefix ending at this node.
+	ids []int
+}
+
+// insert adds the route identifier at the given path prefix.
+func (n *radixNode) insert(prefix string, id int) {
+	for {
+		if prefix == "" {
+			n.ids = append(n.ids, id)
+			return
+		}
+
+		child := n.child(prefix[0])
+		if child == nil {
+			n.children = append(n.children, &radixNode{prefix: prefix, ids: []int{id}})
+			return
+		}
+
+		common := commonPrefixLength(prefix, child.prefix)
+		if common < len(child.prefix) {
+			// Split the child at the end of the common prefix.
+			split := &radixNode{
+				prefix:   child.prefix[common:],
+				children: child.children,
+				ids:      child.ids,
+			}
+			child.prefix = child.prefix[:common]
+			child.children = []*radixNode{split}
+			child.ids = nil
+		}
+
+		n = child
+		prefix = prefix[common:]
+	}
+}
+
+// lookup appends to ids the identifiers of the routes whose path prefix is a prefix of the given path.
+func (n *radixNode) lookup(path string, ids []int) []int {
+	for n != nil {
+		ids = append(ids, n.ids...)
+
+		if path == "" {
+			return ids
+		}
+
+		child := n.child(path[0])
+		if child == nil || !strings.HasPrefix(path, child.prefix) {
+			return ids
+		}
+
+		n = child
+		path = path[len(child.prefix):]
+	}
+
+	return ids
+}
+
+func (n *radixNode) child(b byte) *radixNode {
+	for _, child := range n.children {
+		if child.prefix[0] == b {
+			return child
+		}
+	}
+
+	return nil
+}
+
+func commonPrefixLength(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return i
+}
@@ -0,0 +1,223 @@
+package http
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
+	"github.com/traefik/traefik/v2/pkg/testhelpers"
+)
+
+func TestRadixNode(t *testing.T) {
+	var tree radixNode
+	tree.insert("/api", 0)
+	tree.insert("/api/v1", 1)
+	tree.insert("/app", 2)
+	tree.insert("", 3)
+	tree.insert("/api/v1", 4)
+	tree.insert("/a", 5)
+
+	testCases := []struct {
+		path     string
+		expected []int
+	}{
+		{path: "/", expected: []int{3}},
+		{path: "/a", expected: []int{3, 5}},
+		{path: "/ap", expected: []int{3, 5}},
+		{path: "/api", expected: []int{3, 5, 0}},
+		{path: "/api/v1/users", expected: []int{3, 5, 0, 1, 4}},
+		{path: "/api/v2", expected: []int{3, 5, 0}},
+		{path: "/application", expected: []int{3, 5, 2}},
+		{path: "/b", expected: []int{3}},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, tree.lookup(test.path, nil), test.path)
+	}
+}
+
+func TestRouteKeys(t *testing.T) {
+	testCases := []struct {
+		rule     string
+		expected []routeKey
+	}{
+		{
+			rule:     "Host(`Foo.com.`)",
+			expected: []routeKey{{host: "foo.com"}},
+		},
+		{
+			rule:     "Host(`foo.com`, `bar.com`) && PathPrefix(`/api`)",
+			expected: []routeKey{{host: "foo.com", prefix: "/api"}, {host: "bar.com", prefix: "/api"}},
+		},
+		{
+			rule:     "Path(`/users/{id:[0-9]+}`)",
+			expected: []routeKey{{prefix: "/users/"}},
+		},
+		{
+			rule:     "Host(`foo.com`) || PathPrefix(`/api`)",
+			expected: []routeKey{{host: "foo.com"}, {prefix: "/api"}},
+		},
+		{
+			rule:     "!Host(`foo.com`) && Headers(`X-Foo`, `bar`)",
+			expected: []routeKey{{}},
+		},
+		{
+			rule:     "HostRegexp(`{sub:[a-z]+}.foo.com`)",
+			expected: []routeKey{{}},
+		},
+	}
+
+	for _, test := range testCases {
+		tree, err := ParseRule(test.rule)
+		require.NoError(t, err)
+
+		assert.Equal(t, test.expected, routeKeys(tree), test.rule)
+	}
+}
+
+// TestMuxer_indexEquivalence checks that the indexed evaluation of the routes
+// always routes the requests like the linear evaluation of all the routes.
+func TestMuxer_indexEquivalence(t *testing.T) {
+	hosts := []string{"foo.com", "bar.com", "FOO.com", "foo.com.", "baz.org"}
+	paths := []string{"/", "/api", "/api/v1", "/api/v1/users/42", "/apix", "/users/abc", "/users/42", "/static/app.js"}
+	methods := []string{http.MethodGet, http.MethodPost}
+	headers := []string{"", "yes"}
+
+	atoms := []string{
+		"Host(`foo.com`)",
+		"Host(`bar.com`, `foo.com.`)",
+		"HostHeader(`baz.org`)",
+		"HostRegexp(`{sub:[a-z]+}.com`)",
+		"PathPrefix(`/api`)",
+		"PathPrefix(`/api/v1`)",
+		"Path(`/api`)",
+		"Path(`/users/{id:[0-9]+}`)",
+		"PathPrefix(`/{dir:[a-z]+}/`)",
+		"Method(`POST`)",
+		"Headers(`X-Canary`, `yes`)",
+		"!Host(`bar.com`)",
+		"!PathPrefix(`/static`)",
+		"Query(`debug=true`)",
+	}
+
+	random := rand.New(rand.NewSource(42))
+
+	randomRule := func() string {
+		rule := atoms[random.Intn(len(atoms))]
+		for i := random.Intn(3); i > 0; i-- {
+			op := " && "
+			if random.Intn(3) == 0 {
+				op = " || "
+			}
+			rule = "(" + rule + op + atoms[random.Intn(len(atoms))] + ")"
+		}
+		return rule
+	}
+
+	for iteration := 0; iteration < 50; iteration++ {
+		muxer, err := NewMuxer()
+		require.NoError(t, err)
+
+		for i := 0; i < 30; i++ {
+			name := strconv.Itoa(i)
+			handler := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				rw.Header().Set("X-From", name)
+			})
+
+			var priority int
+			if random.Intn(2) == 0 {
+				priority = random.Intn(10)
+			}
+
+			_ = muxer.AddRoute(randomRule(), priority, handler)
+		}
+
+		muxer.SortRoutes()
+
+		reqHost := requestdecorator.New(nil)
+
+		for _, host := range hosts {
+			for _, path := range paths {
+				for _, method := range methods {
+					for _, header := range headers {
+						req := testhelpers.MustNewRequest(method, "http://"+host+path, nil)
+						req.Header.Set("X-Canary", header)
+
+						indexed := httptest.NewRecorder()
+						reqHost.ServeHTTP(indexed, req, muxer.ServeHTTP)
+
+						linear := httptest.NewRecorder()
+						reqHost.ServeHTTP(linear, req, muxer.Router.ServeHTTP)
+
+						desc := fmt.Sprintf("%s %s%s %s", method, host, path, header)
+						assert.Equal(t, linear.Code, indexed.Code, desc)
+						assert.Equal(t, linear.Header().Get("X-From"), indexed.Header().Get("X-From"), desc)
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkMuxer(b *testing.B) {
+	const routers = 20000
+
+	muxer, err := NewMuxer()
+	require.NoError(b, err)
+
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+
+	for i := 0; i < routers; i++ {
+		var rule string
+		switch i % 4 {
+		case 0:
+			rule = fmt.Sprintf("Host(`svc%d.example.com`)", i)
+		case 1:
+			rule = fmt.Sprintf("Host(`svc%d.example.com`) && PathPrefix(`/api/v%d`)", i, i%10)
+		case 2:
+			rule = fmt.Sprintf("Host(`example.com`) && PathPrefix(`/svc%d`)", i)
+		default:
+			rule = fmt.Sprintf("Host(`svc%d.example.com`) && Path(`/users/{id:[0-9]+}`) && Headers(`X-Tenant`, `t%d`)", i, i)
+		}
+
+		require.NoError(b, muxer.AddRoute(rule, 0, handler))
+	}
+
+	muxer.SortRoutes()
+
+	reqHost := requestdecorator.New(nil)
+
+	requests := []*http.Request{
+		testhelpers.MustNewRequest(http.MethodGet, "http://svc19996.example.com/", nil),
+		testhelpers.MustNewRequest(http.MethodGet, "http://svc9997.example.com/api/v7/users", nil),
+		testhelpers.MustNewRequest(http.MethodGet, "http://example.com/svc19998/index.html", nil),
+		testhelpers.MustNewRequest(http.MethodGet, "http://unknown.example.com/", nil),
+	}
+
+	benchmarks := []struct {
+		desc  string
+		serve http.HandlerFunc
+	}{
+		{desc: "indexed", serve: muxer.ServeHTTP},
+		{desc: "linear", serve: muxer.Router.ServeHTTP},
+	}
+
+	for _, bench := range benchmarks {
+		b.Run(bench.desc, func(b *testing.B) {
+			rw := httptest.NewRecorder()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				reqHost.ServeHTTP(rw, requests[i%len(requests)], bench.serve)
+			}
+		})
+	}
+}
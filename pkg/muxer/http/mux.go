@@ -1,8 +1,12 @@
 package http
 
 import (
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -27,12 +31,26 @@ var httpFuncs = map[string]func(*mux.Route, ...string) error{
 	"Headers":       headers,
 	"HeadersRegexp": headersRegexp,
 	"Query":         query,
+	"Cookie":        cookie,
+	"CookieRegexp":  cookieRegexp,
+	"JWTClaim":      jwtClaim,
+	"Canary":        canary,
 }
 
 // Muxer handles routing with rules.
 type Muxer struct {
 	*mux.Router
 	parser predicate.Parser
+
+	// keys holds the literal hosts and path prefixes required by the routes, used to build the index.
+	keys map[*mux.Route][]routeKey
+	// index of the routes, built when the routes are sorted.
+	index *routeIndex
+	// trees holds the rules of the routes, used to explain why a route does not match a request.
+	trees map[*mux.Route]*rules.Tree
+	// methodMatchers reports whether a route rule contains a Method matcher,
+	// in which case unmatched requests are evaluated against all the routes to tell 404 from 405 responses.
+	methodMatchers bool
 }
 
 // NewMuxer returns a new muxer instance.
@@ -50,11 +68,19 @@ func NewMuxer() (*Muxer, error) {
 	return &Muxer{
 		Router: mux.NewRouter().SkipClean(true),
 		parser: parser,
+		keys:   make(map[*mux.Route][]routeKey),
+		trees:  make(map[*mux.Route]*rules.Tree),
 	}, nil
 }
 
 // AddRoute add a new route to the router.
 func (r *Muxer) AddRoute(rule string, priority int, handler http.Handler) error {
+	return r.AddNamedRoute("", rule, priority, handler)
+}
+
+// AddNamedRoute adds a new route to the router, named after the router it comes from,
+// so that the route can be told apart when explaining a routing decision.
+func (r *Muxer) AddNamedRoute(name, rule string, priority int, handler http.Handler) error {
 	parse, err := r.parser.Parse(rule)
 	if err != nil {
 		return fmt.Errorf("error while parsing rule %s: %w", rule, err)
@@ -69,19 +95,165 @@ func (r *Muxer) AddRoute(rule string, priority int, handler http.Handler) error
 		priority = len(rule)
 	}
 
+	// The index is stale until the routes are sorted again.
+	r.index = nil
+
 	route := r.NewRoute().Handler(handler).Priority(priority)
+	if name != "" {
+		route.Name(name)
+	}
 
-	err = addRuleOnRoute(route, buildTree())
+	tree := buildTree()
+	r.trees[route] = tree
+
+	err = addRuleOnRoute(route, tree)
 	if err != nil {
 		route.BuildOnly()
+		// A build only route never matches, so it does not need to be evaluated.
+		r.keys[route] = []routeKey{}
 		return err
 	}
 
+	if keys := routeKeys(tree); keys != nil {
+		r.keys[route] = keys
+	}
+
+	if len(tree.ParseMatchers([]string{"Method"})) > 0 {
+		r.methodMatchers = true
+	}
+
 	return nil
 }
 
+// SortRoutes sorts the routes by priority, and indexes them by the literal hosts and path prefixes they require.
+func (r *Muxer) SortRoutes() {
+	r.Router.SortRoutes()
+
+	var routes []*mux.Route
+	_ = r.Walk(func(route *mux.Route, _ *mux.Router, ancestors []*mux.Route) error {
+		if len(ancestors) == 0 {
+			routes = append(routes, route)
+		}
+		return nil
+	})
+
+	r.index = newRouteIndex(routes, r.keys)
+}
+
+// ServeHTTP dispatches the request to the handler of the first matching route,
+// and stores the variables captured by the route rule in the request context.
+// Once the routes are sorted, only the routes which can possibly match the request, according to the index, are evaluated,
+// with the same outcome as evaluating all of them in order.
+func (r *Muxer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	var match mux.RouteMatch
+	if !r.match(req, &match) {
+		if errors.Is(match.MatchErr, mux.ErrMethodMismatch) {
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		http.NotFound(rw, req)
+		return
+	}
+
+	if len(match.Vars) > 0 {
+		// Same as what the router does with the variables of the matching route.
+		req = mux.SetURLVars(req, match.Vars)
+		req = req.WithContext(requestdecorator.WithPathParams(req.Context(), match.Vars))
+	}
+
+	match.Handler.ServeHTTP(rw, req)
+}
+
+func (r *Muxer) match(req *http.Request, match *mux.RouteMatch) bool {
+	if r.index == nil || r.NotFoundHandler != nil || r.MethodNotAllowedHandler != nil {
+		return r.Router.Match(req, match)
+	}
+
+	for _, route := range r.index.candidates(req) {
+		if route.Match(req, match) {
+			return true
+		}
+	}
+
+	if r.methodMatchers {
+		// Let the router evaluate all the routes to reply with a 405 if one of them only failed on its method.
+		*match = mux.RouteMatch{}
+		return r.Router.Match(req, match)
+	}
+
+	return false
+}
+
+// MatchResult describes the evaluation of a route against a request.
+type MatchResult struct {
+	Name     string
+	Handler  http.Handler
+	Priority int
+	Matched  bool
+	// FailedMatchers are the matchers of the route rule which do not match the request, when the route does not match.
+	FailedMatchers []*rules.Tree
+}
+
+// MatchAll evaluates all the routes against the given request, in the order they are evaluated when serving it.
+// It is meant to explain routing decisions, and requires the routes to be sorted beforehand.
+func (r *Muxer) MatchAll(req *http.Request) []MatchResult {
+	var results []MatchResult
+
+	_ = r.Walk(func(route *mux.Route, _ *mux.Router, ancestors []*mux.Route) error {
+		// Only the top-level routes are the ones added with AddRoute.
+		if len(ancestors) > 0 {
+			return nil
+		}
+
+		result := MatchResult{
+			Name:     route.GetName(),
+			Handler:  route.GetHandler(),
+			Priority: route.GetPriority(),
+			Matched:  route.Match(req, &mux.RouteMatch{}),
+		}
+
+		if !result.Matched {
+			result.FailedMatchers = failedMatchers(r.trees[route], req)
+		}
+
+		results = append(results, result)
+
+		return nil
+	})
+
+	return results
+}
+
+// failedMatchers returns the matchers of the given rule which do not match the request.
+func failedMatchers(tree *rules.Tree, req *http.Request) []*rules.Tree {
+	if tree == nil {
+		return nil
+	}
+
+	var failed []*rules.Tree
+	for _, leaf := range tree.Leaves() {
+		route := mux.NewRouter().SkipClean(true).NewRoute()
+		if err := addRuleOnRoute(route, leaf); err != nil || !route.Match(req, &mux.RouteMatch{}) {
+			failed = append(failed, leaf)
+		}
+	}
+
+	return failed
+}
+
 // ParseDomains extract domains from rule.
 func ParseDomains(rule string) ([]string, error) {
+	tree, err := ParseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return tree.ParseMatchers([]string{hostMatcher}), nil
+}
+
+// ParseRule parses the given rule into its tree representation, without validating the matchers values.
+func ParseRule(rule string) (*rules.Tree, error) {
 	var matchers []string
 	for matcher := range httpFuncs {
 		matchers = append(matchers, matcher)
@@ -102,7 +274,7 @@ func ParseDomains(rule string) ([]string, error) {
 		return nil, fmt.Errorf("error while parsing rule %s", rule)
 	}
 
-	return buildTree().ParseMatchers([]string{hostMatcher}), nil
+	return buildTree(), nil
 }
 
 func path(route *mux.Route, paths ...string) error {
@@ -245,6 +417,176 @@ func query(route *mux.Route, query ...string) error {
 	return route.GetError()
 }
 
+func cookie(route *mux.Route, values ...string) error {
+	if len(values) != 2 {
+		return fmt.Errorf("unexpected number of parameters for \"Cookie\" matcher; got %d, expected 2", len(values))
+	}
+
+	name, value := values[0], values[1]
+
+	route.MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+		c, err := req.Cookie(name)
+		if err != nil {
+			return false
+		}
+
+		return c.Value == value
+	})
+
+	return nil
+}
+
+func cookieRegexp(route *mux.Route, values ...string) error {
+	if len(values) != 2 {
+		return fmt.Errorf("unexpected number of parameters for \"CookieRegexp\" matcher; got %d, expected 2", len(values))
+	}
+
+	name := values[0]
+
+	re, err := regexp.Compile(values[1])
+	if err != nil {
+		return fmt.Errorf("compiling regexp for \"CookieRegexp\" matcher: %w", err)
+	}
+
+	route.MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+		c, err := req.Cookie(name)
+		if err != nil {
+			return false
+		}
+
+		return re.MatchString(c.Value)
+	})
+
+	return nil
+}
+
+// jwtClaim matches on the claims of a JWT which has already been validated before routing,
+// by the JWT validation of the entry point.
+// Raw Authorization headers are never decoded here, as their signature has not been verified.
+func jwtClaim(route *mux.Route, values ...string) error {
+	if len(values) != 2 {
+		return fmt.Errorf("unexpected number of parameters for \"JWTClaim\" matcher; got %d, expected 2", len(values))
+	}
+
+	path := values[0]
+	value := values[1]
+
+	route.MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+		claims := requestdecorator.GetJWTClaims(req.Context())
+		if claims == nil {
+			return false
+		}
+
+		return claimMatches(requestdecorator.LookupJWTClaim(claims, path), value)
+	})
+
+	return nil
+}
+
+// claimMatches reports whether the claim is equal to the given value,
+// or contains it when the claim is an array.
+func claimMatches(claim interface{}, value string) bool {
+	switch c := claim.(type) {
+	case string:
+		return c == value
+	case bool:
+		return strconv.FormatBool(c) == value
+	case float64:
+		return strconv.FormatFloat(c, 'f', -1, 64) == value
+	case []interface{}:
+		for _, elem := range c {
+			if claimMatches(elem, value) {
+				return true
+			}
+		}
+		return false
+	case []string:
+		for _, elem := range c {
+			if elem == value {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// canaryBuckets is the number of buckets the canary hash keys are spread over,
+// allowing percentages with a precision of two decimals.
+const canaryBuckets = 10000
+
+func canary(route *mux.Route, values ...string) error {
+	if len(values) != 2 {
+		return fmt.Errorf("unexpected number of parameters for \"Canary\" matcher; got %d, expected 2", len(values))
+	}
+
+	percent, err := strconv.ParseFloat(values[0], 64)
+	if err != nil || percent < 0 || percent > 100 {
+		return fmt.Errorf("invalid percentage %q for \"Canary\" matcher, must be a number between 0 and 100", values[0])
+	}
+
+	hashKey, err := newCanaryKey(values[1])
+	if err != nil {
+		return err
+	}
+
+	threshold := uint64(percent * canaryBuckets / 100)
+
+	route.MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+		key := hashKey(req)
+		if key == "" {
+			// Requests without a stable key always go to the non-canary routers.
+			return false
+		}
+
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(key))
+
+		return h.Sum64()%canaryBuckets < threshold
+	})
+
+	return nil
+}
+
+// newCanaryKey returns the function extracting the stable key to hash from a request.
+// Supported values are "ClientIP", "Header:<name>" and "Cookie:<name>".
+func newCanaryKey(value string) (func(*http.Request) string, error) {
+	kind, name, _ := strings.Cut(value, ":")
+
+	switch strings.ToLower(kind) {
+	case "clientip":
+		if name != "" {
+			break
+		}
+
+		strategy := ip.RemoteAddrStrategy{}
+		return strategy.GetIP, nil
+	case "header":
+		if name == "" {
+			break
+		}
+
+		return func(req *http.Request) string {
+			return req.Header.Get(name)
+		}, nil
+	case "cookie":
+		if name == "" {
+			break
+		}
+
+		return func(req *http.Request) string {
+			c, err := req.Cookie(name)
+			if err != nil {
+				return ""
+			}
+			return c.Value
+		}, nil
+	}
+
+	return nil, fmt.Errorf("invalid hash key %q for \"Canary\" matcher, must be one of ClientIP, Header:<name> or Cookie:<name>", value)
+}
+
 func addRuleOnRouter(router *mux.Router, rule *rules.Tree) error {
 	switch rule.Matcher {
 	case "and":
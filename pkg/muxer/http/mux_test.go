@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -636,6 +637,124 @@ func Test_addRoute(t *testing.T) {
 				"http://tchouk/toto": http.StatusOK,
 			},
 		},
+		{
+			desc:          "Cookie with missing value",
+			rule:          "Cookie(`session`)",
+			expectedError: true,
+		},
+		{
+			desc:          "Cookie with empty value",
+			rule:          "Cookie(`session`, ``)",
+			expectedError: true,
+		},
+		{
+			desc:    "Matching Cookie",
+			rule:    "Cookie(`canary`, `true`)",
+			headers: map[string]string{"Cookie": "foo=bar; canary=true"},
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusOK,
+			},
+		},
+		{
+			desc:    "Non matching Cookie value",
+			rule:    "Cookie(`canary`, `true`)",
+			headers: map[string]string{"Cookie": "canary=false"},
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "Missing Cookie",
+			rule: "Cookie(`canary`, `true`)",
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusNotFound,
+			},
+		},
+		{
+			desc:    "Not Cookie",
+			rule:    "Host(`tchouk`) && !Cookie(`canary`, `true`)",
+			headers: map[string]string{"Cookie": "canary=false"},
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusOK,
+			},
+		},
+		{
+			desc:          "Invalid CookieRegexp",
+			rule:          "CookieRegexp(`user`, `[a-z`)",
+			expectedError: true,
+		},
+		{
+			desc:    "Matching CookieRegexp",
+			rule:    "CookieRegexp(`user`, `^beta-[0-9]+$`)",
+			headers: map[string]string{"Cookie": "user=beta-42"},
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusOK,
+			},
+		},
+		{
+			desc:    "Non matching CookieRegexp",
+			rule:    "CookieRegexp(`user`, `^beta-[0-9]+$`)",
+			headers: map[string]string{"Cookie": "user=alpha-42"},
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusNotFound,
+			},
+		},
+		{
+			desc:    "Cookie or Header",
+			rule:    "Cookie(`canary`, `true`) || Headers(`X-Canary`, `true`)",
+			headers: map[string]string{"X-Canary": "true"},
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusOK,
+			},
+		},
+		{
+			desc:          "Canary with missing hash key",
+			rule:          "Canary(`10`)",
+			expectedError: true,
+		},
+		{
+			desc:          "Canary with invalid percentage",
+			rule:          "Canary(`110`, `ClientIP`)",
+			expectedError: true,
+		},
+		{
+			desc:          "Canary with invalid hash key",
+			rule:          "Canary(`10`, `Query:user`)",
+			expectedError: true,
+		},
+		{
+			desc:          "Canary with header hash key without name",
+			rule:          "Canary(`10`, `Header:`)",
+			expectedError: true,
+		},
+		{
+			desc:    "Canary with all the traffic",
+			rule:    "Canary(`100`, `Header:X-User`)",
+			headers: map[string]string{"X-User": "john"},
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusOK,
+			},
+		},
+		{
+			desc:    "Canary with no traffic",
+			rule:    "Canary(`0`, `Header:X-User`)",
+			headers: map[string]string{"X-User": "john"},
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "Canary without hash key in request",
+			rule: "Canary(`100`, `Cookie:session`)",
+			expected: map[string]int{
+				"http://tchouk/toto": http.StatusNotFound,
+			},
+		},
+		{
+			desc:          "JWTClaim with missing value",
+			rule:          "JWTClaim(`tenant`)",
+			expectedError: true,
+		},
 	}
 
 	for _, test := range testCases {
@@ -654,6 +773,8 @@ func Test_addRoute(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 
+				muxer.SortRoutes()
+
 				// RequestDecorator is necessary for the host rule
 				reqHost := requestdecorator.New(nil)
 
@@ -671,6 +792,11 @@ func Test_addRoute(t *testing.T) {
 					}
 					reqHost.ServeHTTP(w, req, muxer.ServeHTTP)
 					results[calledURL] = w.Code
+
+					// The indexed routes must behave as the linear evaluation of all the routes.
+					linear := httptest.NewRecorder()
+					reqHost.ServeHTTP(linear, req, muxer.Router.ServeHTTP)
+					assert.Equal(t, linear.Code, w.Code, calledURL)
 				}
 				assert.Equal(t, test.expected, results)
 			}
@@ -678,6 +804,201 @@ func Test_addRoute(t *testing.T) {
 	}
 }
 
+func TestJWTClaim(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rule     string
+		claims   map[string]interface{}
+		expected int
+	}{
+		{
+			desc:     "no validated claims",
+			rule:     "JWTClaim(`tenant`, `acme`)",
+			expected: http.StatusNotFound,
+		},
+		{
+			desc:     "matching string claim",
+			rule:     "JWTClaim(`tenant`, `acme`)",
+			claims:   map[string]interface{}{"tenant": "acme"},
+			expected: http.StatusOK,
+		},
+		{
+			desc:     "non matching string claim",
+			rule:     "JWTClaim(`tenant`, `acme`)",
+			claims:   map[string]interface{}{"tenant": "other"},
+			expected: http.StatusNotFound,
+		},
+		{
+			desc:     "missing claim",
+			rule:     "JWTClaim(`tenant`, `acme`)",
+			claims:   map[string]interface{}{"sub": "john"},
+			expected: http.StatusNotFound,
+		},
+		{
+			desc:     "matching array claim",
+			rule:     "JWTClaim(`groups`, `admin`)",
+			claims:   map[string]interface{}{"groups": []interface{}{"dev", "admin"}},
+			expected: http.StatusOK,
+		},
+		{
+			desc:     "matching nested claim",
+			rule:     "JWTClaim(`realm_access.roles`, `ops`)",
+			claims:   map[string]interface{}{"realm_access": map[string]interface{}{"roles": []interface{}{"ops"}}},
+			expected: http.StatusOK,
+		},
+		{
+			desc:     "matching number claim",
+			rule:     "JWTClaim(`tier`, `2`)",
+			claims:   map[string]interface{}{"tier": float64(2)},
+			expected: http.StatusOK,
+		},
+		{
+			desc:     "matching boolean claim",
+			rule:     "JWTClaim(`beta`, `true`)",
+			claims:   map[string]interface{}{"beta": true},
+			expected: http.StatusOK,
+		},
+		{
+			desc:     "inverted claim",
+			rule:     "!JWTClaim(`tenant`, `acme`)",
+			claims:   map[string]interface{}{"tenant": "other"},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, 0, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			require.NoError(t, err)
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost/", nil)
+			if test.claims != nil {
+				req = req.WithContext(requestdecorator.WithJWTClaims(req.Context(), test.claims))
+			}
+
+			w := httptest.NewRecorder()
+			muxer.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expected, w.Code)
+		})
+	}
+}
+
+func TestCanary(t *testing.T) {
+	muxer, err := NewMuxer()
+	require.NoError(t, err)
+
+	err = muxer.AddRoute("Host(`localhost`) && Canary(`20`, `Header:X-User`)", 20, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-From", "canary")
+	}))
+	require.NoError(t, err)
+
+	err = muxer.AddRoute("Host(`localhost`)", 10, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-From", "stable")
+	}))
+	require.NoError(t, err)
+
+	muxer.SortRoutes()
+
+	reqHost := requestdecorator.New(nil)
+
+	serve := func(user string) string {
+		req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost/", nil)
+		req.Header.Set("X-User", user)
+
+		w := httptest.NewRecorder()
+		reqHost.ServeHTTP(w, req, muxer.ServeHTTP)
+
+		return w.Header().Get("X-From")
+	}
+
+	const users = 10000
+
+	var canaries int
+	for i := 0; i < users; i++ {
+		user := "user-" + strconv.Itoa(i)
+
+		from := serve(user)
+		if from == "canary" {
+			canaries++
+		}
+
+		// The same user must always be routed to the same router.
+		assert.Equal(t, from, serve(user))
+	}
+
+	assert.InDelta(t, users*0.2, canaries, users*0.02)
+}
+
+func TestPathParams(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rule     string
+		sorted   bool
+		path     string
+		expected map[string]string
+	}{
+		{
+			desc:     "Path with a named capture",
+			rule:     "Path(`/users/{id:[0-9]+}`)",
+			sorted:   true,
+			path:     "/users/42",
+			expected: map[string]string{"id": "42"},
+		},
+		{
+			desc:     "PathPrefix with named captures in alternatives",
+			rule:     "Host(`localhost`) && (PathPrefix(`/{lang:[a-z]{2}}/docs/{page}`) || Path(`/docs`))",
+			sorted:   true,
+			path:     "/fr/docs/intro/routing",
+			expected: map[string]string{"lang": "fr", "page": "intro"},
+		},
+		{
+			desc:     "Path with a named capture on unsorted routes",
+			rule:     "Path(`/users/{id:[0-9]+}`)",
+			path:     "/users/42",
+			expected: map[string]string{"id": "42"},
+		},
+		{
+			desc: "Path without captures",
+			rule: "Path(`/users`)",
+			path: "/users",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			var params map[string]string
+			err = muxer.AddRoute(test.rule, 0, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				params = requestdecorator.GetPathParams(r.Context())
+			}))
+			require.NoError(t, err)
+
+			if test.sorted {
+				muxer.SortRoutes()
+			}
+
+			w := httptest.NewRecorder()
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			requestdecorator.New(nil).ServeHTTP(w, req, muxer.ServeHTTP)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, test.expected, params)
+		})
+	}
+}
+
 func Test_addRoutePriority(t *testing.T) {
 	type Case struct {
 		xFrom    string
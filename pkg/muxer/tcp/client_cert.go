@@ -0,0 +1,60 @@
+package tcp
+
+import (
+	"crypto/tls"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/tcp"
+)
+
+// clientCertHandler terminates the TLS connection to get the client certificate,
+// and forwards the decrypted connection to the first route matching it.
+// The routes are the ones which can still match the connection before the handshake, in evaluation order.
+// Only the routes with the TLS configuration used for the handshake can handle the connection,
+// as the other ones would be served over a handshake which did not apply their TLS options.
+type clientCertHandler struct {
+	config *tls.Config
+	routes []*route
+	meta   ConnData
+}
+
+// ServeTCP terminates the TLS connection, and forwards it to the first matching route.
+func (h *clientCertHandler) ServeTCP(conn tcp.WriteCloser) {
+	tlsConn := tls.Server(conn, h.config)
+	if err := tlsConn.Handshake(); err != nil {
+		log.Debug().Err(err).Msg("Error during TLS handshake")
+		_ = conn.Close()
+		return
+	}
+
+	meta := h.meta
+	meta.handshakeDone = true
+
+	// Only a verified client certificate can be trusted to identify the client,
+	// i.e. when the TLS options require the verification of the client certificates.
+	if chains := tlsConn.ConnectionState().VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+		meta.clientCert = chains[0][0]
+	}
+
+	for _, route := range h.routes {
+		if !route.matchers.match(meta) {
+			continue
+		}
+
+		// The connection is already decrypted, so only the routes terminating TLS can handle it.
+		tlsHandler, ok := route.handler.(*tcp.TLSHandler)
+		if !ok {
+			break
+		}
+
+		if tlsHandler.Config != h.config {
+			continue
+		}
+
+		tlsHandler.Next.ServeTCP(tlsConn)
+		return
+	}
+
+	log.Debug().Msg("No TLS router matching the client certificate")
+	_ = tlsConn.Close()
+}
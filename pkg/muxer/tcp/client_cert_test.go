@@ -0,0 +1,209 @@
+package tcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/tcp"
+)
+
+type pipeConn struct {
+	net.Conn
+}
+
+func (p pipeConn) CloseWrite() error {
+	return p.Close()
+}
+
+func TestClientCertHandler(t *testing.T) {
+	serverCert := generateCert(t, pkix.Name{CommonName: "foo.com"}, []string{"foo.com"})
+
+	testCases := []struct {
+		desc       string
+		clientAuth tls.ClientAuthType
+		subject    pkix.Name
+		sans       []string
+		untrusted  bool
+		expected   string
+	}{
+		{
+			desc:       "matching subject",
+			clientAuth: tls.RequireAndVerifyClientCert,
+			subject:    pkix.Name{CommonName: "tenant-a"},
+			expected:   "tenant-a",
+		},
+		{
+			desc:       "matching SAN",
+			clientAuth: tls.RequireAndVerifyClientCert,
+			subject:    pkix.Name{CommonName: "tenant-b"},
+			sans:       []string{"tenant-b.acme.com"},
+			expected:   "tenant-b",
+		},
+		{
+			desc:       "no matching client certificate",
+			clientAuth: tls.RequireAndVerifyClientCert,
+			subject:    pkix.Name{CommonName: "tenant-c"},
+			expected:   "default",
+		},
+		{
+			desc:       "matching subject verified if given",
+			clientAuth: tls.VerifyClientCertIfGiven,
+			subject:    pkix.Name{CommonName: "tenant-a"},
+			expected:   "tenant-a",
+		},
+		{
+			desc:       "unverified client certificate with matching subject",
+			clientAuth: tls.RequireAnyClientCert,
+			subject:    pkix.Name{CommonName: "tenant-a"},
+			untrusted:  true,
+			expected:   "default",
+		},
+		{
+			desc:       "unverified client certificate with matching SAN",
+			clientAuth: tls.RequestClientCert,
+			subject:    pkix.Name{CommonName: "tenant-b"},
+			sans:       []string{"tenant-b.acme.com"},
+			untrusted:  true,
+			expected:   "default",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			clientCert := generateCert(t, test.subject, test.sans)
+
+			clientCAs := x509.NewCertPool()
+			if !test.untrusted {
+				leaf, err := x509.ParseCertificate(clientCert.Certificate[0])
+				require.NoError(t, err)
+				clientCAs.AddCert(leaf)
+			}
+
+			serverConfig := &tls.Config{
+				Certificates: []tls.Certificate{serverCert},
+				ClientAuth:   test.clientAuth,
+				ClientCAs:    clientCAs,
+			}
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute("HostSNI(`foo.com`) && ClientCertSubject(`CN=tenant-a`)", 0, respondTLS(serverConfig, "tenant-a"))
+			require.NoError(t, err)
+
+			err = muxer.AddRoute("HostSNI(`foo.com`) && ClientCertSAN(`tenant-b.acme.com`)", 0, respondTLS(serverConfig, "tenant-b"))
+			require.NoError(t, err)
+
+			err = muxer.AddRoute("HostSNI(`foo.com`)", 0, respondTLS(serverConfig, "default"))
+			require.NoError(t, err)
+
+			response, err := dialClientCert(t, muxer, clientCert)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, response)
+		})
+	}
+}
+
+func TestClientCertHandler_otherTLSConfig(t *testing.T) {
+	serverCert := generateCert(t, pkix.Name{CommonName: "foo.com"}, []string{"foo.com"})
+	clientCert := generateCert(t, pkix.Name{CommonName: "tenant-c"}, nil)
+
+	leaf, err := x509.ParseCertificate(clientCert.Certificate[0])
+	require.NoError(t, err)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(leaf)
+
+	muxer, err := NewMuxer()
+	require.NoError(t, err)
+
+	err = muxer.AddRoute("HostSNI(`foo.com`) && ClientCertSubject(`CN=tenant-a`)", 0, respondTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		ClientCAs:    clientCAs,
+	}, "tenant-a"))
+	require.NoError(t, err)
+
+	// The handshake did not apply the TLS options of this route, which requires another CA.
+	err = muxer.AddRoute("HostSNI(`foo.com`)", 0, respondTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    x509.NewCertPool(),
+	}, "default"))
+	require.NoError(t, err)
+
+	response, _ := dialClientCert(t, muxer, clientCert)
+	assert.Empty(t, response)
+}
+
+// respondTLS returns a handler terminating TLS with the given configuration, and writing the given response.
+func respondTLS(config *tls.Config, response string) tcp.Handler {
+	return &tcp.TLSHandler{
+		Config: config,
+		Next: tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+			_, _ = conn.Write([]byte(response))
+			_ = conn.Close()
+		}),
+	}
+}
+
+// dialClientCert connects to the given muxer with the given client certificate, and returns the response.
+func dialClientCert(t *testing.T, muxer *Muxer, clientCert tls.Certificate) (string, error) {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+
+	handler, _ := muxer.Match(ConnData{serverName: "foo.com"})
+	require.NotNil(t, handler)
+
+	go handler.ServeTCP(pipeConn{Conn: serverConn})
+
+	client := tls.Client(clientConn, &tls.Config{
+		ServerName:         "foo.com",
+		Certificates:       []tls.Certificate{clientCert},
+		InsecureSkipVerify: true,
+	})
+	defer func() { _ = client.Close() }()
+
+	require.NoError(t, client.SetDeadline(time.Now().Add(5*time.Second)))
+
+	response, err := io.ReadAll(client)
+	return string(response), err
+}
+
+func generateCert(t *testing.T, subject pkix.Name, dnsNames []string) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      subject,
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -2,6 +2,7 @@ package tcp
 
 import (
 	"bytes"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -11,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"github.com/pires/go-proxyproto"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v2/pkg/ip"
 	"github.com/traefik/traefik/v2/pkg/rules"
@@ -20,15 +22,32 @@ import (
 )
 
 var tcpFuncs = map[string]func(*matchersTree, ...string) error{
-	"HostSNI":       hostSNI,
-	"HostSNIRegexp": hostSNIRegexp,
-	"ClientIP":      clientIP,
-	"ALPN":          alpn,
+	"HostSNI":           hostSNI,
+	"HostSNIRegexp":     hostSNIRegexp,
+	"ClientIP":          clientIP,
+	"ALPN":              alpn,
+	"ClientCertSubject": clientCertSubject,
+	"ClientCertSAN":     clientCertSAN,
+	"ProxyTLV":          proxyTLV,
 }
 
+// clientCertMatchers are the matchers which depend on the client certificate,
+// only known once the TLS connection is terminated.
+var clientCertMatchers = []string{"ClientCertSubject", "ClientCertSAN"}
+
 // ParseHostSNI extracts the HostSNIs declared in a rule.
 // This is a first naive implementation used in TCP routing.
 func ParseHostSNI(rule string) ([]string, error) {
+	tree, err := ParseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return tree.ParseMatchers([]string{"HostSNI"}), nil
+}
+
+// ParseRule parses the given rule into its tree representation, without validating the matchers values.
+func ParseRule(rule string) (*rules.Tree, error) {
 	var matchers []string
 	for matcher := range tcpFuncs {
 		matchers = append(matchers, matcher)
@@ -49,7 +68,18 @@ func ParseHostSNI(rule string) ([]string, error) {
 		return nil, fmt.Errorf("error while parsing rule %s", rule)
 	}
 
-	return buildTree().ParseMatchers([]string{"HostSNI"}), nil
+	return buildTree(), nil
+}
+
+// UsesClientCert reports whether the given rule matches on the client certificate,
+// which requires the TLS connection to be terminated by the router.
+func UsesClientCert(rule string) (bool, error) {
+	tree, err := ParseRule(rule)
+	if err != nil {
+		return false, err
+	}
+
+	return len(tree.ParseMatchers(clientCertMatchers)) > 0, nil
 }
 
 // ConnData contains TCP connection metadata.
@@ -57,6 +87,12 @@ type ConnData struct {
 	serverName string
 	remoteIP   string
 	alpnProtos []string
+	// proxyTLVs are the TLVs of the PROXY protocol v2 header of the connection.
+	proxyTLVs []proxyproto.TLV
+	// handshakeDone reports whether the TLS connection has been terminated,
+	// in which case clientCert holds the certificate presented by the client, if it has been verified.
+	handshakeDone bool
+	clientCert    *x509.Certificate
 }
 
 // NewConnData builds a connData struct from the given parameters.
@@ -71,13 +107,29 @@ func NewConnData(serverName string, conn tcp.WriteCloser, alpnProtos []string) (
 	// so there is no need to trim a potential trailing dot
 	serverName = types.CanonicalDomain(serverName)
 
+	var proxyTLVs []proxyproto.TLV
+	if proxyConn, ok := conn.(tcp.ProxyProtocolConn); ok {
+		proxyTLVs = proxyConn.ProxyTLVs()
+	}
+
 	return ConnData{
 		serverName: types.CanonicalDomain(serverName),
 		remoteIP:   remoteIP,
 		alpnProtos: alpnProtos,
+		proxyTLVs:  proxyTLVs,
 	}, nil
 }
 
+// NewSyntheticConnData builds a connData struct which does not originate from an actual connection,
+// e.g. to explain which route would handle a connection with the given metadata.
+func NewSyntheticConnData(serverName, remoteIP string, alpnProtos []string) ConnData {
+	return ConnData{
+		serverName: types.CanonicalDomain(serverName),
+		remoteIP:   remoteIP,
+		alpnProtos: alpnProtos,
+	}
+}
+
 // Muxer defines a muxer that handles TCP routing with rules.
 type Muxer struct {
 	routes []*route
@@ -101,19 +153,80 @@ func NewMuxer() (*Muxer, error) {
 
 // Match returns the handler of the first route matching the connection metadata,
 // and whether the match is exactly from the rule HostSNI(*).
+// When the rule of this route depends on the client certificate,
+// the returned handler terminates the TLS connection before matching the routes again.
 func (m Muxer) Match(meta ConnData) (tcp.Handler, bool) {
-	for _, route := range m.routes {
-		if route.matchers.match(meta) {
-			return route.handler, route.catchAll
+	for i, route := range m.routes {
+		if !route.matchers.match(meta) {
+			continue
+		}
+
+		if route.clientCert && !meta.handshakeDone {
+			if tlsHandler, ok := route.handler.(*tcp.TLSHandler); ok {
+				return &clientCertHandler{config: tlsHandler.Config, routes: m.routes[i:], meta: meta}, route.catchAll
+			}
 		}
+
+		return route.handler, route.catchAll
 	}
 
 	return nil, false
 }
 
+// MatchResult describes the evaluation of a route against connection metadata.
+type MatchResult struct {
+	Name     string
+	Handler  tcp.Handler
+	Priority int
+	Matched  bool
+	// FailedMatchers are the matchers of the route rule which do not match the connection, when the route does not match.
+	FailedMatchers []*rules.Tree
+}
+
+// MatchAll evaluates all the routes against the connection metadata, in the order they are evaluated by Match.
+// It is meant to explain routing decisions.
+func (m Muxer) MatchAll(meta ConnData) []MatchResult {
+	results := make([]MatchResult, 0, len(m.routes))
+	for _, route := range m.routes {
+		result := MatchResult{
+			Name:     route.name,
+			Handler:  route.handler,
+			Priority: route.priority,
+			Matched:  route.matchers.match(meta),
+		}
+
+		if !result.Matched {
+			result.FailedMatchers = failedMatchers(route.rule, meta)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// failedMatchers returns the matchers of the given rule which do not match the connection metadata.
+func failedMatchers(rule *rules.Tree, meta ConnData) []*rules.Tree {
+	var failed []*rules.Tree
+	for _, leaf := range rule.Leaves() {
+		var matchers matchersTree
+		if err := addRule(&matchers, leaf); err != nil || !matchers.match(meta) {
+			failed = append(failed, leaf)
+		}
+	}
+
+	return failed
+}
+
 // AddRoute adds a new route, associated to the given handler, at the given
 // priority, to the muxer.
 func (m *Muxer) AddRoute(rule string, priority int, handler tcp.Handler) error {
+	return m.AddNamedRoute("", rule, priority, handler)
+}
+
+// AddNamedRoute adds a new route to the muxer, named after the router it comes from,
+// so that the route can be told apart when explaining a routing decision.
+func (m *Muxer) AddNamedRoute(name, rule string, priority int, handler tcp.Handler) error {
 	parse, err := m.parser.Parse(rule)
 	if err != nil {
 		return fmt.Errorf("error while parsing rule %s: %w", rule, err)
@@ -150,10 +263,13 @@ func (m *Muxer) AddRoute(rule string, priority int, handler tcp.Handler) error {
 	}
 
 	newRoute := &route{
-		handler:  handler,
-		matchers: matchers,
-		catchAll: catchAll,
-		priority: priority,
+		name:       name,
+		rule:       ruleTree,
+		handler:    handler,
+		matchers:   matchers,
+		catchAll:   catchAll,
+		clientCert: len(ruleTree.ParseMatchers(clientCertMatchers)) > 0,
+		priority:   priority,
 	}
 	m.routes = append(m.routes, newRoute)
 
@@ -191,6 +307,15 @@ func addRule(tree *matchersTree, rule *rules.Tree) error {
 				return !matcherFunc(meta)
 			}
 		}
+
+		if isClientCertMatcher(rule.Matcher) {
+			// Until the TLS connection is terminated, the client certificate is unknown,
+			// and the matcher (or its negation) optimistically matches.
+			matcherFunc := tree.matcher
+			tree.matcher = func(meta ConnData) bool {
+				return !meta.handshakeDone || matcherFunc(meta)
+			}
+		}
 	}
 
 	return nil
@@ -216,12 +341,18 @@ func (r routes) Less(i, j int) bool { return r[i].priority > r[j].priority }
 // route holds the matchers to match TCP route,
 // and the handler that will serve the connection.
 type route struct {
+	// name of the router the route comes from, if any.
+	name string
+	// rule is the parsed rule of the route.
+	rule *rules.Tree
 	// matchers tree structure reflecting the rule.
 	matchers matchersTree
 	// handler responsible for handling the route.
 	handler tcp.Handler
 	// catchAll indicates whether the route rule has exactly the catchAll value (HostSNI(`*`)).
 	catchAll bool
+	// clientCert indicates whether the route rule depends on the client certificate.
+	clientCert bool
 	// priority is used to disambiguate between two (or more) rules that would
 	// all match for a given request.
 	// Computed from the matching rule length, if not user-set.
@@ -406,6 +537,150 @@ func hostSNIRegexp(tree *matchersTree, templates ...string) error {
 	return nil
 }
 
+// clientCertSubject checks if the subject of the client certificate matches one of the matcher subjects,
+// formatted as an RFC 2253 distinguished name (e.g. CN=foo,O=Bar).
+func clientCertSubject(tree *matchersTree, subjects ...string) error {
+	if len(subjects) == 0 {
+		return errors.New("empty value for \"ClientCertSubject\" matcher is not allowed")
+	}
+
+	tree.matcher = func(meta ConnData) bool {
+		if meta.clientCert == nil {
+			return false
+		}
+
+		subject := meta.clientCert.Subject.String()
+		for _, s := range subjects {
+			if s == subject {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return nil
+}
+
+// clientCertSAN checks if one of the subject alternative names (DNS names, email addresses, IP addresses and URIs)
+// of the client certificate matches one of the matcher names.
+func clientCertSAN(tree *matchersTree, sans ...string) error {
+	if len(sans) == 0 {
+		return errors.New("empty value for \"ClientCertSAN\" matcher is not allowed")
+	}
+
+	tree.matcher = func(meta ConnData) bool {
+		if meta.clientCert == nil {
+			return false
+		}
+
+		cert := meta.clientCert
+		for _, san := range sans {
+			for _, name := range cert.DNSNames {
+				if strings.EqualFold(san, name) {
+					return true
+				}
+			}
+
+			for _, email := range cert.EmailAddresses {
+				if san == email {
+					return true
+				}
+			}
+
+			for _, ip := range cert.IPAddresses {
+				if sanIP := net.ParseIP(san); sanIP != nil && sanIP.Equal(ip) {
+					return true
+				}
+			}
+
+			for _, uri := range cert.URIs {
+				if san == uri.String() {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	return nil
+}
+
+// proxyTLV checks if the connection PROXY protocol v2 header has a TLV of the matcher type, with the matcher value.
+// The type can be followed by a subtype (e.g. 0xEA:0x01 for the AWS VPC endpoint ID),
+// which is then expected as the first byte of the TLV value, and is not part of the compared value.
+func proxyTLV(tree *matchersTree, values ...string) error {
+	if len(values) != 2 {
+		return fmt.Errorf("unexpected number of parameters for \"ProxyTLV\" matcher; got %d, expected 2", len(values))
+	}
+
+	typ, subtype, hasSubtype, err := parseTLVType(values[0])
+	if err != nil {
+		return fmt.Errorf("invalid type for \"ProxyTLV\" matcher: %w", err)
+	}
+
+	value := values[1]
+
+	tree.matcher = func(meta ConnData) bool {
+		for _, tlv := range meta.proxyTLVs {
+			if tlv.Type != typ {
+				continue
+			}
+
+			tlvValue := tlv.Value
+			if hasSubtype {
+				if len(tlvValue) == 0 || tlvValue[0] != subtype {
+					continue
+				}
+				tlvValue = tlvValue[1:]
+			}
+
+			if string(tlvValue) == value {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return nil
+}
+
+// parseTLVType parses a TLV type, optionally followed by a subtype, e.g. 0xEA or 0xEA:0x01.
+func parseTLVType(value string) (proxyproto.PP2Type, byte, bool, error) {
+	typ, subtype, hasSubtype := value, "", false
+	if i := strings.IndexByte(value, ':'); i >= 0 {
+		typ, subtype, hasSubtype = value[:i], value[i+1:], true
+	}
+
+	t, err := strconv.ParseUint(typ, 0, 8)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("%q is not a byte: %w", typ, err)
+	}
+
+	if !hasSubtype {
+		return proxyproto.PP2Type(t), 0, false, nil
+	}
+
+	st, err := strconv.ParseUint(subtype, 0, 8)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("%q is not a byte: %w", subtype, err)
+	}
+
+	return proxyproto.PP2Type(t), byte(st), true, nil
+}
+
+func isClientCertMatcher(matcher string) bool {
+	for _, m := range clientCertMatchers {
+		if m == matcher {
+			return true
+		}
+	}
+
+	return false
+}
+
 // TODO: expose more of containous/mux fork to get rid of the following copied code (https://github.com/containous/mux/blob/8ffa4f6d063c/regexp.go).
 
 // preparePattern builds a regexp pattern from the initial user defined expression.
@@ -1,12 +1,17 @@
 package tcp
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"net"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"github.com/pires/go-proxyproto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/tcp"
@@ -1125,3 +1130,227 @@ func Test_Priority(t *testing.T) {
 		})
 	}
 }
+
+func Test_ClientCertSubject(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "tenant-a", Organization: []string{"Acme"}},
+	}
+
+	testCases := []struct {
+		desc       string
+		subjects   []string
+		clientCert *x509.Certificate
+		buildErr   bool
+		matchErr   bool
+	}{
+		{
+			desc:     "Empty",
+			buildErr: true,
+		},
+		{
+			desc:     "Not matching without certificate",
+			subjects: []string{"CN=tenant-a,O=Acme"},
+			matchErr: true,
+		},
+		{
+			desc:       "Not matching subject",
+			subjects:   []string{"CN=tenant-b,O=Acme"},
+			clientCert: cert,
+			matchErr:   true,
+		},
+		{
+			desc:       "Matching subject",
+			subjects:   []string{"CN=tenant-b,O=Acme", "CN=tenant-a,O=Acme"},
+			clientCert: cert,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			matchersTree := &matchersTree{}
+			err := clientCertSubject(matchersTree, test.subjects...)
+			if test.buildErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			meta := ConnData{
+				handshakeDone: true,
+				clientCert:    test.clientCert,
+			}
+
+			assert.Equal(t, test.matchErr, !matchersTree.match(meta))
+		})
+	}
+}
+
+func Test_ClientCertSAN(t *testing.T) {
+	uri, err := url.Parse("spiffe://acme.com/tenant-a")
+	require.NoError(t, err)
+
+	cert := &x509.Certificate{
+		DNSNames:       []string{"db.tenant-a.acme.com"},
+		EmailAddresses: []string{"admin@tenant-a.acme.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{uri},
+	}
+
+	testCases := []struct {
+		desc     string
+		sans     []string
+		buildErr bool
+		matchErr bool
+	}{
+		{
+			desc:     "Empty",
+			buildErr: true,
+		},
+		{
+			desc:     "Not matching SAN",
+			sans:     []string{"db.tenant-b.acme.com"},
+			matchErr: true,
+		},
+		{
+			desc: "Matching DNS name",
+			sans: []string{"DB.tenant-a.acme.com"},
+		},
+		{
+			desc: "Matching email address",
+			sans: []string{"admin@tenant-a.acme.com"},
+		},
+		{
+			desc: "Matching IP address",
+			sans: []string{"10.0.0.1"},
+		},
+		{
+			desc: "Matching URI",
+			sans: []string{"db.tenant-b.acme.com", "spiffe://acme.com/tenant-a"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			matchersTree := &matchersTree{}
+			err := clientCertSAN(matchersTree, test.sans...)
+			if test.buildErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			meta := ConnData{
+				handshakeDone: true,
+				clientCert:    cert,
+			}
+
+			assert.Equal(t, test.matchErr, !matchersTree.match(meta))
+		})
+	}
+}
+
+func Test_ProxyTLV(t *testing.T) {
+	tlvs := []proxyproto.TLV{
+		{Type: proxyproto.PP2_TYPE_AUTHORITY, Value: []byte("foo.com")},
+		{Type: 0xEA, Value: append([]byte{0x01}, "vpce-123"...)},
+	}
+
+	testCases := []struct {
+		desc     string
+		values   []string
+		buildErr bool
+		matchErr bool
+	}{
+		{
+			desc:     "Missing value",
+			values:   []string{"0x02"},
+			buildErr: true,
+		},
+		{
+			desc:     "Invalid type",
+			values:   []string{"0x100", "foo.com"},
+			buildErr: true,
+		},
+		{
+			desc:     "Invalid subtype",
+			values:   []string{"0xEA:foo", "vpce-123"},
+			buildErr: true,
+		},
+		{
+			desc:   "Matching TLV",
+			values: []string{"0x02", "foo.com"},
+		},
+		{
+			desc:   "Matching TLV with decimal type",
+			values: []string{"2", "foo.com"},
+		},
+		{
+			desc:     "Not matching TLV value",
+			values:   []string{"0x02", "bar.com"},
+			matchErr: true,
+		},
+		{
+			desc:     "Not matching TLV type",
+			values:   []string{"0x05", "foo.com"},
+			matchErr: true,
+		},
+		{
+			desc:   "Matching TLV with subtype",
+			values: []string{"0xEA:0x01", "vpce-123"},
+		},
+		{
+			desc:     "Not matching TLV subtype",
+			values:   []string{"0xEA:0x02", "vpce-123"},
+			matchErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			matchersTree := &matchersTree{}
+			err := proxyTLV(matchersTree, test.values...)
+			if test.buildErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			meta := ConnData{
+				proxyTLVs: tlvs,
+			}
+
+			assert.Equal(t, test.matchErr, !matchersTree.match(meta))
+		})
+	}
+}
+
+func TestMuxer_clientCertBeforeHandshake(t *testing.T) {
+	muxer, err := NewMuxer()
+	require.NoError(t, err)
+
+	tlsHandler := &tcp.TLSHandler{Next: tcp.HandlerFunc(func(conn tcp.WriteCloser) {}), Config: &tls.Config{}}
+
+	err = muxer.AddRoute("HostSNI(`foo.com`) && !ClientCertSubject(`CN=foo`)", 0, tlsHandler)
+	require.NoError(t, err)
+
+	handler, _ := muxer.Match(ConnData{serverName: "foo.com"})
+	require.IsType(t, &clientCertHandler{}, handler)
+
+	handler, _ = muxer.Match(ConnData{serverName: "bar.com"})
+	assert.Nil(t, handler)
+
+	handler, _ = muxer.Match(ConnData{serverName: "foo.com", handshakeDone: true})
+	assert.Equal(t, tlsHandler, handler)
+}
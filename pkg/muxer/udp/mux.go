@@ -0,0 +1,265 @@
+package udp
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/ip"
+	"github.com/traefik/traefik/v2/pkg/rules"
+	"github.com/traefik/traefik/v2/pkg/udp"
+	"github.com/vulcand/predicate"
+)
+
+var udpFuncs = map[string]func(*matchersTree, ...string) error{
+	"ClientIP": clientIP,
+}
+
+// ParseRule parses the given rule into its tree representation, without validating the matchers values.
+func ParseRule(rule string) (*rules.Tree, error) {
+	parser, err := newParser()
+	if err != nil {
+		return nil, err
+	}
+
+	parse, err := parser.Parse(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	buildTree, ok := parse.(rules.TreeBuilder)
+	if !ok {
+		return nil, fmt.Errorf("error while parsing rule %s", rule)
+	}
+
+	return buildTree(), nil
+}
+
+// Muxer defines a muxer that handles UDP routing with rules.
+type Muxer struct {
+	routes []*route
+	parser predicate.Parser
+}
+
+// NewMuxer returns a UDP muxer.
+func NewMuxer() (*Muxer, error) {
+	parser, err := newParser()
+	if err != nil {
+		return nil, fmt.Errorf("error while creating rules parser: %w", err)
+	}
+
+	return &Muxer{parser: parser}, nil
+}
+
+func newParser() (predicate.Parser, error) {
+	var matcherNames []string
+	for matcherName := range udpFuncs {
+		matcherNames = append(matcherNames, matcherName)
+	}
+
+	return rules.NewParser(matcherNames)
+}
+
+// Match returns the handler of the first route matching the IP address of the session client.
+func (m Muxer) Match(remoteIP string) udp.Handler {
+	for _, route := range m.routes {
+		if route.matchers.match(remoteIP) {
+			return route.handler
+		}
+	}
+
+	return nil
+}
+
+// ServeUDP implements the udp.Handler interface,
+// by forwarding the session to the handler of the first route matching its client IP.
+// The session is closed if no route matches.
+func (m Muxer) ServeUDP(conn *udp.Conn) {
+	remoteIP, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		log.Error().Err(err).Msgf("Could not extract the client IP from %s", conn.RemoteAddr())
+		conn.Close()
+		return
+	}
+
+	handler := m.Match(remoteIP)
+	if handler == nil {
+		log.Debug().Msgf("No UDP route matching the session from %s", conn.RemoteAddr())
+		conn.Close()
+		return
+	}
+
+	handler.ServeUDP(conn)
+}
+
+// AddRoute adds a new route, associated to the given handler, at the given
+// priority, to the muxer.
+// An empty rule matches all the sessions, and is evaluated last unless a priority is set.
+func (m *Muxer) AddRoute(rule string, priority int, handler udp.Handler) error {
+	var matchers matchersTree
+
+	if rule == "" {
+		matchers.matcher = func(string) bool { return true }
+
+		// Routers without rule are fallbacks:
+		// when no user-defined priority is found, the lowest computable priority minus one is used.
+		if priority == 0 {
+			priority = -1
+		}
+	} else {
+		parse, err := m.parser.Parse(rule)
+		if err != nil {
+			return fmt.Errorf("error while parsing rule %s: %w", rule, err)
+		}
+
+		buildTree, ok := parse.(rules.TreeBuilder)
+		if !ok {
+			return fmt.Errorf("error while parsing rule %s", rule)
+		}
+
+		err = addRule(&matchers, buildTree())
+		if err != nil {
+			return err
+		}
+
+		// Default value, which means the user has not set it, so we'll compute it.
+		if priority == 0 {
+			priority = len(rule)
+		}
+	}
+
+	m.routes = append(m.routes, &route{
+		handler:  handler,
+		matchers: matchers,
+		priority: priority,
+	})
+
+	sort.Stable(routes(m.routes))
+
+	return nil
+}
+
+func addRule(tree *matchersTree, rule *rules.Tree) error {
+	switch rule.Matcher {
+	case "and", "or":
+		tree.operator = rule.Matcher
+		tree.left = &matchersTree{}
+		err := addRule(tree.left, rule.RuleLeft)
+		if err != nil {
+			return err
+		}
+
+		tree.right = &matchersTree{}
+		return addRule(tree.right, rule.RuleRight)
+	default:
+		err := rules.CheckRule(rule)
+		if err != nil {
+			return err
+		}
+
+		err = udpFuncs[rule.Matcher](tree, rule.Value...)
+		if err != nil {
+			return err
+		}
+
+		if rule.Not {
+			matcherFunc := tree.matcher
+			tree.matcher = func(remoteIP string) bool {
+				return !matcherFunc(remoteIP)
+			}
+		}
+	}
+
+	return nil
+}
+
+// HasRoutes returns whether the muxer has routes.
+func (m *Muxer) HasRoutes() bool {
+	return len(m.routes) > 0
+}
+
+// routes implements sort.Interface.
+type routes []*route
+
+// Len implements sort.Interface.
+func (r routes) Len() int { return len(r) }
+
+// Swap implements sort.Interface.
+func (r routes) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+
+// Less implements sort.Interface.
+func (r routes) Less(i, j int) bool { return r[i].priority > r[j].priority }
+
+// route holds the matchers to match UDP route,
+// and the handler that will serve the session.
+type route struct {
+	// matchers tree structure reflecting the rule.
+	matchers matchersTree
+	// handler responsible for handling the route.
+	handler udp.Handler
+	// priority is used to disambiguate between two (or more) rules that would
+	// all match for a given session.
+	// Computed from the matching rule length, if not user-set.
+	priority int
+}
+
+// matcher is a matcher func used to match the session client IP.
+type matcher func(remoteIP string) bool
+
+// matchersTree represents the matchers tree structure.
+type matchersTree struct {
+	// If matcher is not nil, it means that this matcherTree is a leaf of the tree.
+	// It is therefore mutually exclusive with left and right.
+	matcher matcher
+	// operator to combine the evaluation of left and right leaves.
+	operator string
+	// Mutually exclusive with matcher.
+	left  *matchersTree
+	right *matchersTree
+}
+
+func (m *matchersTree) match(remoteIP string) bool {
+	if m == nil {
+		// This should never happen as it should have been detected during parsing.
+		log.Warn().Msg("Rule matcher is nil")
+		return false
+	}
+
+	if m.matcher != nil {
+		return m.matcher(remoteIP)
+	}
+
+	switch m.operator {
+	case "or":
+		return m.left.match(remoteIP) || m.right.match(remoteIP)
+	case "and":
+		return m.left.match(remoteIP) && m.right.match(remoteIP)
+	default:
+		// This should never happen as it should have been detected during parsing.
+		log.Warn().Msgf("Invalid rule operator %s", m.operator)
+		return false
+	}
+}
+
+func clientIP(tree *matchersTree, clientIPs ...string) error {
+	checker, err := ip.NewChecker(clientIPs)
+	if err != nil {
+		return fmt.Errorf("could not initialize IP Checker for \"ClientIP\" matcher: %w", err)
+	}
+
+	tree.matcher = func(remoteIP string) bool {
+		if remoteIP == "" {
+			return false
+		}
+
+		ok, err := checker.Contains(remoteIP)
+		if err != nil {
+			log.Warn().Err(err).Msg("\"ClientIP\" matcher: could not match remote address")
+			return false
+		}
+		return ok
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package udp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/udp"
+)
+
+// namedHandler identifies the route matching a session.
+type namedHandler string
+
+func (n namedHandler) ServeUDP(*udp.Conn) {}
+
+func Test_addUDPRoute(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rule     string
+		remoteIP string
+		buildErr bool
+		matchErr bool
+	}{
+		{
+			desc: "Empty rule",
+		},
+		{
+			desc:     "Invalid rule",
+			rule:     "ClientIP(`10.0.0.1`",
+			buildErr: true,
+		},
+		{
+			desc:     "Unknown matcher",
+			rule:     "HostSNI(`foo.com`)",
+			buildErr: true,
+		},
+		{
+			desc:     "Empty ClientIP",
+			rule:     "ClientIP()",
+			buildErr: true,
+		},
+		{
+			desc:     "Malformed CIDR",
+			rule:     "ClientIP(`héhé`)",
+			buildErr: true,
+		},
+		{
+			desc:     "Matching IP",
+			rule:     "ClientIP(`10.0.0.1`)",
+			remoteIP: "10.0.0.1",
+		},
+		{
+			desc:     "Matching CIDR",
+			rule:     "ClientIP(`10.0.0.0/8`, `192.168.0.1`)",
+			remoteIP: "10.20.30.40",
+		},
+		{
+			desc:     "Not matching IP",
+			rule:     "ClientIP(`10.0.0.0/8`)",
+			remoteIP: "192.168.0.1",
+			matchErr: true,
+		},
+		{
+			desc:     "Not matching empty IP",
+			rule:     "ClientIP(`10.0.0.0/8`)",
+			matchErr: true,
+		},
+		{
+			desc:     "Negated ClientIP",
+			rule:     "!ClientIP(`10.0.0.0/8`)",
+			remoteIP: "192.168.0.1",
+		},
+		{
+			desc:     "Conjunction",
+			rule:     "ClientIP(`10.0.0.0/8`) && !ClientIP(`10.0.0.1`)",
+			remoteIP: "10.0.0.1",
+			matchErr: true,
+		},
+		{
+			desc:     "Disjunction",
+			rule:     "ClientIP(`10.0.0.0/8`) || ClientIP(`192.168.0.0/16`)",
+			remoteIP: "192.168.0.1",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, 0, namedHandler("foo"))
+			if test.buildErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			handler := muxer.Match(test.remoteIP)
+			if test.matchErr {
+				assert.Nil(t, handler)
+				return
+			}
+
+			assert.Equal(t, namedHandler("foo"), handler)
+		})
+	}
+}
+
+func Test_Priority(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rules    map[string]int
+		remoteIP string
+		expected string
+	}{
+		{
+			desc: "Longest rule first",
+			rules: map[string]int{
+				"ClientIP(`10.0.0.0/8`)":           0,
+				"ClientIP(`10.0.0.1`, `10.0.0.2`)": 0,
+			},
+			remoteIP: "10.0.0.1",
+			expected: "ClientIP(`10.0.0.1`, `10.0.0.2`)",
+		},
+		{
+			desc: "Highest priority first",
+			rules: map[string]int{
+				"ClientIP(`10.0.0.0/8`)":           10,
+				"ClientIP(`10.0.0.1`, `10.0.0.2`)": 1,
+			},
+			remoteIP: "10.0.0.1",
+			expected: "ClientIP(`10.0.0.0/8`)",
+		},
+		{
+			desc: "Empty rule is evaluated last",
+			rules: map[string]int{
+				"":                       0,
+				"ClientIP(`10.0.0.0/8`)": 0,
+			},
+			remoteIP: "10.0.0.1",
+			expected: "ClientIP(`10.0.0.0/8`)",
+		},
+		{
+			desc: "Empty rule as fallback",
+			rules: map[string]int{
+				"":                       0,
+				"ClientIP(`10.0.0.0/8`)": 0,
+			},
+			remoteIP: "192.168.0.1",
+			expected: "",
+		},
+		{
+			desc: "Empty rule with priority",
+			rules: map[string]int{
+				"":                       100,
+				"ClientIP(`10.0.0.0/8`)": 0,
+			},
+			remoteIP: "10.0.0.1",
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer()
+			require.NoError(t, err)
+
+			for rule, priority := range test.rules {
+				err := muxer.AddRoute(rule, priority, namedHandler(rule))
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, namedHandler(test.expected), muxer.Match(test.remoteIP))
+		})
+	}
+}
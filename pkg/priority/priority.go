@@ -0,0 +1,210 @@
+package priority
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Selector selects the tier of services to which the traffic is sent, among tiers ordered by priority.
+//
+// The traffic is sent to the first tier with at least its minimum number of healthy services.
+// When no tier has enough healthy services, the traffic is sent to the first tier with a healthy service.
+// Failing over to a lower priority tier is immediate, whereas failing back to a higher priority tier
+// only happens once it has had enough healthy services for the failback delay,
+// so that the traffic does not flap between tiers.
+type Selector struct {
+	mu sync.RWMutex
+
+	tiers []*tier
+	// active is the index of the tier to which the traffic is sent, or -1 if no tier has a healthy service.
+	active        int
+	failbackDelay time.Duration
+	failbackTimer *time.Timer
+
+	// updaters is the list of hooks that are run (to update the parent(s) of the selector),
+	// whenever the selector status changes, i.e. when the first service becomes healthy,
+	// or the last service becomes unhealthy.
+	updaters []func(bool)
+
+	now func() time.Time
+}
+
+type tier struct {
+	minHealthy int
+	services   int
+	// healthy is a record of which services of the tier are healthy, keyed by name.
+	healthy map[string]struct{}
+	// eligibleSince is the time since when the tier has had its minimum number of healthy services.
+	// It is zero when the tier does not have enough healthy services.
+	eligibleSince time.Time
+}
+
+// eligible returns whether the tier has its minimum number of healthy services,
+// which is at most its number of services.
+func (t *tier) eligible() bool {
+	minHealthy := t.minHealthy
+	if minHealthy < 1 {
+		minHealthy = 1
+	}
+	if minHealthy > t.services {
+		minHealthy = t.services
+	}
+
+	return len(t.healthy) > 0 && len(t.healthy) >= minHealthy
+}
+
+// NewSelector creates a new Selector, failing back to a higher priority tier after the given delay.
+func NewSelector(failbackDelay time.Duration) *Selector {
+	return &Selector{
+		active:        -1,
+		failbackDelay: failbackDelay,
+		now:           time.Now,
+	}
+}
+
+// AddTier appends a tier of lower priority than the existing ones, and returns its index.
+// The tier gets the traffic only when at least minHealthy of its services are healthy.
+func (s *Selector) AddTier(minHealthy int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tiers = append(s.tiers, &tier{minHealthy: minHealthy, healthy: make(map[string]struct{})})
+
+	return len(s.tiers) - 1
+}
+
+// AddService adds a service to the given tier.
+// The service is considered healthy until SetStatus is called with its name.
+func (s *Selector) AddService(tierIndex int, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.tiers[tierIndex]
+	t.services++
+	t.healthy[name] = struct{}{}
+	if t.eligibleSince.IsZero() && t.eligible() {
+		t.eligibleSince = s.now()
+	}
+
+	s.selectTier(context.Background())
+}
+
+// SetStatus sets the status of the given service of the given tier.
+func (s *Selector) SetStatus(ctx context.Context, tierIndex int, name string, up bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upBefore := s.up()
+
+	t := s.tiers[tierIndex]
+	if up {
+		t.healthy[name] = struct{}{}
+	} else {
+		delete(t.healthy, name)
+	}
+
+	switch {
+	case !t.eligible():
+		t.eligibleSince = time.Time{}
+	case t.eligibleSince.IsZero():
+		t.eligibleSince = s.now()
+	}
+
+	s.selectTier(ctx)
+
+	upAfter := s.up()
+	if upBefore == upAfter {
+		return
+	}
+
+	status := "DOWN"
+	if upAfter {
+		status = "UP"
+	}
+
+	log.Ctx(ctx).Debug().Msgf("Propagating new %s status", status)
+	for _, fn := range s.updaters {
+		fn(upAfter)
+	}
+}
+
+// RegisterStatusUpdater adds fn to the list of hooks that are run when the status of the selector changes.
+// Not thread safe.
+func (s *Selector) RegisterStatusUpdater(fn func(up bool)) {
+	s.updaters = append(s.updaters, fn)
+}
+
+// Active returns the index of the tier to which the traffic is sent.
+// It returns false if no tier has a healthy service.
+func (s *Selector) Active() (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.active, s.active >= 0
+}
+
+// up returns whether any tier has a healthy service.
+// It must be called with the mutex held.
+func (s *Selector) up() bool {
+	for _, t := range s.tiers {
+		if len(t.healthy) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectTier selects the tier to which the traffic is sent,
+// and schedules a failback when a higher priority tier is waiting for the failback delay.
+// It must be called with the mutex held.
+func (s *Selector) selectTier(ctx context.Context) {
+	now := s.now()
+
+	selected := -1
+	var failbackIn time.Duration
+	for index, t := range s.tiers {
+		if !t.eligible() {
+			continue
+		}
+
+		if s.active >= 0 && index < s.active {
+			if wait := t.eligibleSince.Add(s.failbackDelay).Sub(now); wait > 0 {
+				if failbackIn == 0 || wait < failbackIn {
+					failbackIn = wait
+				}
+				continue
+			}
+		}
+
+		selected = index
+		break
+	}
+
+	if selected < 0 {
+		for index, t := range s.tiers {
+			if len(t.healthy) > 0 {
+				selected = index
+				break
+			}
+		}
+	}
+
+	if selected != s.active {
+		log.Ctx(ctx).Debug().Msgf("Switching traffic from tier %d to tier %d", s.active, selected)
+		s.active = selected
+	}
+
+	if failbackIn > 0 && s.failbackTimer == nil {
+		s.failbackTimer = time.AfterFunc(failbackIn, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			s.failbackTimer = nil
+			s.selectTier(ctx)
+		})
+	}
+}
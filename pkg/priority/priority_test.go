@@ -0,0 +1,171 @@
+package priority
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type status struct {
+	tier int
+	name string
+	up   bool
+}
+
+func TestSelector(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		minHealthy     []int
+		statuses       []status
+		expectedActive int
+		expectedOk     bool
+	}{
+		{
+			desc:           "all services healthy",
+			minHealthy:     []int{1, 1},
+			expectedActive: 0,
+			expectedOk:     true,
+		},
+		{
+			desc:           "first tier down",
+			minHealthy:     []int{1, 1},
+			statuses:       []status{{0, "a", false}, {0, "b", false}},
+			expectedActive: 1,
+			expectedOk:     true,
+		},
+		{
+			desc:           "first tier below its minimum",
+			minHealthy:     []int{2, 1},
+			statuses:       []status{{0, "a", false}},
+			expectedActive: 1,
+			expectedOk:     true,
+		},
+		{
+			desc:           "first tier above its minimum",
+			minHealthy:     []int{1, 1},
+			statuses:       []status{{0, "a", false}},
+			expectedActive: 0,
+			expectedOk:     true,
+		},
+		{
+			desc:           "minimum above the number of services",
+			minHealthy:     []int{5, 1},
+			expectedActive: 0,
+			expectedOk:     true,
+		},
+		{
+			desc:           "all tiers below their minimum",
+			minHealthy:     []int{2, 2},
+			statuses:       []status{{0, "a", false}, {1, "c", false}},
+			expectedActive: 0,
+			expectedOk:     true,
+		},
+		{
+			desc:           "first tier back up",
+			minHealthy:     []int{1, 1},
+			statuses:       []status{{0, "a", false}, {0, "b", false}, {0, "a", true}},
+			expectedActive: 0,
+			expectedOk:     true,
+		},
+		{
+			desc:           "all services down",
+			minHealthy:     []int{1, 1},
+			statuses:       []status{{0, "a", false}, {0, "b", false}, {1, "c", false}, {1, "d", false}},
+			expectedActive: -1,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			selector := newSelector(0, test.minHealthy...)
+
+			for _, s := range test.statuses {
+				selector.SetStatus(context.Background(), s.tier, s.name, s.up)
+			}
+
+			active, ok := selector.Active()
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedActive, active)
+		})
+	}
+}
+
+func TestSelector_failbackDelay(t *testing.T) {
+	selector := newSelector(100*time.Millisecond, 1, 1, 1)
+
+	selector.SetStatus(context.Background(), 0, "a", false)
+	selector.SetStatus(context.Background(), 0, "b", false)
+	selector.SetStatus(context.Background(), 1, "c", false)
+	selector.SetStatus(context.Background(), 1, "d", false)
+
+	active, _ := selector.Active()
+	assert.Equal(t, 2, active)
+
+	selector.SetStatus(context.Background(), 1, "c", true)
+
+	active, _ = selector.Active()
+	assert.Equal(t, 2, active)
+
+	// Flapping resets the failback delay.
+	time.Sleep(50 * time.Millisecond)
+	selector.SetStatus(context.Background(), 1, "c", false)
+	selector.SetStatus(context.Background(), 1, "c", true)
+	time.Sleep(60 * time.Millisecond)
+
+	active, _ = selector.Active()
+	assert.Equal(t, 2, active)
+
+	require.Eventually(t, func() bool {
+		active, _ = selector.Active()
+		return active == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Failing over is immediate.
+	selector.SetStatus(context.Background(), 1, "c", false)
+
+	active, _ = selector.Active()
+	assert.Equal(t, 2, active)
+}
+
+func TestSelector_statusUpdater(t *testing.T) {
+	selector := newSelector(0, 1, 1)
+
+	var statuses []bool
+	selector.RegisterStatusUpdater(func(up bool) {
+		statuses = append(statuses, up)
+	})
+
+	selector.SetStatus(context.Background(), 0, "a", false)
+	selector.SetStatus(context.Background(), 0, "b", false)
+	selector.SetStatus(context.Background(), 1, "c", false)
+	assert.Empty(t, statuses)
+
+	selector.SetStatus(context.Background(), 1, "d", false)
+	assert.Equal(t, []bool{false}, statuses)
+
+	selector.SetStatus(context.Background(), 1, "d", true)
+	assert.Equal(t, []bool{false, true}, statuses)
+}
+
+// newSelector creates a selector with a tier per given minimum, each with two services.
+// The services of the tiers are named in order: a and b, c and d, e and f.
+func newSelector(failbackDelay time.Duration, minHealthy ...int) *Selector {
+	selector := NewSelector(failbackDelay)
+
+	name := 'a'
+	for _, m := range minHealthy {
+		tier := selector.AddTier(m)
+		for i := 0; i < 2; i++ {
+			selector.AddService(tier, string(name))
+			name++
+		}
+	}
+
+	return selector
+}
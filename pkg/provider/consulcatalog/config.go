@@ -216,6 +216,7 @@ func (p *Provider) addServerTCP(item itemData, loadBalancer *dynamic.TCPServersL
 	}
 
 	loadBalancer.Servers[0].Address = net.JoinHostPort(item.Address, port)
+	setServerLocality(&loadBalancer.Servers[0].Zone, &loadBalancer.Servers[0].Region, item)
 
 	return nil
 }
@@ -285,10 +286,23 @@ func (p *Provider) addServer(item itemData, loadBalancer *dynamic.ServersLoadBal
 	}
 
 	loadBalancer.Servers[0].URL = fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(item.Address, port))
+	setServerLocality(&loadBalancer.Servers[0].Zone, &loadBalancer.Servers[0].Region, item)
 
 	return nil
 }
 
+// setServerLocality sets the zone and region of a server from the node of the item,
+// unless they are already set by the labels.
+func setServerLocality(zone, region *string, item itemData) {
+	if *zone == "" {
+		*zone = item.Zone
+	}
+
+	if *region == "" {
+		*region = item.Region
+	}
+}
+
 func itemServersTransportKey(item itemData) string {
 	return provider.Normalize("tls-" + item.Namespace + "-" + item.Datacenter + "-" + item.Name)
 }
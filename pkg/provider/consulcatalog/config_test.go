@@ -45,8 +45,9 @@ func TestDefaultRule(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -98,8 +99,9 @@ func TestDefaultRule(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -149,8 +151,9 @@ func TestDefaultRule(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:     map[string]*dynamic.Router{},
@@ -195,8 +198,9 @@ func TestDefaultRule(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:     map[string]*dynamic.Router{},
@@ -241,8 +245,9 @@ func TestDefaultRule(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -329,8 +334,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -359,6 +365,93 @@ func Test_buildConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "one container with a located node",
+			items: []itemData{
+				{
+					ID:      "Test",
+					Node:    "Node1",
+					Name:    "dev/Test",
+					Labels:  map[string]string{},
+					Address: "127.0.0.1",
+					Port:    "80",
+					Zone:    "eu-west-1a",
+					Region:  "eu-west-1",
+					Status:  api.HealthPassing,
+				},
+				{
+					ID:   "Test",
+					Node: "Node2",
+					Name: "dev/Test2",
+					Labels: map[string]string{
+						"traefik.http.services.Service1.loadbalancer.server.zone": "eu-west-1b",
+					},
+					Address: "127.0.0.2",
+					Port:    "80",
+					Zone:    "eu-west-1a",
+					Region:  "eu-west-1",
+					Status:  api.HealthPassing,
+				},
+			},
+			expected: &dynamic.Configuration{
+				TCP: &dynamic.TCPConfiguration{
+					Routers:     map[string]*dynamic.TCPRouter{},
+					Middlewares: map[string]*dynamic.TCPMiddleware{},
+					Services:    map[string]*dynamic.TCPService{},
+				},
+				UDP: &dynamic.UDPConfiguration{
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
+				},
+				HTTP: &dynamic.HTTPConfiguration{
+					Routers: map[string]*dynamic.Router{
+						"dev-Test": {
+							Service: "dev-Test",
+							Rule:    "Host(`dev-Test.traefik.wtf`)",
+						},
+						"dev-Test2": {
+							Service: "Service1",
+							Rule:    "Host(`dev-Test2.traefik.wtf`)",
+						},
+					},
+					Middlewares: map[string]*dynamic.Middleware{},
+					Services: map[string]*dynamic.Service{
+						"dev-Test": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL:    "http://127.0.0.1:80",
+										Zone:   "eu-west-1a",
+										Region: "eu-west-1",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+						"Service1": {
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Servers: []dynamic.Server{
+									{
+										URL:    "http://127.0.0.2:80",
+										Zone:   "eu-west-1b",
+										Region: "eu-west-1",
+									},
+								},
+								PassHostHeader: Bool(true),
+								ResponseForwarding: &dynamic.ResponseForwarding{
+									FlushInterval: ptypes.Duration(100 * time.Millisecond),
+								},
+							},
+						},
+					},
+					ServersTransports: map[string]*dynamic.ServersTransport{},
+				},
+			},
+		},
 		{
 			desc:         "one connect container",
 			ConnectAware: true,
@@ -385,8 +478,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Middlewares: map[string]*dynamic.TCPMiddleware{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -471,8 +565,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Middlewares: map[string]*dynamic.TCPMiddleware{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -549,8 +644,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -625,8 +721,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -687,8 +784,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -746,8 +844,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -800,8 +899,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -853,8 +953,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -904,8 +1005,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Middlewares: map[string]*dynamic.Middleware{},
@@ -956,8 +1058,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1009,8 +1112,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:     map[string]*dynamic.Router{},
@@ -1077,8 +1181,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1134,8 +1239,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1181,8 +1287,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1235,8 +1342,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1301,8 +1409,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1370,8 +1479,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1446,8 +1556,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1514,8 +1625,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:     map[string]*dynamic.Router{},
@@ -1585,8 +1697,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:     map[string]*dynamic.Router{},
@@ -1649,8 +1762,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1703,8 +1817,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1755,8 +1870,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -1807,8 +1923,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:     map[string]*dynamic.Router{},
@@ -1864,8 +1981,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -1895,8 +2013,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -1926,8 +2045,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -1957,8 +2077,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -1990,8 +2111,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -2023,8 +2145,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -2075,8 +2198,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -2161,8 +2285,9 @@ func Test_buildConfiguration(t *testing.T) {
 					},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -2211,8 +2336,9 @@ func Test_buildConfiguration(t *testing.T) {
 					},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -2255,6 +2381,7 @@ func Test_buildConfiguration(t *testing.T) {
 							},
 						},
 					},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				TCP: &dynamic.TCPConfiguration{
 					Routers:     map[string]*dynamic.TCPRouter{},
@@ -2301,8 +2428,9 @@ func Test_buildConfiguration(t *testing.T) {
 					},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -2354,8 +2482,9 @@ func Test_buildConfiguration(t *testing.T) {
 					},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -2399,6 +2528,7 @@ func Test_buildConfiguration(t *testing.T) {
 							},
 						},
 					},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				TCP: &dynamic.TCPConfiguration{
 					Routers:     map[string]*dynamic.TCPRouter{},
@@ -2470,8 +2600,9 @@ func Test_buildConfiguration(t *testing.T) {
 					},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -2553,6 +2684,7 @@ func Test_buildConfiguration(t *testing.T) {
 							},
 						},
 					},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				TCP: &dynamic.TCPConfiguration{
 					Routers:     map[string]*dynamic.TCPRouter{},
@@ -2621,8 +2753,9 @@ func Test_buildConfiguration(t *testing.T) {
 					},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -2660,6 +2793,7 @@ func Test_buildConfiguration(t *testing.T) {
 							},
 						},
 					},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				TCP: &dynamic.TCPConfiguration{
 					Routers:     map[string]*dynamic.TCPRouter{},
@@ -2707,8 +2841,9 @@ func Test_buildConfiguration(t *testing.T) {
 					},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -2757,8 +2892,9 @@ func Test_buildConfiguration(t *testing.T) {
 					Services:    map[string]*dynamic.TCPService{},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers: map[string]*dynamic.Router{
@@ -2886,8 +3022,9 @@ func Test_buildConfiguration(t *testing.T) {
 					},
 				},
 				UDP: &dynamic.UDPConfiguration{
-					Routers:  map[string]*dynamic.UDPRouter{},
-					Services: map[string]*dynamic.UDPService{},
+					Routers:     map[string]*dynamic.UDPRouter{},
+					Services:    map[string]*dynamic.UDPService{},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -2962,6 +3099,7 @@ func Test_buildConfiguration(t *testing.T) {
 							},
 						},
 					},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -3013,6 +3151,7 @@ func Test_buildConfiguration(t *testing.T) {
 							},
 						},
 					},
+					Middlewares: map[string]*dynamic.UDPMiddleware{},
 				},
 				HTTP: &dynamic.HTTPConfiguration{
 					Routers:           map[string]*dynamic.Router{},
@@ -29,6 +29,12 @@ const defaultTemplateRule = "Host(`{{ normalize .Name }}`)"
 // providerName is the Consul Catalog provider name.
 const providerName = "consulcatalog"
 
+// Node metadata keys holding the location of the nodes, for the locality-aware load-balancing.
+const (
+	nodeMetaZone   = "zone"
+	nodeMetaRegion = "region"
+)
+
 var _ provider.Provider = (*Provider)(nil)
 
 type itemData struct {
@@ -39,6 +45,8 @@ type itemData struct {
 	Namespace  string
 	Address    string
 	Port       string
+	Zone       string
+	Region     string
 	Status     string
 	Labels     map[string]string
 	Tags       []string
@@ -340,6 +348,8 @@ func (p *Provider) getConsulServicesData(ctx context.Context) ([]itemData, error
 				Name:       name,
 				Address:    address,
 				Port:       strconv.Itoa(consulService.Service.Port),
+				Zone:       consulService.Node.Meta[nodeMetaZone],
+				Region:     consulService.Node.Meta[nodeMetaRegion],
 				Labels:     tagsToNeutralLabels(consulService.Service.Tags, p.Prefix),
 				Tags:       consulService.Service.Tags,
 				Status:     status,
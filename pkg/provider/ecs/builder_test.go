@@ -48,6 +48,13 @@ func mPrivateIP(ip string) func(*machine) {
 	}
 }
 
+func mLocality(zone, region string) func(*machine) {
+	return func(m *machine) {
+		m.zone = zone
+		m.region = region
+	}
+}
+
 func mHealthStatus(status string) func(*machine) {
 	return func(m *machine) {
 		m.healthStatus = status
@@ -217,6 +217,7 @@ func (p *Provider) addServerTCP(instance ecsInstance, loadBalancer *dynamic.TCPS
 	}
 
 	loadBalancer.Servers[0].Address = net.JoinHostPort(ip, port)
+	setServerLocality(&loadBalancer.Servers[0].Zone, &loadBalancer.Servers[0].Region, instance)
 
 	return nil
 }
@@ -273,10 +274,27 @@ func (p *Provider) addServer(instance ecsInstance, loadBalancer *dynamic.Servers
 
 	loadBalancer.Servers[0].URL = fmt.Sprintf("%s://%s", loadBalancer.Servers[0].Scheme, net.JoinHostPort(ip, port))
 	loadBalancer.Servers[0].Scheme = ""
+	setServerLocality(&loadBalancer.Servers[0].Zone, &loadBalancer.Servers[0].Region, instance)
 
 	return nil
 }
 
+// setServerLocality sets the zone and region of a server from the task of the instance,
+// unless they are already set by the labels.
+func setServerLocality(zone, region *string, instance ecsInstance) {
+	if instance.machine == nil {
+		return
+	}
+
+	if *zone == "" {
+		*zone = instance.machine.zone
+	}
+
+	if *region == "" {
+		*region = instance.machine.region
+	}
+}
+
 func (p *Provider) getIPPort(instance ecsInstance, serverPort string) (string, string, error) {
 	var ip, port string
 
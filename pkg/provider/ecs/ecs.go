@@ -64,6 +64,9 @@ type machine struct {
 	privateIP    string
 	ports        []portMapping
 	healthStatus string
+	// zone and region are the location of the task, for the locality-aware load-balancing.
+	zone   string
+	region string
 }
 
 type awsClient struct {
@@ -373,6 +376,9 @@ func (p *Provider) listInstances(ctx context.Context, client *awsClient) ([]ecsI
 					}
 				}
 
+				mach.zone = aws.StringValue(task.AvailabilityZone)
+				mach.region = p.Region
+
 				instance := ecsInstance{
 					Name:                fmt.Sprintf("%s-%s", strings.Replace(aws.StringValue(task.Group), ":", "-", 1), *container.Name),
 					ID:                  key[len(key)-12:],
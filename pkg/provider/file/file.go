@@ -304,8 +304,9 @@ func (p *Provider) loadFileConfigFromDirectory(ctx context.Context, directory st
 				Options: make(map[string]tls.Options),
 			},
 			UDP: &dynamic.UDPConfiguration{
-				Routers:  make(map[string]*dynamic.UDPRouter),
-				Services: make(map[string]*dynamic.UDPService),
+				Routers:     make(map[string]*dynamic.UDPRouter),
+				Services:    make(map[string]*dynamic.UDPService),
+				Middlewares: make(map[string]*dynamic.UDPMiddleware),
 			},
 		}
 	}
@@ -408,6 +409,14 @@ func (p *Provider) loadFileConfigFromDirectory(ctx context.Context, directory st
 			}
 		}
 
+		for name, conf := range c.UDP.Middlewares {
+			if _, exists := configuration.UDP.Middlewares[name]; exists {
+				logger.Warn().Str(logs.MiddlewareName, name).Msg("UDP middleware already configured, skipping")
+			} else {
+				configuration.UDP.Middlewares[name] = conf
+			}
+		}
+
 		for _, conf := range c.TLS.Certificates {
 			if _, exists := configTLSMaps[conf]; exists {
 				logger.Warn().Msgf("TLS configuration %v already configured, skipping", conf)
@@ -515,8 +524,9 @@ func (p *Provider) decodeConfiguration(filePath, content string) (*dynamic.Confi
 			Options: make(map[string]tls.Options),
 		},
 		UDP: &dynamic.UDPConfiguration{
-			Routers:  make(map[string]*dynamic.UDPRouter),
-			Services: make(map[string]*dynamic.UDPService),
+			Routers:     make(map[string]*dynamic.UDPRouter),
+			Services:    make(map[string]*dynamic.UDPService),
+			Middlewares: make(map[string]*dynamic.UDPMiddleware),
 		},
 	}
 
@@ -36,6 +36,7 @@ type Client interface {
 	GetIngressRouteUDPs() []*v1alpha1.IngressRouteUDP
 	GetMiddlewares() []*v1alpha1.Middleware
 	GetMiddlewareTCPs() []*v1alpha1.MiddlewareTCP
+	GetMiddlewareUDPs() []*v1alpha1.MiddlewareUDP
 	GetTraefikService(namespace, name string) (*v1alpha1.TraefikService, bool, error)
 	GetTraefikServices() []*v1alpha1.TraefikService
 	GetTLSOptions() []*v1alpha1.TLSOption
@@ -44,6 +45,7 @@ type Client interface {
 	GetService(namespace, name string) (*corev1.Service, bool, error)
 	GetSecret(namespace, name string) (*corev1.Secret, bool, error)
 	GetEndpoints(namespace, name string) (*corev1.Endpoints, bool, error)
+	GetNode(name string) (*corev1.Node, bool, error)
 }
 
 // TODO: add tests for the clientWrapper (and its methods) itself.
@@ -54,8 +56,11 @@ type clientWrapper struct {
 	factoriesCrd    map[string]externalversions.SharedInformerFactory
 	factoriesKube   map[string]informers.SharedInformerFactory
 	factoriesSecret map[string]informers.SharedInformerFactory
+	clusterFactory  informers.SharedInformerFactory
 
 	labelSelector string
+	// watchNodes enables the watch of the nodes, for the topology of the servers.
+	watchNodes bool
 
 	isNamespaceAll    bool
 	watchedNamespaces []string
@@ -166,6 +171,7 @@ func (c *clientWrapper) WatchAll(namespaces []string, stopCh <-chan struct{}) (<
 		factoryCrd.Traefik().V1alpha1().IngressRoutes().Informer().AddEventHandler(eventHandler)
 		factoryCrd.Traefik().V1alpha1().Middlewares().Informer().AddEventHandler(eventHandler)
 		factoryCrd.Traefik().V1alpha1().MiddlewareTCPs().Informer().AddEventHandler(eventHandler)
+		factoryCrd.Traefik().V1alpha1().MiddlewareUDPs().Informer().AddEventHandler(eventHandler)
 		factoryCrd.Traefik().V1alpha1().IngressRouteTCPs().Informer().AddEventHandler(eventHandler)
 		factoryCrd.Traefik().V1alpha1().IngressRouteUDPs().Informer().AddEventHandler(eventHandler)
 		factoryCrd.Traefik().V1alpha1().TLSOptions().Informer().AddEventHandler(eventHandler)
@@ -211,6 +217,19 @@ func (c *clientWrapper) WatchAll(namespaces []string, stopCh <-chan struct{}) (<
 		}
 	}
 
+	if c.watchNodes {
+		c.clusterFactory = informers.NewSharedInformerFactoryWithOptions(c.csKube, resyncPeriod)
+		c.clusterFactory.Core().V1().Nodes().Informer().AddEventHandler(eventHandler)
+
+		c.clusterFactory.Start(stopCh)
+
+		for t, ok := range c.clusterFactory.WaitForCacheSync(stopCh) {
+			if !ok {
+				return nil, fmt.Errorf("timed out waiting for controller caches to sync %s", t.String())
+			}
+		}
+	}
+
 	return eventCh, nil
 }
 
@@ -284,6 +303,20 @@ func (c *clientWrapper) GetMiddlewareTCPs() []*v1alpha1.MiddlewareTCP {
 	return result
 }
 
+func (c *clientWrapper) GetMiddlewareUDPs() []*v1alpha1.MiddlewareUDP {
+	var result []*v1alpha1.MiddlewareUDP
+
+	for ns, factory := range c.factoriesCrd {
+		middlewares, err := factory.Traefik().V1alpha1().MiddlewareUDPs().Lister().List(labels.Everything())
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to list UDP middlewares in namespace %s", ns)
+		}
+		result = append(result, middlewares...)
+	}
+
+	return result
+}
+
 // GetTraefikService returns the named service from the given namespace.
 func (c *clientWrapper) GetTraefikService(namespace, name string) (*v1alpha1.TraefikService, bool, error) {
 	if !c.isWatchedNamespace(namespace) {
@@ -388,6 +421,17 @@ func (c *clientWrapper) GetSecret(namespace, name string) (*corev1.Secret, bool,
 	return secret, exist, err
 }
 
+// GetNode returns the named node.
+func (c *clientWrapper) GetNode(name string) (*corev1.Node, bool, error) {
+	if c.clusterFactory == nil {
+		return nil, false, errors.New("nodes are not watched")
+	}
+
+	node, err := c.clusterFactory.Core().V1().Nodes().Lister().Get(name)
+	exist, err := translateNotFoundError(err)
+	return node, exist, err
+}
+
 // lookupNamespace returns the lookup namespace key for the given namespace.
 // When listening on all namespaces, it returns the client-go identifier ("")
 // for all-namespaces. Otherwise, it returns the given namespace.
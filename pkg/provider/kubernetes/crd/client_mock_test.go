@@ -25,6 +25,7 @@ type clientMock struct {
 	services  []*corev1.Service
 	secrets   []*corev1.Secret
 	endpoints []*corev1.Endpoints
+	nodes     []*corev1.Node
 
 	apiServiceError   error
 	apiSecretError    error
@@ -35,6 +36,7 @@ type clientMock struct {
 	ingressRouteUDPs []*v1alpha1.IngressRouteUDP
 	middlewares      []*v1alpha1.Middleware
 	middlewareTCPs   []*v1alpha1.MiddlewareTCP
+	middlewareUDPs   []*v1alpha1.MiddlewareUDP
 	tlsOptions       []*v1alpha1.TLSOption
 	tlsStores        []*v1alpha1.TLSStore
 	traefikServices  []*v1alpha1.TraefikService
@@ -59,6 +61,8 @@ func newClientMock(paths ...string) clientMock {
 				c.services = append(c.services, o)
 			case *corev1.Endpoints:
 				c.endpoints = append(c.endpoints, o)
+			case *corev1.Node:
+				c.nodes = append(c.nodes, o)
 			case *v1alpha1.IngressRoute:
 				c.ingressRoutes = append(c.ingressRoutes, o)
 			case *v1alpha1.IngressRouteTCP:
@@ -69,6 +73,8 @@ func newClientMock(paths ...string) clientMock {
 				c.middlewares = append(c.middlewares, o)
 			case *v1alpha1.MiddlewareTCP:
 				c.middlewareTCPs = append(c.middlewareTCPs, o)
+			case *v1alpha1.MiddlewareUDP:
+				c.middlewareUDPs = append(c.middlewareUDPs, o)
 			case *v1alpha1.TraefikService:
 				c.traefikServices = append(c.traefikServices, o)
 			case *v1alpha1.TLSOption:
@@ -108,6 +114,10 @@ func (c clientMock) GetMiddlewareTCPs() []*v1alpha1.MiddlewareTCP {
 	return c.middlewareTCPs
 }
 
+func (c clientMock) GetMiddlewareUDPs() []*v1alpha1.MiddlewareUDP {
+	return c.middlewareUDPs
+}
+
 func (c clientMock) GetTraefikService(namespace, name string) (*v1alpha1.TraefikService, bool, error) {
 	for _, svc := range c.traefikServices {
 		if svc.Namespace == namespace && svc.Name == name {
@@ -171,6 +181,16 @@ func (c clientMock) GetEndpoints(namespace, name string) (*corev1.Endpoints, boo
 	return &corev1.Endpoints{}, false, nil
 }
 
+func (c clientMock) GetNode(name string) (*corev1.Node, bool, error) {
+	for _, node := range c.nodes {
+		if node.Name == name {
+			return node, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
 func (c clientMock) GetSecret(namespace, name string) (*corev1.Secret, bool, error) {
 	if c.apiSecretError != nil {
 		return nil, false, c.apiSecretError
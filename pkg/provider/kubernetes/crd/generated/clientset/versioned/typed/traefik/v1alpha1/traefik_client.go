@@ -39,6 +39,7 @@ type TraefikV1alpha1Interface interface {
 	IngressRouteUDPsGetter
 	MiddlewaresGetter
 	MiddlewareTCPsGetter
+	MiddlewareUDPsGetter
 	ServersTransportsGetter
 	TLSOptionsGetter
 	TLSStoresGetter
@@ -70,6 +71,10 @@ func (c *TraefikV1alpha1Client) MiddlewareTCPs(namespace string) MiddlewareTCPIn
 	return newMiddlewareTCPs(c, namespace)
 }
 
+func (c *TraefikV1alpha1Client) MiddlewareUDPs(namespace string) MiddlewareUDPInterface {
+	return newMiddlewareUDPs(c, namespace)
+}
+
 func (c *TraefikV1alpha1Client) ServersTransports(namespace string) ServersTransportInterface {
 	return newServersTransports(c, namespace)
 }
@@ -55,6 +55,7 @@ type Provider struct {
 	IngressClass              string          `description:"Value of kubernetes.io/ingress.class annotation to watch for." json:"ingressClass,omitempty" toml:"ingressClass,omitempty" yaml:"ingressClass,omitempty" export:"true"`
 	ThrottleDuration          ptypes.Duration `description:"Ingress refresh throttle duration" json:"throttleDuration,omitempty" toml:"throttleDuration,omitempty" yaml:"throttleDuration,omitempty" export:"true"`
 	AllowEmptyServices        bool            `description:"Allow the creation of services without endpoints." json:"allowEmptyServices,omitempty" toml:"allowEmptyServices,omitempty" yaml:"allowEmptyServices,omitempty" export:"true"`
+	NodeTopology              bool            `description:"Set the zone and region of the servers from the topology labels of their nodes (requires the permission to list and watch nodes)." json:"nodeTopology,omitempty" toml:"nodeTopology,omitempty" yaml:"nodeTopology,omitempty" export:"true"`
 	lastConfiguration         safe.Safe
 }
 
@@ -88,6 +89,7 @@ func (p *Provider) newK8sClient(ctx context.Context) (*clientWrapper, error) {
 	}
 
 	client.labelSelector = p.LabelSelector
+	client.watchNodes = p.NodeTopology
 	return client, nil
 }
 
@@ -240,7 +242,7 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 			continue
 		}
 
-		rateLimit, err := createRateLimitMiddleware(middleware.Spec.RateLimit)
+		rateLimit, err := createRateLimitMiddleware(client, middleware.Namespace, middleware.Spec.RateLimit)
 		if err != nil {
 			logger.Error().Err(err).Msg("Error while reading rateLimit middleware")
 			continue
@@ -295,11 +297,20 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 		}
 	}
 
+	for _, middlewareUDP := range client.GetMiddlewareUDPs() {
+		id := provider.Normalize(makeID(middlewareUDP.Namespace, middlewareUDP.Name))
+		conf.UDP.Middlewares[id] = &dynamic.UDPMiddleware{
+			IPAllowList: middlewareUDP.Spec.IPAllowList,
+			RateLimit:   middlewareUDP.Spec.RateLimit,
+		}
+	}
+
 	cb := configBuilder{
 		client:                    client,
 		allowCrossNamespace:       p.AllowCrossNamespace,
 		allowExternalNameServices: p.AllowExternalNameServices,
 		allowEmptyServices:        p.AllowEmptyServices,
+		nodeTopology:              p.NodeTopology,
 	}
 
 	for _, service := range client.GetTraefikServices() {
@@ -428,6 +439,25 @@ func getServicePort(svc *corev1.Service, port intstr.IntOrString) (*corev1.Servi
 	return &corev1.ServicePort{Port: port.IntVal}, nil
 }
 
+// getNodeTopology returns the zone and region of the given node, from its well-known topology labels.
+func getNodeTopology(client Client, nodeName *string) (string, string) {
+	if nodeName == nil {
+		return "", ""
+	}
+
+	node, exists, err := client.GetNode(*nodeName)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Cannot get the topology of the node %s", *nodeName)
+		return "", ""
+	}
+
+	if !exists {
+		return "", ""
+	}
+
+	return node.Labels[corev1.LabelTopologyZone], node.Labels[corev1.LabelTopologyRegion]
+}
+
 func createPluginMiddleware(k8sClient Client, ns string, plugins map[string]apiextensionv1.JSON) (map[string]dynamic.PluginConf, error) {
 	if plugins == nil {
 		return nil, nil
@@ -536,7 +566,7 @@ func createCircuitBreakerMiddleware(circuitBreaker *v1alpha1.CircuitBreaker) (*d
 	return cb, nil
 }
 
-func createRateLimitMiddleware(rateLimit *v1alpha1.RateLimit) (*dynamic.RateLimit, error) {
+func createRateLimitMiddleware(k8sClient Client, namespace string, rateLimit *v1alpha1.RateLimit) (*dynamic.RateLimit, error) {
 	if rateLimit == nil {
 		return nil, nil
 	}
@@ -559,15 +589,120 @@ func createRateLimitMiddleware(rateLimit *v1alpha1.RateLimit) (*dynamic.RateLimi
 		rl.SourceCriterion = rateLimit.SourceCriterion
 	}
 
+	if rateLimit.Redis != nil {
+		redis, err := createRateLimitRedis(k8sClient, namespace, rateLimit.Redis)
+		if err != nil {
+			return nil, err
+		}
+		rl.Redis = redis
+	}
+
+	if len(rateLimit.Plans) > 0 {
+		rl.Plans = make(map[string]*dynamic.RateLimitPlan, len(rateLimit.Plans))
+	}
+
+	for name, plan := range rateLimit.Plans {
+		p := &dynamic.RateLimitPlan{Sources: plan.Sources, Average: plan.Average}
+		p.SetDefaults()
+
+		if plan.Burst != nil {
+			p.Burst = *plan.Burst
+		}
+
+		if plan.Period != nil {
+			err := p.Period.Set(plan.Period.String())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rl.Plans[name] = p
+	}
+
 	return rl, nil
 }
 
+func createRateLimitRedis(k8sClient Client, namespace string, redis *v1alpha1.RateLimitRedis) (*dynamic.RateLimitRedis, error) {
+	r := &dynamic.RateLimitRedis{
+		Endpoints: redis.Endpoints,
+		DB:        redis.DB,
+	}
+	r.SetDefaults()
+
+	if redis.Timeout != nil {
+		if err := r.Timeout.Set(redis.Timeout.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(redis.Secret) > 0 {
+		secret, ok, err := k8sClient.GetSecret(namespace, redis.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch secret '%s/%s': %w", namespace, redis.Secret, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("secret '%s/%s' not found", namespace, redis.Secret)
+		}
+		if secret == nil {
+			return nil, fmt.Errorf("data for secret '%s/%s' must not be nil", namespace, redis.Secret)
+		}
+
+		r.Username = string(secret.Data["username"])
+		r.Password = string(secret.Data["password"])
+	}
+
+	if redis.TLS == nil {
+		return r, nil
+	}
+
+	r.TLS = &types.ClientTLS{
+		CAOptional:         redis.TLS.CAOptional,
+		InsecureSkipVerify: redis.TLS.InsecureSkipVerify,
+	}
+
+	if len(redis.TLS.CASecret) > 0 {
+		caSecret, err := loadCASecret(namespace, redis.TLS.CASecret, k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load redis ca secret: %w", err)
+		}
+		r.TLS.CA = caSecret
+	}
+
+	if len(redis.TLS.CertSecret) > 0 {
+		cert, key, err := loadAuthTLSSecret(namespace, redis.TLS.CertSecret, k8sClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load redis secret: %w", err)
+		}
+		r.TLS.Cert = cert
+		r.TLS.Key = key
+	}
+
+	return r, nil
+}
+
 func createRetryMiddleware(retry *v1alpha1.Retry) (*dynamic.Retry, error) {
 	if retry == nil {
 		return nil, nil
 	}
 
-	r := &dynamic.Retry{Attempts: retry.Attempts}
+	r := &dynamic.Retry{
+		Attempts: retry.Attempts,
+		Status:   retry.Status,
+		Methods:  retry.Methods,
+	}
+
+	if retry.Budget != nil {
+		r.Budget = &dynamic.RetryBudget{}
+		r.Budget.SetDefaults()
+
+		if retry.Budget.Percent != nil {
+			r.Budget.Percent = *retry.Budget.Percent
+		}
+
+		if retry.Budget.MinRetriesPerSecond != nil {
+			r.Budget.MinRetriesPerSecond = *retry.Budget.MinRetriesPerSecond
+		}
+	}
 
 	err := r.InitialInterval.Set(retry.InitialInterval.String())
 	if err != nil {
@@ -592,6 +727,7 @@ func (p *Provider) createErrorPageMiddleware(client Client, namespace string, er
 		allowCrossNamespace:       p.AllowCrossNamespace,
 		allowExternalNameServices: p.AllowExternalNameServices,
 		allowEmptyServices:        p.AllowEmptyServices,
+		nodeTopology:              p.NodeTopology,
 	}
 
 	balancerServerHTTP, err := cb.buildServersLB(namespace, errorPage.Service.LoadBalancerSpec)
@@ -616,6 +752,23 @@ func createForwardAuthMiddleware(k8sClient Client, namespace string, auth *v1alp
 		AuthResponseHeaders:      auth.AuthResponseHeaders,
 		AuthResponseHeadersRegex: auth.AuthResponseHeadersRegex,
 		AuthRequestHeaders:       auth.AuthRequestHeaders,
+		ForwardBody:              auth.ForwardBody,
+		MaxBodySize:              auth.MaxBodySize,
+	}
+
+	if auth.Cache != nil {
+		forwardAuth.Cache = &dynamic.ForwardAuthCache{}
+		forwardAuth.Cache.SetDefaults()
+
+		if auth.Cache.TTL != nil {
+			if err := forwardAuth.Cache.TTL.Set(auth.Cache.TTL.String()); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(auth.Cache.KeyHeaders) > 0 {
+			forwardAuth.Cache.KeyHeaders = auth.Cache.KeyHeaders
+		}
 	}
 
 	if auth.TLS == nil {
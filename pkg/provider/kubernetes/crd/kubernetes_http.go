@@ -55,6 +55,7 @@ func (p *Provider) loadIngressRouteConfiguration(ctx context.Context, client Cli
 			allowCrossNamespace:       p.AllowCrossNamespace,
 			allowExternalNameServices: p.AllowExternalNameServices,
 			allowEmptyServices:        p.AllowEmptyServices,
+			nodeTopology:              p.NodeTopology,
 		}
 
 		for _, route := range ingressRoute.Spec.Routes {
@@ -199,6 +200,7 @@ type configBuilder struct {
 	allowCrossNamespace       bool
 	allowExternalNameServices bool
 	allowEmptyServices        bool
+	nodeTopology              bool
 }
 
 // buildTraefikService creates the configuration for the traefik service defined in tService,
@@ -316,6 +318,11 @@ func (c configBuilder) buildServersLB(namespace string, svc v1alpha1.LoadBalance
 	}
 
 	lb.Sticky = svc.Sticky
+	lb.Locality = svc.Locality
+
+	if svc.Strategy != roundRobinStrategy {
+		lb.Strategy = svc.Strategy
+	}
 
 	lb.ServersTransport, err = c.makeServersTransportKey(namespace, svc.ServersTransport)
 	if err != nil {
@@ -345,12 +352,10 @@ func (c *configBuilder) makeServersTransportKey(parentNamespace string, serversT
 }
 
 func (c configBuilder) loadServers(parentNamespace string, svc v1alpha1.LoadBalancerSpec) ([]dynamic.Server, error) {
-	strategy := svc.Strategy
-	if strategy == "" {
-		strategy = roundRobinStrategy
-	}
-	if strategy != roundRobinStrategy {
-		return nil, fmt.Errorf("load balancing strategy %s is not supported", strategy)
+	switch svc.Strategy {
+	case "", roundRobinStrategy, dynamic.BalancerStrategyLeastRequests, dynamic.BalancerStrategyPeakEWMA:
+	default:
+		return nil, fmt.Errorf("load balancing strategy %s is not supported", svc.Strategy)
 	}
 
 	namespace := namespaceOrFallback(svc, parentNamespace)
@@ -425,9 +430,15 @@ func (c configBuilder) loadServers(parentNamespace string, svc v1alpha1.LoadBala
 		for _, addr := range subset.Addresses {
 			hostPort := net.JoinHostPort(addr.IP, strconv.Itoa(int(port)))
 
-			servers = append(servers, dynamic.Server{
+			server := dynamic.Server{
 				URL: fmt.Sprintf("%s://%s", protocol, hostPort),
-			})
+			}
+
+			if c.nodeTopology {
+				server.Zone, server.Region = getNodeTopology(c.client, addr.NodeName)
+			}
+
+			servers = append(servers, server)
 		}
 	}
 
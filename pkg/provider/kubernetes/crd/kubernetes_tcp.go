@@ -206,6 +206,8 @@ func (p *Provider) createLoadBalancerServerTCP(client Client, parentNamespace st
 		tcpService.LoadBalancer.TerminationDelay = service.TerminationDelay
 	}
 
+	tcpService.LoadBalancer.Locality = service.Locality
+
 	return tcpService, nil
 }
 
@@ -261,9 +263,15 @@ func (p *Provider) loadTCPServers(client Client, namespace string, svc v1alpha1.
 			}
 
 			for _, addr := range subset.Addresses {
-				servers = append(servers, dynamic.TCPServer{
+				server := dynamic.TCPServer{
 					Address: net.JoinHostPort(addr.IP, strconv.Itoa(int(port))),
-				})
+				}
+
+				if p.NodeTopology {
+					server.Zone, server.Region = getNodeTopology(client, addr.NodeName)
+				}
+
+				servers = append(servers, server)
 			}
 		}
 	}
@@ -104,7 +104,7 @@ type LoadBalancerSpec struct {
 	// It defaults to https when Kubernetes Service port is 443, http otherwise.
 	Scheme string `json:"scheme,omitempty"`
 	// Strategy defines the load balancing strategy between the servers.
-	// RoundRobin is the only supported value at the moment.
+	// Supported values are RoundRobin (default), LeastRequests, and PeakEWMA.
 	Strategy string `json:"strategy,omitempty"`
 	// PassHostHeader defines whether the client Host header is forwarded to the upstream Kubernetes Service.
 	// By default, passHostHeader is true.
@@ -115,6 +115,10 @@ type LoadBalancerSpec struct {
 	// It allows to configure the transport between Traefik and your servers.
 	// Can only be used on a Kubernetes Service.
 	ServersTransport string `json:"serversTransport,omitempty"`
+	// Locality defines the locality-aware load-balancing configuration.
+	// It requires the location of Traefik, and the nodeTopology option of the provider.
+	// More info: https://doc.traefik.io/traefik/v2.9/routing/services/#locality-aware-load-balancing
+	Locality *dynamic.Locality `json:"locality,omitempty"`
 
 	// Weight defines the weight and should only be specified when Name references a TraefikService object
 	// (and to be precise, one that embeds a Weighted Round Robin).
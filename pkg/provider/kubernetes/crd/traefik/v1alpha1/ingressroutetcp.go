@@ -78,6 +78,10 @@ type ServiceTCP struct {
 	// ProxyProtocol defines the PROXY protocol configuration.
 	// More info: https://doc.traefik.io/traefik/v2.9/routing/services/#proxy-protocol
 	ProxyProtocol *dynamic.ProxyProtocol `json:"proxyProtocol,omitempty"`
+	// Locality defines the locality-aware load-balancing configuration.
+	// It requires the location of Traefik, and the nodeTopology option of the provider.
+	// More info: https://doc.traefik.io/traefik/v2.9/routing/services/#locality-aware-load-balancing-1
+	Locality *dynamic.Locality `json:"locality,omitempty"`
 }
 
 // +genclient
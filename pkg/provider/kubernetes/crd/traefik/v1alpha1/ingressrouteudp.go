@@ -18,8 +18,17 @@ type IngressRouteUDPSpec struct {
 
 // RouteUDP holds the UDP route configuration.
 type RouteUDP struct {
+	// Match defines the router's rule.
+	// When empty, the route matches all the sessions.
+	// More info: https://doc.traefik.io/traefik/v2.9/routing/routers/#rule_2
+	Match string `json:"match,omitempty"`
+	// Priority defines the router's priority.
+	// More info: https://doc.traefik.io/traefik/v2.9/routing/routers/#priority_2
+	Priority int `json:"priority,omitempty"`
 	// Services defines the list of UDP services.
 	Services []ServiceUDP `json:"services,omitempty"`
+	// Middlewares defines the list of references to MiddlewareUDP resources.
+	Middlewares []ObjectReference `json:"middlewares,omitempty"`
 }
 
 // ServiceUDP defines an upstream UDP service to proxy traffic to.
@@ -155,6 +155,25 @@ type ForwardAuth struct {
 	AuthRequestHeaders []string `json:"authRequestHeaders,omitempty"`
 	// TLS defines the configuration used to secure the connection to the authentication server.
 	TLS *ClientTLS `json:"tls,omitempty"`
+	// ForwardBody defines whether to send the request body to the authentication server.
+	ForwardBody bool `json:"forwardBody,omitempty"`
+	// MaxBodySize defines the maximum size in bytes of the request body forwarded to the authentication server.
+	// The requests with a larger body are refused. Default: 1048576 (1MiB).
+	MaxBodySize int64 `json:"maxBodySize,omitempty"`
+	// Cache defines the caching of the decisions of the authentication server.
+	Cache *ForwardAuthCache `json:"cache,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ForwardAuthCache holds the forward auth cache configuration.
+type ForwardAuthCache struct {
+	// TTL defines how long the decisions of the authentication server are cached.
+	// Default: 5s.
+	TTL *intstr.IntOrString `json:"ttl,omitempty"`
+	// KeyHeaders defines the request headers whose values identify a decision in the cache.
+	// Default: Authorization.
+	KeyHeaders []string `json:"keyHeaders,omitempty"`
 }
 
 // ClientTLS holds the client TLS configuration.
@@ -191,13 +210,56 @@ type RateLimit struct {
 	// If several strategies are defined at the same time, an error will be raised.
 	// If none are set, the default is to use the request's remote address field (as an ipStrategy).
 	SourceCriterion *dynamic.SourceCriterion `json:"sourceCriterion,omitempty"`
+	// Redis defines the Redis store in which the requests are counted,
+	// so that the rate is limited across all the Traefik instances sharing the store.
+	Redis *RateLimitRedis `json:"redis,omitempty"`
+	// Plans defines quota tiers, by name.
+	// The sources of a plan are limited with the rate of the plan,
+	// and the other sources with the rate of the middleware, i.e. the default plan.
+	Plans map[string]RateLimitPlan `json:"plans,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RateLimitRedis holds the configuration of the Redis store of the rate limiter.
+type RateLimitRedis struct {
+	// Endpoints defines the addresses of the Redis servers, or of the nodes of the Redis cluster.
+	Endpoints []string `json:"endpoints,omitempty"`
+	// TLS defines the configuration used to secure the connections to the Redis servers.
+	TLS *ClientTLS `json:"tls,omitempty"`
+	// Secret is the name of the referenced Kubernetes Secret containing the credentials used to authenticate to the Redis servers.
+	// The credentials are extracted from the keys `username` and `password`.
+	Secret string `json:"secret,omitempty"`
+	// DB defines the Redis database in which the requests are counted.
+	DB int `json:"db,omitempty"`
+	// Timeout defines the maximum duration of an operation on the Redis servers,
+	// after which the rate is limited locally until the store is available again.
+	// Default: 500ms.
+	Timeout *intstr.IntOrString `json:"timeout,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RateLimitPlan holds the rate limit of a quota tier.
+type RateLimitPlan struct {
+	// Sources defines the sources, as selected by the source criterion of the middleware, which are limited with this plan.
+	Sources []string `json:"sources,omitempty"`
+	// Average is the maximum rate, by default in requests/s, allowed for the sources of the plan.
+	// It defaults to 0, which means no rate limiting.
+	Average int64 `json:"average,omitempty"`
+	// Period, in combination with Average, defines the actual maximum rate, such as:
+	// r = Average / Period. It defaults to a second.
+	Period *intstr.IntOrString `json:"period,omitempty"`
+	// Burst is the maximum number of requests allowed to arrive in the same arbitrarily small period of time.
+	// It defaults to 1.
+	Burst *int64 `json:"burst,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
 
 // Retry holds the retry middleware configuration.
-// This middleware reissues requests a given number of times to a backend server if that server does not reply.
-// As soon as the server answers, the middleware stops retrying, regardless of the response status.
+// This middleware reissues requests a given number of times to a backend server if that server does not reply,
+// or if it answers with one of the configured status codes.
 // More info: https://doc.traefik.io/traefik/v2.9/middlewares/http/retry/
 type Retry struct {
 	// Attempts defines how many times the request should be retried.
@@ -208,6 +270,28 @@ type Retry struct {
 	// The value of initialInterval should be provided in seconds or as a valid duration format,
 	// see https://pkg.go.dev/time#ParseDuration.
 	InitialInterval intstr.IntOrString `json:"initialInterval,omitempty"`
+	// Status defines which status or range of statuses of the responses should result in a retry.
+	// It can be defined as a single number (503), as multiple comma-separated numbers (502,503),
+	// as ranges by separating two codes with a dash (502-504), or a combination of the two.
+	Status []string `json:"status,omitempty"`
+	// Methods defines the methods of the requests which are retried on status.
+	// Defaults to the idempotent methods: GET, HEAD, OPTIONS, TRACE, PUT and DELETE.
+	Methods []string `json:"methods,omitempty"`
+	// Budget limits the retries to a percentage of the requests, to prevent retry storms.
+	Budget *RetryBudget `json:"budget,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RetryBudget holds the retry budget configuration.
+// The budget is shared by all the routers using the retry middleware, and kept across the configuration reloads.
+type RetryBudget struct {
+	// Percent defines the maximum percentage of retries to requests, over the last 10 seconds.
+	// Default: 20.
+	Percent *int `json:"percent,omitempty"`
+	// MinRetriesPerSecond defines the number of retries per second which are always allowed, regardless of the percentage.
+	// Default: 10.
+	MinRetriesPerSecond *int `json:"minRetriesPerSecond,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
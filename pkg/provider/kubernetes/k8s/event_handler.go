@@ -51,9 +51,20 @@ func objChanged(oldObj, newObj interface{}) bool {
 		return endpointsChanged(oldObj.(*corev1.Endpoints), newObj.(*corev1.Endpoints))
 	}
 
+	if _, ok := oldObj.(*corev1.Node); ok {
+		return nodeTopologyChanged(oldObj.(*corev1.Node), newObj.(*corev1.Node))
+	}
+
 	return true
 }
 
+// nodeTopologyChanged reports whether the topology labels of the node changed,
+// as the frequent status updates of the nodes are irrelevant.
+func nodeTopologyChanged(a, b *corev1.Node) bool {
+	return a.Labels[corev1.LabelTopologyZone] != b.Labels[corev1.LabelTopologyZone] ||
+		a.Labels[corev1.LabelTopologyRegion] != b.Labels[corev1.LabelTopologyRegion]
+}
+
 func endpointsChanged(a, b *corev1.Endpoints) bool {
 	if len(a.Subsets) != len(b.Subsets) {
 		return true
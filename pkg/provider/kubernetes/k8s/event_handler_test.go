@@ -505,6 +505,38 @@ func Test_detectChanges(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "With node status change",
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					ResourceVersion: "1",
+					Labels:          map[string]string{corev1.LabelTopologyZone: "eu-west-1a"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					ResourceVersion: "2",
+					Labels:          map[string]string{corev1.LabelTopologyZone: "eu-west-1a"},
+				},
+				Status: corev1.NodeStatus{Phase: corev1.NodeRunning},
+			},
+		},
+		{
+			name: "With node zone change",
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					ResourceVersion: "1",
+					Labels:          map[string]string{corev1.LabelTopologyZone: "eu-west-1a"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					ResourceVersion: "2",
+					Labels:          map[string]string{corev1.LabelTopologyZone: "eu-west-1b"},
+				},
+			},
+			want: true,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -243,6 +243,15 @@ func Test_buildConfiguration(t *testing.T) {
 		"traefik/udp/routers/UDPRouter0/entrypoints/0":                                               "foobar",
 		"traefik/udp/routers/UDPRouter0/entrypoints/1":                                               "foobar",
 		"traefik/udp/routers/UDPRouter0/service":                                                     "foobar",
+		"traefik/udp/routers/UDPRouter0/rule":                                                        "foobar",
+		"traefik/udp/routers/UDPRouter0/priority":                                                    "42",
+		"traefik/udp/routers/UDPRouter0/middlewares/0":                                               "foobar",
+		"traefik/udp/routers/UDPRouter0/middlewares/1":                                               "foobar",
+		"traefik/udp/middlewares/UDPMiddleware01/ipAllowList/sourceRange/0":                          "foobar",
+		"traefik/udp/middlewares/UDPMiddleware01/ipAllowList/sourceRange/1":                          "foobar",
+		"traefik/udp/middlewares/UDPMiddleware02/rateLimit/average":                                  "42",
+		"traefik/udp/middlewares/UDPMiddleware02/rateLimit/burst":                                    "42",
+		"traefik/udp/middlewares/UDPMiddleware02/rateLimit/period":                                   "42s",
 		"traefik/udp/routers/UDPRouter1/entrypoints/0":                                               "foobar",
 		"traefik/udp/routers/UDPRouter1/entrypoints/1":                                               "foobar",
 		"traefik/udp/routers/UDPRouter1/service":                                                     "foobar",
@@ -797,13 +806,30 @@ func Test_buildConfiguration(t *testing.T) {
 			Routers: map[string]*dynamic.UDPRouter{
 				"UDPRouter0": {
 					EntryPoints: []string{"foobar", "foobar"},
+					Middlewares: []string{"foobar", "foobar"},
 					Service:     "foobar",
+					Rule:        "foobar",
+					Priority:    42,
 				},
 				"UDPRouter1": {
 					EntryPoints: []string{"foobar", "foobar"},
 					Service:     "foobar",
 				},
 			},
+			Middlewares: map[string]*dynamic.UDPMiddleware{
+				"UDPMiddleware01": {
+					IPAllowList: &dynamic.UDPIPAllowList{
+						SourceRange: []string{"foobar", "foobar"},
+					},
+				},
+				"UDPMiddleware02": {
+					RateLimit: &dynamic.UDPRateLimit{
+						Average: 42,
+						Burst:   42,
+						Period:  ptypes.Duration(42 * time.Second),
+					},
+				},
+			},
 			Services: map[string]*dynamic.UDPService{
 				"UDPService01": {
 					LoadBalancer: &dynamic.UDPServersLoadBalancer{
@@ -0,0 +1,228 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxDisjunctions is the maximum number of alternatives a rule can be expanded to
+// before giving up on its analysis, to avoid the exponential blow-up of the normal form.
+const maxDisjunctions = 32
+
+// setMatchers lists the matchers whose values are alternatives,
+// i.e. the matcher matches if any of its values matches.
+var setMatchers = map[string]bool{
+	"Host":          true,
+	"HostHeader":    true,
+	"HostRegexp":    true,
+	"Path":          true,
+	"PathPrefix":    true,
+	"Method":        true,
+	"ClientIP":      true,
+	"HostSNI":       true,
+	"HostSNIRegexp": true,
+	"ALPN":          true,
+}
+
+// caseInsensitiveMatchers lists the matchers whose values are compared case-insensitively.
+var caseInsensitiveMatchers = map[string]bool{
+	"Host":       true,
+	"HostHeader": true,
+	"HostSNI":    true,
+	"Method":     true,
+}
+
+// Canonical returns a normalized representation of the tree,
+// such that two rules matching the same requests by construction have the same representation,
+// e.g. regardless of the order of the operands of the logical operators.
+func (tree *Tree) Canonical() string {
+	switch tree.Matcher {
+	case and, or:
+		var operands []string
+		for _, t := range tree.flatten(tree.Matcher) {
+			operands = append(operands, t.Canonical())
+		}
+		sort.Strings(operands)
+
+		sep := " && "
+		if tree.Matcher == or {
+			sep = " || "
+		}
+
+		return "(" + strings.Join(operands, sep) + ")"
+	default:
+		var b strings.Builder
+		if tree.Not {
+			b.WriteString("!")
+		}
+
+		b.WriteString(matcherName(tree.Matcher))
+		b.WriteString("(")
+		b.WriteString(strings.Join(normalizeValues(tree), ", "))
+		b.WriteString(")")
+
+		return b.String()
+	}
+}
+
+// Conjunctions returns the rule as a disjunction of conjunctions of matchers (its disjunctive normal form),
+// or nil if the rule is too complex to be expanded.
+func (tree *Tree) Conjunctions() [][]*Tree {
+	switch tree.Matcher {
+	case or:
+		left := tree.RuleLeft.Conjunctions()
+		right := tree.RuleRight.Conjunctions()
+		if left == nil || right == nil || len(left)+len(right) > maxDisjunctions {
+			return nil
+		}
+
+		return append(left, right...)
+	case and:
+		left := tree.RuleLeft.Conjunctions()
+		right := tree.RuleRight.Conjunctions()
+		if left == nil || right == nil || len(left)*len(right) > maxDisjunctions {
+			return nil
+		}
+
+		var result [][]*Tree
+		for _, l := range left {
+			for _, r := range right {
+				conj := make([]*Tree, 0, len(l)+len(r))
+				conj = append(conj, l...)
+				conj = append(conj, r...)
+				result = append(result, conj)
+			}
+		}
+
+		return result
+	default:
+		return [][]*Tree{{tree}}
+	}
+}
+
+// ConjunctionCovers reports whether all the requests matched by the conjunction b are also matched by the conjunction a.
+// The analysis is conservative: false is returned when it cannot be proven.
+func ConjunctionCovers(a, b []*Tree) bool {
+	for _, atomA := range a {
+		var implied bool
+		for _, atomB := range b {
+			if implies(atomB, atomA) {
+				implied = true
+				break
+			}
+		}
+
+		if !implied {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (tree *Tree) flatten(operator string) []*Tree {
+	if tree.Matcher != operator {
+		return []*Tree{tree}
+	}
+
+	return append(tree.RuleLeft.flatten(operator), tree.RuleRight.flatten(operator)...)
+}
+
+// implies reports whether any request matching the matcher b also matches the matcher a.
+func implies(b, a *Tree) bool {
+	if a.Not || b.Not {
+		return a.Not == b.Not && a.Canonical() == b.Canonical()
+	}
+
+	nameA, nameB := matcherName(a.Matcher), matcherName(b.Matcher)
+
+	if nameA == "PathPrefix" && (nameB == "Path" || nameB == "PathPrefix") {
+		return hasPrefixes(b.Value, a.Value)
+	}
+
+	if nameA != nameB {
+		return false
+	}
+
+	valuesA, valuesB := normalizeValues(a), normalizeValues(b)
+
+	if !setMatchers[nameA] {
+		return strings.Join(valuesA, "\x00") == strings.Join(valuesB, "\x00")
+	}
+
+	if nameA == "HostSNI" && contains(valuesA, "*") {
+		return true
+	}
+
+	for _, v := range valuesB {
+		if !contains(valuesA, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasPrefixes reports whether each of the paths starts with one of the given literal prefixes.
+func hasPrefixes(paths, prefixes []string) bool {
+	for _, p := range paths {
+		var found bool
+		for _, prefix := range prefixes {
+			if strings.Contains(prefix, "{") {
+				continue
+			}
+
+			// The path prefix must itself be literal up to the length of the prefix.
+			if strings.HasPrefix(p, prefix) && !strings.Contains(p[:len(prefix)], "{") {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matcherName(name string) string {
+	if name == "HostHeader" {
+		return "Host"
+	}
+
+	return name
+}
+
+func normalizeValues(tree *Tree) []string {
+	name := matcherName(tree.Matcher)
+
+	values := make([]string, 0, len(tree.Value))
+	for _, v := range tree.Value {
+		switch {
+		case name == "Method":
+			v = strings.ToUpper(v)
+		case caseInsensitiveMatchers[name]:
+			v = strings.TrimSuffix(strings.ToLower(v), ".")
+		}
+
+		values = append(values, v)
+	}
+
+	if setMatchers[name] {
+		sort.Strings(values)
+	}
+
+	return values
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,217 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTree(t *testing.T, rule string) *Tree {
+	t.Helper()
+
+	parser, err := NewParser([]string{"Host", "HostHeader", "Path", "PathPrefix", "Method", "Headers", "HostSNI", "ClientIP"})
+	require.NoError(t, err)
+
+	parse, err := parser.Parse(rule)
+	require.NoError(t, err)
+
+	buildTree, ok := parse.(TreeBuilder)
+	require.True(t, ok)
+
+	return buildTree()
+}
+
+func TestTree_Canonical(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		rule1 string
+		rule2 string
+		equal bool
+	}{
+		{
+			desc:  "same rule",
+			rule1: "Host(`foo.com`)",
+			rule2: "Host(`foo.com`)",
+			equal: true,
+		},
+		{
+			desc:  "case insensitive host and matcher",
+			rule1: "Host(`FOO.com`)",
+			rule2: "host(`foo.com`)",
+			equal: true,
+		},
+		{
+			desc:  "HostHeader is Host",
+			rule1: "HostHeader(`foo.com`)",
+			rule2: "Host(`foo.com`)",
+			equal: true,
+		},
+		{
+			desc:  "commutative and",
+			rule1: "Host(`foo.com`) && PathPrefix(`/foo`)",
+			rule2: "PathPrefix(`/foo`) && Host(`foo.com`)",
+			equal: true,
+		},
+		{
+			desc:  "associative or",
+			rule1: "(Path(`/a`) || Path(`/b`)) || Path(`/c`)",
+			rule2: "Path(`/c`) || (Path(`/b`) || Path(`/a`))",
+			equal: true,
+		},
+		{
+			desc:  "unordered values",
+			rule1: "Host(`foo.com`, `bar.com`)",
+			rule2: "Host(`bar.com`, `foo.com`)",
+			equal: true,
+		},
+		{
+			desc:  "ordered values",
+			rule1: "Headers(`X-Foo`, `bar`)",
+			rule2: "Headers(`bar`, `X-Foo`)",
+		},
+		{
+			desc:  "case sensitive path",
+			rule1: "Path(`/Foo`)",
+			rule2: "Path(`/foo`)",
+		},
+		{
+			desc:  "negation",
+			rule1: "!Host(`foo.com`)",
+			rule2: "Host(`foo.com`)",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			canonical1 := parseTree(t, test.rule1).Canonical()
+			canonical2 := parseTree(t, test.rule2).Canonical()
+
+			if test.equal {
+				assert.Equal(t, canonical1, canonical2)
+			} else {
+				assert.NotEqual(t, canonical1, canonical2)
+			}
+		})
+	}
+}
+
+func TestConjunctionCovers(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		broad    string
+		narrow   string
+		expected bool
+	}{
+		{
+			desc:     "identical",
+			broad:    "Host(`foo.com`)",
+			narrow:   "Host(`foo.com`)",
+			expected: true,
+		},
+		{
+			desc:     "host subset",
+			broad:    "Host(`foo.com`, `bar.com`)",
+			narrow:   "Host(`foo.com`)",
+			expected: true,
+		},
+		{
+			desc:   "host superset",
+			broad:  "Host(`foo.com`)",
+			narrow: "Host(`foo.com`, `bar.com`)",
+		},
+		{
+			desc:     "additional matcher",
+			broad:    "Host(`foo.com`)",
+			narrow:   "Host(`foo.com`) && PathPrefix(`/api`)",
+			expected: true,
+		},
+		{
+			desc:   "missing matcher",
+			broad:  "Host(`foo.com`) && PathPrefix(`/api`)",
+			narrow: "Host(`foo.com`)",
+		},
+		{
+			desc:     "shorter path prefix",
+			broad:    "PathPrefix(`/api`)",
+			narrow:   "PathPrefix(`/api/v1`)",
+			expected: true,
+		},
+		{
+			desc:     "path under prefix",
+			broad:    "PathPrefix(`/api`)",
+			narrow:   "Path(`/api/users`)",
+			expected: true,
+		},
+		{
+			desc:   "path prefix under path",
+			broad:  "Path(`/api`)",
+			narrow: "PathPrefix(`/api`)",
+		},
+		{
+			desc:   "regexp path prefix",
+			broad:  "PathPrefix(`/{version:v[0-9]+}`)",
+			narrow: "PathPrefix(`/v1/api`)",
+		},
+		{
+			desc:     "method subset",
+			broad:    "Method(`GET`, `POST`)",
+			narrow:   "Method(`get`)",
+			expected: true,
+		},
+		{
+			desc:     "HostSNI catch-all",
+			broad:    "HostSNI(`*`)",
+			narrow:   "HostSNI(`foo.com`)",
+			expected: true,
+		},
+		{
+			desc:     "identical negation",
+			broad:    "!Host(`foo.com`)",
+			narrow:   "!Host(`foo.com`) && Path(`/`)",
+			expected: true,
+		},
+		{
+			desc:   "negation of a subset",
+			broad:  "!Host(`foo.com`, `bar.com`)",
+			narrow: "!Host(`foo.com`)",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			broad := parseTree(t, test.broad).Conjunctions()
+			require.Len(t, broad, 1)
+
+			narrow := parseTree(t, test.narrow).Conjunctions()
+			require.Len(t, narrow, 1)
+
+			assert.Equal(t, test.expected, ConjunctionCovers(broad[0], narrow[0]))
+		})
+	}
+}
+
+func TestTree_Conjunctions(t *testing.T) {
+	tree := parseTree(t, "Host(`foo.com`) && (Path(`/a`) || Path(`/b`))")
+
+	conjunctions := tree.Conjunctions()
+	require.Len(t, conjunctions, 2)
+
+	var canonicals []string
+	for _, conj := range conjunctions {
+		require.Len(t, conj, 2)
+		canonicals = append(canonicals, conj[0].Canonical()+" "+conj[1].Canonical())
+	}
+
+	assert.Equal(t, []string{"Host(foo.com) Path(/a)", "Host(foo.com) Path(/b)"}, canonicals)
+
+	// Too many alternatives.
+	tree = parseTree(t, "(Path(`/a`) || Path(`/b`)) && (Path(`/c`) || Path(`/d`)) && (Path(`/e`) || Path(`/f`)) && (Path(`/g`) || Path(`/h`)) && (Path(`/i`) || Path(`/j`)) && (Path(`/k`) || Path(`/l`))")
+	assert.Nil(t, tree.Conjunctions())
+}
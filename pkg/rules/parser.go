@@ -115,6 +115,16 @@ func (tree *Tree) ParseMatchers(matchers []string) []string {
 	}
 }
 
+// Leaves returns the matchers of the Tree, i.e. its nodes which are neither "and" nor "or" operators.
+func (tree *Tree) Leaves() []*Tree {
+	switch tree.Matcher {
+	case and, or:
+		return append(tree.RuleLeft.Leaves(), tree.RuleRight.Leaves()...)
+	default:
+		return []*Tree{tree}
+	}
+}
+
 // CheckRule validates the given rule.
 func CheckRule(rule *Tree) error {
 	if len(rule.Value) == 0 {
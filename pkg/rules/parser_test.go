@@ -273,6 +273,24 @@ func TestRuleMatch(t *testing.T) {
 	}
 }
 
+func TestTree_Leaves(t *testing.T) {
+	parser, err := NewParser([]string{"m"})
+	require.NoError(t, err)
+
+	parse, err := parser.Parse("m(`1`) && (m(`2`) || !m(`3`))")
+	require.NoError(t, err)
+
+	treeBuilder, ok := parse.(TreeBuilder)
+	require.True(t, ok)
+
+	expected := []*Tree{
+		{Matcher: "m", Value: []string{"1"}},
+		{Matcher: "m", Value: []string{"2"}},
+		{Matcher: "m", Not: true, Value: []string{"3"}},
+	}
+	assert.Equal(t, expected, treeBuilder().Leaves())
+}
+
 func checkEquivalence(t *testing.T, expected *testTree, actual *Tree) {
 	t.Helper()
 
@@ -26,8 +26,9 @@ func mergeConfiguration(configurations dynamic.Configurations, defaultEntryPoint
 			Middlewares: make(map[string]*dynamic.TCPMiddleware),
 		},
 		UDP: &dynamic.UDPConfiguration{
-			Routers:  make(map[string]*dynamic.UDPRouter),
-			Services: make(map[string]*dynamic.UDPService),
+			Routers:     make(map[string]*dynamic.UDPRouter),
+			Services:    make(map[string]*dynamic.UDPService),
+			Middlewares: make(map[string]*dynamic.UDPMiddleware),
 		},
 		TLS: &dynamic.TLSConfiguration{
 			Stores:  make(map[string]tls.Store),
@@ -86,6 +87,9 @@ func mergeConfiguration(configurations dynamic.Configurations, defaultEntryPoint
 			for routerName, router := range configuration.UDP.Routers {
 				conf.UDP.Routers[provider.MakeQualifiedName(pvd, routerName)] = router
 			}
+			for middlewareName, middleware := range configuration.UDP.Middlewares {
+				conf.UDP.Middlewares[provider.MakeQualifiedName(pvd, middlewareName)] = middleware
+			}
 			for serviceName, service := range configuration.UDP.Services {
 				conf.UDP.Services[provider.MakeQualifiedName(pvd, serviceName)] = service
 			}
@@ -44,6 +44,7 @@ type Builder struct {
 	configs        map[string]*runtime.MiddlewareInfo
 	pluginBuilder  PluginsBuilder
 	serviceBuilder serviceBuilder
+	retryBudgets   *retry.Budgets
 }
 
 type serviceBuilder interface {
@@ -55,6 +56,11 @@ func NewBuilder(configs map[string]*runtime.MiddlewareInfo, serviceBuilder servi
 	return &Builder{configs: configs, serviceBuilder: serviceBuilder, pluginBuilder: pluginBuilder}
 }
 
+// SetRetryBudgets sets the retry budgets shared by the retry middlewares across the builders.
+func (b *Builder) SetRetryBudgets(budgets *retry.Budgets) {
+	b.retryBudgets = budgets
+}
+
 // BuildChain creates a middleware chain.
 func (b *Builder) BuildChain(ctx context.Context, middlewares []string) *alice.Chain {
 	chain := alice.New()
@@ -220,6 +226,26 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		}
 	}
 
+	// JWT
+	if config.JWT != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return auth.NewJWT(ctx, next, *config.JWT, middlewareName)
+		}
+	}
+
+	// OIDC
+	if config.OIDC != nil {
+		if middleware != nil {
+			return nil, badConf
+		}
+		middleware = func(next http.Handler) (http.Handler, error) {
+			return auth.NewOIDC(ctx, next, *config.OIDC, middlewareName)
+		}
+	}
+
 	// GrpcWeb
 	if config.GrpcWeb != nil {
 		if middleware != nil {
@@ -327,7 +353,7 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		}
 		middleware = func(next http.Handler) (http.Handler, error) {
 			// TODO missing metrics / accessLog
-			return retry.New(ctx, next, *config.Retry, retry.Listeners{}, middlewareName)
+			return retry.New(ctx, next, *config.Retry, b.retryBudgets, retry.Listeners{}, middlewareName)
 		}
 	}
 
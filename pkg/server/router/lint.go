@@ -0,0 +1,409 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/logs"
+	httpmuxer "github.com/traefik/traefik/v2/pkg/muxer/http"
+	tcpmuxer "github.com/traefik/traefik/v2/pkg/muxer/tcp"
+	"github.com/traefik/traefik/v2/pkg/rules"
+)
+
+// lintRouter holds the information needed to statically analyze a router rule.
+type lintRouter struct {
+	name         string
+	priority     int
+	canonical    string
+	conjunctions [][]*rules.Tree
+	addWarning   func(error)
+}
+
+// lintGroupKey identifies the routers sharing the same muxer.
+type lintGroupKey struct {
+	entryPoint string
+	tls        bool
+}
+
+// conjunctionRef references a conjunction of a router rule.
+type conjunctionRef struct {
+	router *lintRouter
+	atoms  []*rules.Tree
+}
+
+// LintRouters statically analyzes the rules of the enabled HTTP and TCP routers,
+// and adds a warning on the routers which are, or may be, unreachable:
+// routers shadowed by others with an identical or broader rule and a higher or equal priority,
+// and routers whose domains overlap between HTTP and TCP routers on the same entry point.
+// It must be called once the routers have been built, as it relies on their effective entry points.
+func LintRouters(ctx context.Context, conf *runtime.Configuration) {
+	if conf == nil {
+		return
+	}
+
+	httpGroups := make(map[lintGroupKey][]*lintRouter)
+	httpDomains := make(map[lintGroupKey]map[string][]string)
+
+	for name, rt := range conf.Routers {
+		if rt.Status == runtime.StatusDisabled {
+			continue
+		}
+
+		tree, err := httpmuxer.ParseRule(rt.Rule)
+		if err != nil {
+			continue
+		}
+
+		priority := rt.Priority
+		if priority == 0 {
+			priority = len(rt.Rule)
+		}
+
+		router := newLintRouter(name, priority, tree, warningFunc(ctx, name, rt.AddError))
+
+		for _, ep := range rt.Using {
+			key := lintGroupKey{entryPoint: ep, tls: rt.TLS != nil}
+			httpGroups[key] = append(httpGroups[key], router)
+
+			if httpDomains[key] == nil {
+				httpDomains[key] = make(map[string][]string)
+			}
+			httpDomains[key][name] = tree.ParseMatchers([]string{"Host"})
+		}
+	}
+
+	for key, routers := range httpGroups {
+		lintGroup(key, routers)
+	}
+
+	tcpGroups := make(map[lintGroupKey][]*lintRouter)
+	tcpDomains := make(map[lintGroupKey]map[string][]string)
+	tcpCatchAll := make(map[lintGroupKey][]string)
+
+	for name, rt := range conf.TCPRouters {
+		if rt.Status == runtime.StatusDisabled {
+			continue
+		}
+
+		tree, err := tcpmuxer.ParseRule(rt.Rule)
+		if err != nil {
+			continue
+		}
+
+		domains := tree.ParseMatchers([]string{"HostSNI"})
+
+		priority := rt.Priority
+		if priority == 0 {
+			priority = len(rt.Rule)
+			if len(domains) == 1 && domains[0] == "*" && tree.RuleLeft == nil {
+				priority = -1
+			}
+		}
+
+		router := newLintRouter(name, priority, tree, warningFunc(ctx, name, rt.AddError))
+
+		for _, ep := range rt.Using {
+			key := lintGroupKey{entryPoint: ep, tls: rt.TLS != nil}
+			tcpGroups[key] = append(tcpGroups[key], router)
+
+			if tcpDomains[key] == nil {
+				tcpDomains[key] = make(map[string][]string)
+			}
+			tcpDomains[key][name] = domains
+
+			if isCatchAll(router.conjunctions) {
+				tcpCatchAll[key] = append(tcpCatchAll[key], name)
+			}
+		}
+	}
+
+	for key, routers := range tcpGroups {
+		lintGroup(key, routers)
+	}
+
+	for key, tcpRouters := range tcpDomains {
+		httpRouters, ok := httpDomains[key]
+		if !ok {
+			continue
+		}
+
+		if key.tls {
+			lintTLSOverlaps(ctx, conf, key.entryPoint, tcpRouters, httpRouters)
+			continue
+		}
+
+		// Non-TLS TCP routers are evaluated before HTTP routers,
+		// and their rules can only be HostSNI(`*`), possibly narrowed down by a ClientIP matcher.
+		// Only the ones matching all the connections make the HTTP routers unreachable.
+		catchAll := tcpCatchAll[key]
+		if len(catchAll) == 0 {
+			continue
+		}
+
+		sort.Strings(catchAll)
+		tcpNames := strings.Join(catchAll, ", ")
+		for httpName := range httpRouters {
+			warningFunc(ctx, httpName, conf.Routers[httpName].AddError)(
+				fmt.Errorf("router is unreachable on entryPoint %q, all connections are captured by TCP routers, which take precedence: %s", key.entryPoint, tcpNames))
+		}
+	}
+}
+
+// lintTLSOverlaps detects the domains served by both TLS TCP routers and HTTPS routers on an entry point.
+// HTTPS routers with a Host rule take precedence over TCP routers with the same HostSNI,
+// whereas TCP routers with a specific HostSNI take precedence over HTTPS routers without any Host rule.
+func lintTLSOverlaps(ctx context.Context, conf *runtime.Configuration, ep string, tcpRouters, httpRouters map[string][]string) {
+	httpHosts := make(map[string][]string)
+	var httpCatchAll []string
+	for _, httpName := range sortedKeys(httpRouters) {
+		domains := httpRouters[httpName]
+		if len(domains) == 0 {
+			httpCatchAll = append(httpCatchAll, httpName)
+			continue
+		}
+
+		for _, domain := range domains {
+			httpHosts[domain] = append(httpHosts[domain], httpName)
+		}
+	}
+
+	for _, tcpName := range sortedKeys(tcpRouters) {
+		addWarning := warningFunc(ctx, tcpName, conf.TCPRouters[tcpName].AddError)
+
+		for _, domain := range tcpRouters[tcpName] {
+			if domain == "*" {
+				if len(httpCatchAll) > 0 {
+					addWarning(fmt.Errorf("HostSNI(`*`) on entryPoint %q is shadowed by HTTP routers without Host rule: %s", ep, strings.Join(httpCatchAll, ", ")))
+				}
+				continue
+			}
+
+			if names, ok := httpHosts[domain]; ok {
+				addWarning(fmt.Errorf("HostSNI %q on entryPoint %q is shadowed by HTTP routers with the same Host, which take precedence: %s", domain, ep, strings.Join(names, ", ")))
+			}
+
+			for _, httpName := range httpCatchAll {
+				warningFunc(ctx, httpName, conf.Routers[httpName].AddError)(
+					fmt.Errorf("connections for %q on entryPoint %q are captured by TCP router %q, which takes precedence", domain, ep, tcpName))
+			}
+		}
+	}
+}
+
+// lintGroup detects the identical and shadowed rules among the routers of a single muxer.
+func lintGroup(key lintGroupKey, routers []*lintRouter) {
+	ep := key.entryPoint
+
+	sort.Slice(routers, func(i, j int) bool {
+		if routers[i].priority == routers[j].priority {
+			return routers[i].name < routers[j].name
+		}
+		return routers[i].priority > routers[j].priority
+	})
+
+	// Index of the conjunctions of the routers with a higher priority than the current group,
+	// keyed by the domain they are restricted to, or by the empty string when they are not.
+	index := make(map[string][]conjunctionRef)
+
+	for start := 0; start < len(routers); {
+		end := start
+		for end < len(routers) && routers[end].priority == routers[start].priority {
+			end++
+		}
+		group := routers[start:end]
+
+		groupIndex := make(map[string][]conjunctionRef)
+		for _, router := range group {
+			indexConjunctions(groupIndex, router)
+		}
+		mergedIndex := mergeIndexes(index, groupIndex)
+
+		identical := make(map[string][]string)
+		for _, router := range group {
+			identical[router.canonical] = append(identical[router.canonical], router.name)
+		}
+
+		for _, router := range group {
+			if names := identical[router.canonical]; len(names) > 1 {
+				router.addWarning(fmt.Errorf("rule is identical to the one of routers %s with the same priority on entryPoint %q, only one of them is reachable",
+					strings.Join(without(names, router.name), ", "), ep))
+				continue
+			}
+
+			if router.conjunctions == nil {
+				continue
+			}
+
+			higher, ok := shadowedBy(index, router)
+			if ok {
+				router.addWarning(fmt.Errorf("router is unreachable on entryPoint %q, shadowed by routers with a higher priority: %s", ep, strings.Join(higher, ", ")))
+				continue
+			}
+
+			same, ok := shadowedBy(mergedIndex, router)
+			if ok {
+				router.addWarning(fmt.Errorf("router may be unreachable on entryPoint %q, shadowed by routers with a higher or equal priority: %s", ep, strings.Join(same, ", ")))
+			}
+		}
+
+		for _, router := range group {
+			indexConjunctions(index, router)
+		}
+
+		start = end
+	}
+}
+
+// shadowedBy returns the names of the indexed routers covering all the conjunctions of the given router,
+// and whether there are such routers.
+func shadowedBy(index map[string][]conjunctionRef, router *lintRouter) ([]string, bool) {
+	names := make(map[string]struct{})
+
+	for _, conj := range router.conjunctions {
+		candidates := index[""]
+		if hosts := conjunctionHosts(conj); len(hosts) > 0 {
+			// A conjunction restricted to some domains can only cover one restricted to a subset of them.
+			candidates = append(candidates[:len(candidates):len(candidates)], index[hosts[0]]...)
+		}
+
+		var covered bool
+		for _, candidate := range candidates {
+			if candidate.router == router {
+				continue
+			}
+
+			if rules.ConjunctionCovers(candidate.atoms, conj) {
+				names[candidate.router.name] = struct{}{}
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return nil, false
+		}
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+
+	return result, true
+}
+
+func indexConjunctions(index map[string][]conjunctionRef, router *lintRouter) {
+	for _, conj := range router.conjunctions {
+		ref := conjunctionRef{router: router, atoms: conj}
+
+		hosts := conjunctionHosts(conj)
+		if len(hosts) == 0 {
+			index[""] = append(index[""], ref)
+			continue
+		}
+
+		for _, host := range hosts {
+			index[host] = append(index[host], ref)
+		}
+	}
+}
+
+// isCatchAll returns whether one of the conjunctions matches all the connections,
+// i.e. is only made of HostSNI(`*`) matchers.
+func isCatchAll(conjunctions [][]*rules.Tree) bool {
+	for _, conj := range conjunctions {
+		catchAll := true
+		for _, atom := range conj {
+			if atom.Not || atom.Matcher != "HostSNI" || len(atom.Value) == 0 || atom.Value[0] != "*" {
+				catchAll = false
+				break
+			}
+		}
+
+		if catchAll {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conjunctionHosts returns the domains the conjunction is restricted to by a Host or HostSNI matcher.
+func conjunctionHosts(conj []*rules.Tree) []string {
+	for _, atom := range conj {
+		if atom.Not {
+			continue
+		}
+
+		switch atom.Matcher {
+		case "Host", "HostHeader", "HostSNI":
+			if atom.Matcher == "HostSNI" && len(atom.Value) > 0 && atom.Value[0] == "*" {
+				continue
+			}
+
+			var hosts []string
+			for _, value := range atom.Value {
+				hosts = append(hosts, strings.TrimSuffix(strings.ToLower(value), "."))
+			}
+			return hosts
+		}
+	}
+
+	return nil
+}
+
+func mergeIndexes(a, b map[string][]conjunctionRef) map[string][]conjunctionRef {
+	merged := make(map[string][]conjunctionRef, len(a)+len(b))
+	for k, v := range a {
+		merged[k] = v[:len(v):len(v)]
+	}
+	for k, v := range b {
+		merged[k] = append(merged[k], v...)
+	}
+
+	return merged
+}
+
+func newLintRouter(name string, priority int, tree *rules.Tree, addWarning func(error)) *lintRouter {
+	return &lintRouter{
+		name:         name,
+		priority:     priority,
+		canonical:    tree.Canonical(),
+		conjunctions: tree.Conjunctions(),
+		addWarning:   addWarning,
+	}
+}
+
+func warningFunc(ctx context.Context, routerName string, addError func(error, bool)) func(error) {
+	return func(err error) {
+		log.Ctx(ctx).Warn().Str(logs.RouterName, routerName).Err(err).Msg("Router rule conflict")
+		addError(err, false)
+	}
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func without(names []string, name string) []string {
+	var result []string
+	for _, n := range names {
+		if n != name {
+			result = append(result, n)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,214 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+func TestLintRouters(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		routers             map[string]*dynamic.Router
+		disabled            []string
+		tcpRouters          map[string]*dynamic.TCPRouter
+		expectedWarnings    map[string]int
+		expectedTCPWarnings map[string]int
+	}{
+		{
+			desc: "no conflict",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)"},
+				"bar": {Rule: "Host(`bar.com`)"},
+			},
+		},
+		{
+			desc: "identical rules with same priority",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`) && Path(`/`)"},
+				"bar": {Rule: "Path(`/`) && Host(`foo.com`)"},
+			},
+			expectedWarnings: map[string]int{"foo": 1, "bar": 1},
+		},
+		{
+			desc: "identical rules with different priorities",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)", Priority: 10},
+				"bar": {Rule: "Host(`foo.com`)", Priority: 20},
+			},
+			expectedWarnings: map[string]int{"foo": 1},
+		},
+		{
+			desc: "narrower rule with lower priority",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`) && PathPrefix(`/api`)", Priority: 10},
+				"bar": {Rule: "Host(`foo.com`, `bar.com`)", Priority: 20},
+			},
+			expectedWarnings: map[string]int{"foo": 1},
+		},
+		{
+			desc: "narrower rule with higher priority",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`) && PathPrefix(`/api`)", Priority: 20},
+				"bar": {Rule: "Host(`foo.com`)", Priority: 10},
+			},
+		},
+		{
+			desc: "narrower rule with default priority",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`) && PathPrefix(`/api`)"},
+				"bar": {Rule: "Host(`foo.com`)"},
+			},
+		},
+		{
+			desc: "alternatives shadowed by several routers",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Path(`/a`) || Path(`/b`)", Priority: 10},
+				"bar": {Rule: "PathPrefix(`/a`)", Priority: 20},
+				"baz": {Rule: "PathPrefix(`/b`)", Priority: 20},
+			},
+			expectedWarnings: map[string]int{"foo": 1},
+		},
+		{
+			desc: "rules on different entry points",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)", EntryPoints: []string{"web"}},
+				"bar": {Rule: "Host(`foo.com`)", EntryPoints: []string{"websecure"}},
+			},
+		},
+		{
+			desc: "HTTP and HTTPS routers",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)"},
+				"bar": {Rule: "Host(`foo.com`)", TLS: &dynamic.RouterTLSConfig{}},
+			},
+		},
+		{
+			desc: "HostSNI shadowed by an HTTPS Host",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)", TLS: &dynamic.RouterTLSConfig{}},
+			},
+			tcpRouters: map[string]*dynamic.TCPRouter{
+				"bar": {Rule: "HostSNI(`foo.com`)", TLS: &dynamic.RouterTCPTLSConfig{}},
+			},
+			expectedTCPWarnings: map[string]int{"bar": 1},
+		},
+		{
+			desc: "HostSNI capturing HTTPS routers without Host",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "PathPrefix(`/`)", TLS: &dynamic.RouterTLSConfig{}},
+			},
+			tcpRouters: map[string]*dynamic.TCPRouter{
+				"bar": {Rule: "HostSNI(`bar.com`)", TLS: &dynamic.RouterTCPTLSConfig{}},
+			},
+			expectedWarnings: map[string]int{"foo": 1},
+		},
+		{
+			desc: "non-TLS TCP router capturing HTTP routers",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)"},
+			},
+			tcpRouters: map[string]*dynamic.TCPRouter{
+				"bar": {Rule: "HostSNI(`*`)"},
+			},
+			expectedWarnings: map[string]int{"foo": 1},
+		},
+		{
+			desc: "non-TLS TCP router restricted to some clients",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)"},
+			},
+			tcpRouters: map[string]*dynamic.TCPRouter{
+				"bar": {Rule: "HostSNI(`*`) && ClientIP(`10.0.0.0/8`)"},
+			},
+		},
+		{
+			desc: "non-TLS TCP router with a catch-all alternative",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)"},
+			},
+			tcpRouters: map[string]*dynamic.TCPRouter{
+				"bar": {Rule: "(HostSNI(`*`) && ClientIP(`10.0.0.0/8`)) || HostSNI(`*`)"},
+			},
+			expectedWarnings: map[string]int{"foo": 1},
+		},
+		{
+			desc: "identical TCP rules",
+			tcpRouters: map[string]*dynamic.TCPRouter{
+				"foo": {Rule: "HostSNI(`foo.com`)", TLS: &dynamic.RouterTCPTLSConfig{}},
+				"bar": {Rule: "HostSNI(`FOO.com`)", TLS: &dynamic.RouterTCPTLSConfig{}},
+			},
+			expectedTCPWarnings: map[string]int{"foo": 1, "bar": 1},
+		},
+		{
+			desc: "disabled router",
+			routers: map[string]*dynamic.Router{
+				"foo": {Rule: "Host(`foo.com`)"},
+				"bar": {Rule: "Host(`foo.com`)"},
+			},
+			disabled: []string{"bar"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			conf := &runtime.Configuration{
+				Routers:    make(map[string]*runtime.RouterInfo),
+				TCPRouters: make(map[string]*runtime.TCPRouterInfo),
+			}
+
+			for name, rt := range test.routers {
+				using := rt.EntryPoints
+				if len(using) == 0 {
+					using = []string{"web"}
+				}
+
+				status := runtime.StatusEnabled
+				for _, disabled := range test.disabled {
+					if name == disabled {
+						status = runtime.StatusDisabled
+					}
+				}
+
+				conf.Routers[name] = &runtime.RouterInfo{Router: rt, Status: status, Using: using}
+			}
+
+			for name, rt := range test.tcpRouters {
+				conf.TCPRouters[name] = &runtime.TCPRouterInfo{TCPRouter: rt, Status: runtime.StatusEnabled, Using: []string{"web"}}
+			}
+
+			LintRouters(context.Background(), conf)
+
+			warnings := make(map[string]int)
+			for name, rt := range conf.Routers {
+				if len(rt.Err) > 0 {
+					warnings[name] = len(rt.Err)
+				}
+			}
+
+			tcpWarnings := make(map[string]int)
+			for name, rt := range conf.TCPRouters {
+				if len(rt.Err) > 0 {
+					tcpWarnings[name] = len(rt.Err)
+					assert.Equal(t, runtime.StatusWarning, rt.Status)
+				}
+			}
+
+			if test.expectedWarnings == nil {
+				test.expectedWarnings = map[string]int{}
+			}
+			if test.expectedTCPWarnings == nil {
+				test.expectedTCPWarnings = map[string]int{}
+			}
+
+			assert.Equal(t, test.expectedWarnings, warnings)
+			assert.Equal(t, test.expectedTCPWarnings, tcpWarnings)
+		})
+	}
+}
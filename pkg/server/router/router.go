@@ -36,6 +36,10 @@ type Manager struct {
 	middlewaresBuilder middlewareBuilder
 	chainBuilder       *middleware.ChainBuilder
 	conf               *runtime.Configuration
+
+	// httpMuxers and httpsMuxers hold the muxers built for the entry points, by entry point name.
+	httpMuxers  map[string]*httpmuxer.Muxer
+	httpsMuxers map[string]*httpmuxer.Muxer
 }
 
 // NewManager Creates a new Manager.
@@ -47,7 +51,18 @@ func NewManager(conf *runtime.Configuration, serviceManager serviceManager, midd
 		middlewaresBuilder: middlewaresBuilder,
 		chainBuilder:       chainBuilder,
 		conf:               conf,
+		httpMuxers:         make(map[string]*httpmuxer.Muxer),
+		httpsMuxers:        make(map[string]*httpmuxer.Muxer),
+	}
+}
+
+// GetMuxer returns the muxer built by BuildHandlers to route the requests of the given entry point, if any.
+func (m *Manager) GetMuxer(entryPointName string, tls bool) *httpmuxer.Muxer {
+	if tls {
+		return m.httpsMuxers[entryPointName]
 	}
+
+	return m.httpMuxers[entryPointName]
 }
 
 func (m *Manager) getHTTPRouters(ctx context.Context, entryPoints []string, tls bool) map[string]map[string]*runtime.RouterInfo {
@@ -68,12 +83,18 @@ func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string, t
 		logger := log.Ctx(rootCtx).With().Str(logs.EntryPointName, entryPointName).Logger()
 		ctx := logger.WithContext(rootCtx)
 
-		handler, err := m.buildEntryPointHandler(ctx, routers)
+		handler, muxer, err := m.buildEntryPointHandler(ctx, routers)
 		if err != nil {
 			logger.Error().Err(err).Send()
 			continue
 		}
 
+		if tls {
+			m.httpsMuxers[entryPointName] = muxer
+		} else {
+			m.httpMuxers[entryPointName] = muxer
+		}
+
 		handlerWithAccessLog, err := alice.New(func(next http.Handler) (http.Handler, error) {
 			return accesslog.NewFieldHandler(next, logs.EntryPointName, entryPointName, accesslog.AddOriginFields), nil
 		}).Then(handler)
@@ -105,10 +126,10 @@ func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string, t
 	return entryPointHandlers
 }
 
-func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string]*runtime.RouterInfo) (http.Handler, error) {
+func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string]*runtime.RouterInfo) (http.Handler, *httpmuxer.Muxer, error) {
 	muxer, err := httpmuxer.NewMuxer()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for routerName, routerConfig := range configs {
@@ -122,7 +143,7 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string
 			continue
 		}
 
-		err = muxer.AddRoute(routerConfig.Rule, routerConfig.Priority, handler)
+		err = muxer.AddNamedRoute(routerName, routerConfig.Rule, routerConfig.Priority, handler)
 		if err != nil {
 			routerConfig.AddError(err, true)
 			logger.Error().Err(err).Send()
@@ -137,7 +158,12 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string
 		return recovery.New(ctx, next)
 	})
 
-	return chain.Then(muxer)
+	handler, err := chain.Then(muxer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return handler, muxer, nil
 }
 
 func (m *Manager) buildRouterHandler(ctx context.Context, routerName string, routerConfig *runtime.RouterInfo) (http.Handler, error) {
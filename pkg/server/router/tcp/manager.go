@@ -235,6 +235,10 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string
 		}
 	}
 
+	// The routers with the same TLS options share the same TLS configuration,
+	// so that the routers matching on the client certificate can hand the connection over to one another.
+	tlsConfigs := make(map[string]*tls.Config)
+
 	for routerName, routerConfig := range configs {
 		logger := log.Ctx(ctx).With().Str(logs.RouterName, routerName).Logger()
 		ctxRouter := logger.WithContext(provider.AddInContext(ctx, routerName))
@@ -278,7 +282,7 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string
 
 		if routerConfig.TLS == nil {
 			logger.Debug().Msgf("Adding route for %q", routerConfig.Rule)
-			if err := router.AddRoute(routerConfig.Rule, routerConfig.Priority, handler); err != nil {
+			if err := router.AddRoute(routerName, routerConfig.Rule, routerConfig.Priority, handler); err != nil {
 				routerConfig.AddError(err, true)
 				logger.Error().Err(err).Send()
 			}
@@ -287,7 +291,7 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string
 
 		if routerConfig.TLS.Passthrough {
 			logger.Debug().Msgf("Adding Passthrough route for %q", routerConfig.Rule)
-			if err := router.AddRouteTLS(routerConfig.Rule, routerConfig.Priority, handler, nil); err != nil {
+			if err := router.AddRouteTLS(routerName, routerConfig.Rule, routerConfig.Priority, handler, nil); err != nil {
 				routerConfig.AddError(err, true)
 				logger.Error().Err(err).Send()
 			}
@@ -314,11 +318,16 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string
 			tlsOptionsName = provider.GetQualifiedName(ctxRouter, tlsOptionsName)
 		}
 
-		tlsConf, err := m.tlsManager.Get(traefiktls.DefaultTLSStoreName, tlsOptionsName)
-		if err != nil {
-			routerConfig.AddError(err, true)
-			logger.Error().Err(err).Send()
-			continue
+		tlsConf, ok := tlsConfigs[tlsOptionsName]
+		if !ok {
+			tlsConf, err = m.tlsManager.Get(traefiktls.DefaultTLSStoreName, tlsOptionsName)
+			if err != nil {
+				routerConfig.AddError(err, true)
+				logger.Error().Err(err).Send()
+				continue
+			}
+
+			tlsConfigs[tlsOptionsName] = tlsConf
 		}
 
 		// Now that the Rule is not just about the Host, we could theoretically have a config like:
@@ -334,9 +343,11 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string
 		// to be the case so far with the existing matchers (HostSNI, and ClientIP), so
 		// it's all good. Otherwise, we would have to do as for HTTPS, i.e. disallow
 		// different TLS configs for the same HostSNIs.
+		// The client certificate matchers are the exception, as they are evaluated after the handshake,
+		// so a connection is only handed over to the routers with the TLS options used for the handshake.
 
 		logger.Debug().Msgf("Adding TLS route for %q", routerConfig.Rule)
-		if err := router.AddRouteTLS(routerConfig.Rule, routerConfig.Priority, handler, tlsConf); err != nil {
+		if err := router.AddRouteTLS(routerName, routerConfig.Rule, routerConfig.Priority, handler, tlsConf); err != nil {
 			routerConfig.AddError(err, true)
 			logger.Error().Err(err).Send()
 		}
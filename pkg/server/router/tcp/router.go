@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
+	httpmuxer "github.com/traefik/traefik/v2/pkg/muxer/http"
 	tcpmuxer "github.com/traefik/traefik/v2/pkg/muxer/tcp"
 	"github.com/traefik/traefik/v2/pkg/tcp"
 )
@@ -26,6 +27,10 @@ type Router struct {
 	// Contains HTTPS routes.
 	muxerHTTPS tcpmuxer.Muxer
 
+	// Muxers of the HTTP and HTTPS handlers, if any, held so that their routing can be explained.
+	httpMuxer  *httpmuxer.Muxer
+	httpsMuxer *httpmuxer.Muxer
+
 	// Forwarder handlers.
 	// Handles all HTTP requests.
 	httpForwarder tcp.Handler
@@ -200,24 +205,44 @@ func (r *Router) ServeTCP(conn tcp.WriteCloser) {
 	conn.Close()
 }
 
-// AddRoute defines a handler for the given rule.
-func (r *Router) AddRoute(rule string, priority int, target tcp.Handler) error {
-	return r.muxerTCP.AddRoute(rule, priority, target)
+// AddRoute defines a handler for the given rule, of the given router.
+func (r *Router) AddRoute(routerName, rule string, priority int, target tcp.Handler) error {
+	if err := checkNoClientCert(rule); err != nil {
+		return err
+	}
+
+	return r.muxerTCP.AddNamedRoute(routerName, rule, priority, target)
 }
 
-// AddRouteTLS defines a handler for a given rule and sets the matching tlsConfig.
-func (r *Router) AddRouteTLS(rule string, priority int, target tcp.Handler, config *tls.Config) error {
+// AddRouteTLS defines a handler for a given rule, of the given router, and sets the matching tlsConfig.
+func (r *Router) AddRouteTLS(routerName, rule string, priority int, target tcp.Handler, config *tls.Config) error {
 	// TLS PassThrough
 	if config == nil {
-		return r.muxerTCPTLS.AddRoute(rule, priority, target)
+		if err := checkNoClientCert(rule); err != nil {
+			return err
+		}
+
+		return r.muxerTCPTLS.AddNamedRoute(routerName, rule, priority, target)
 	}
 
-	return r.muxerTCPTLS.AddRoute(rule, priority, &tcp.TLSHandler{
+	return r.muxerTCPTLS.AddNamedRoute(routerName, rule, priority, &tcp.TLSHandler{
 		Next:   target,
 		Config: config,
 	})
 }
 
+// checkNoClientCert returns an error if the rule matches on the client certificate,
+// which is only known when the router terminates TLS.
+func checkNoClientCert(rule string) error {
+	// Invalid rules are reported by the muxer.
+	clientCert, err := tcpmuxer.UsesClientCert(rule)
+	if err == nil && clientCert {
+		return errors.New("client certificate matchers are only allowed on routers terminating TLS")
+	}
+
+	return nil
+}
+
 // AddHTTPTLSConfig defines a handler for a given sniHost and sets the matching tlsConfig.
 func (r *Router) AddHTTPTLSConfig(sniHost string, config *tls.Config) {
 	if r.hostHTTPTLSConfig == nil {
@@ -238,6 +263,31 @@ func (r *Router) GetConn(conn tcp.WriteCloser, peeked string) tcp.WriteCloser {
 	return conn
 }
 
+// GetTCPMuxer gets the muxer of the TCP routes, or of the TCP TLS routes.
+func (r *Router) GetTCPMuxer(tls bool) *tcpmuxer.Muxer {
+	if tls {
+		return &r.muxerTCPTLS
+	}
+
+	return &r.muxerTCP
+}
+
+// GetHTTPMuxer gets the muxer of the attached http handler, if any.
+func (r *Router) GetHTTPMuxer() *httpmuxer.Muxer {
+	return r.httpMuxer
+}
+
+// GetHTTPSMuxer gets the muxer of the attached https handler, if any.
+func (r *Router) GetHTTPSMuxer() *httpmuxer.Muxer {
+	return r.httpsMuxer
+}
+
+// SetHTTPMuxers attaches the muxers of the http and https handlers on the router.
+func (r *Router) SetHTTPMuxers(httpMuxer, httpsMuxer *httpmuxer.Muxer) {
+	r.httpMuxer = httpMuxer
+	r.httpsMuxer = httpsMuxer
+}
+
 // GetHTTPHandler gets the attached http handler.
 func (r *Router) GetHTTPHandler() http.Handler {
 	return r.httpHandler
@@ -8,25 +8,33 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/logs"
+	udpmuxer "github.com/traefik/traefik/v2/pkg/muxer/udp"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
 	udpservice "github.com/traefik/traefik/v2/pkg/server/service/udp"
 	"github.com/traefik/traefik/v2/pkg/udp"
 )
 
+type middlewareBuilder interface {
+	BuildChain(ctx context.Context, names []string) *udp.Chain
+}
+
 // NewManager Creates a new Manager.
 func NewManager(conf *runtime.Configuration,
 	serviceManager *udpservice.Manager,
+	middlewaresBuilder middlewareBuilder,
 ) *Manager {
 	return &Manager{
-		serviceManager: serviceManager,
-		conf:           conf,
+		serviceManager:     serviceManager,
+		middlewaresBuilder: middlewaresBuilder,
+		conf:               conf,
 	}
 }
 
 // Manager is a route/router manager.
 type Manager struct {
-	serviceManager *udpservice.Manager
-	conf           *runtime.Configuration
+	serviceManager     *udpservice.Manager
+	middlewaresBuilder middlewareBuilder
+	conf               *runtime.Configuration
 }
 
 func (m *Manager) getUDPRouters(ctx context.Context, entryPoints []string) map[string]map[string]*runtime.UDPRouterInfo {
@@ -50,21 +58,25 @@ func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string) m
 		logger := log.Ctx(rootCtx).With().Str(logs.EntryPointName, entryPointName).Logger()
 		ctx := logger.WithContext(rootCtx)
 
-		if len(routers) > 1 {
-			logger.Warn().Msg("Config has more than one udp router for a given entrypoint.")
+		handler, err := m.buildEntryPointHandler(ctx, routers)
+		if err != nil {
+			logger.Error().Err(err).Send()
+			continue
 		}
 
-		handlers := m.buildEntryPointHandlers(ctx, routers)
-
-		if len(handlers) > 0 {
-			// As UDP support only one router per entrypoint, we only take the first one.
-			entryPointHandlers[entryPointName] = handlers[0]
+		if handler.HasRoutes() {
+			entryPointHandlers[entryPointName] = handler
 		}
 	}
 	return entryPointHandlers
 }
 
-func (m *Manager) buildEntryPointHandlers(ctx context.Context, configs map[string]*runtime.UDPRouterInfo) []udp.Handler {
+func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string]*runtime.UDPRouterInfo) (*udpmuxer.Muxer, error) {
+	muxer, err := udpmuxer.NewMuxer()
+	if err != nil {
+		return nil, err
+	}
+
 	var rtNames []string
 	for routerName := range configs {
 		rtNames = append(rtNames, routerName)
@@ -74,29 +86,46 @@ func (m *Manager) buildEntryPointHandlers(ctx context.Context, configs map[strin
 		return rtNames[i] > rtNames[j]
 	})
 
-	var handlers []udp.Handler
-
 	for _, routerName := range rtNames {
 		routerConfig := configs[routerName]
 		logger := log.Ctx(ctx).With().Str(logs.RouterName, routerName).Logger()
 		ctxRouter := logger.WithContext(provider.AddInContext(ctx, routerName))
 
-		if routerConfig.Service == "" {
-			err := errors.New("the service is missing on the udp router")
+		handler, err := m.buildUDPHandler(ctxRouter, routerConfig)
+		if err != nil {
 			routerConfig.AddError(err, true)
 			logger.Error().Err(err).Send()
 			continue
 		}
 
-		handler, err := m.serviceManager.BuildUDP(ctxRouter, routerConfig.Service)
+		err = muxer.AddRoute(routerConfig.Rule, routerConfig.Priority, handler)
 		if err != nil {
 			routerConfig.AddError(err, true)
 			logger.Error().Err(err).Send()
 			continue
 		}
+	}
 
-		handlers = append(handlers, handler)
+	return muxer, nil
+}
+
+func (m *Manager) buildUDPHandler(ctx context.Context, router *runtime.UDPRouterInfo) (udp.Handler, error) {
+	var qualifiedNames []string
+	for _, name := range router.Middlewares {
+		qualifiedNames = append(qualifiedNames, provider.GetQualifiedName(ctx, name))
 	}
+	router.Middlewares = qualifiedNames
+
+	if router.Service == "" {
+		return nil, errors.New("the service is missing on the udp router")
+	}
+
+	sHandler, err := m.serviceManager.BuildUDP(ctx, router.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	mHandler := m.middlewaresBuilder.BuildChain(ctx, router.Middlewares)
 
-	return handlers
+	return udp.NewChain().Extend(*mHandler).Then(sHandler)
 }
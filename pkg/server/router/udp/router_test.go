@@ -7,15 +7,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	udpmiddleware "github.com/traefik/traefik/v2/pkg/server/middleware/udp"
 	"github.com/traefik/traefik/v2/pkg/server/service/udp"
 )
 
 func TestRuntimeConfiguration(t *testing.T) {
 	testCases := []struct {
-		desc          string
-		serviceConfig map[string]*runtime.UDPServiceInfo
-		routerConfig  map[string]*runtime.UDPRouterInfo
-		expectedError int
+		desc             string
+		serviceConfig    map[string]*runtime.UDPServiceInfo
+		routerConfig     map[string]*runtime.UDPRouterInfo
+		middlewareConfig map[string]*runtime.UDPMiddlewareInfo
+		expectedError    int
 	}{
 		{
 			desc: "No error",
@@ -103,6 +105,141 @@ func TestRuntimeConfiguration(t *testing.T) {
 			},
 			expectedError: 2,
 		},
+		{
+			desc: "Routers with rules and middlewares",
+			serviceConfig: map[string]*runtime.UDPServiceInfo{
+				"foo-service": {
+					UDPService: &dynamic.UDPService{
+						LoadBalancer: &dynamic.UDPServersLoadBalancer{
+							Servers: []dynamic.UDPServer{
+								{
+									Address: "127.0.0.1:80",
+								},
+							},
+						},
+					},
+				},
+			},
+			middlewareConfig: map[string]*runtime.UDPMiddlewareInfo{
+				"allowlist": {
+					UDPMiddleware: &dynamic.UDPMiddleware{
+						IPAllowList: &dynamic.UDPIPAllowList{
+							SourceRange: []string{"10.0.0.0/8"},
+						},
+					},
+				},
+				"ratelimit": {
+					UDPMiddleware: &dynamic.UDPMiddleware{
+						RateLimit: &dynamic.UDPRateLimit{
+							Average: 100,
+						},
+					},
+				},
+			},
+			routerConfig: map[string]*runtime.UDPRouterInfo{
+				"foo": {
+					UDPRouter: &dynamic.UDPRouter{
+						EntryPoints: []string{"web"},
+						Service:     "foo-service",
+						Middlewares: []string{"allowlist", "ratelimit"},
+						Rule:        "ClientIP(`10.0.0.0/8`)",
+					},
+				},
+				"bar": {
+					UDPRouter: &dynamic.UDPRouter{
+						EntryPoints: []string{"web"},
+						Service:     "foo-service",
+						Priority:    10,
+					},
+				},
+			},
+			expectedError: 0,
+		},
+		{
+			desc: "Router with invalid rule",
+			serviceConfig: map[string]*runtime.UDPServiceInfo{
+				"foo-service": {
+					UDPService: &dynamic.UDPService{
+						LoadBalancer: &dynamic.UDPServersLoadBalancer{
+							Servers: []dynamic.UDPServer{
+								{
+									Address: "127.0.0.1:80",
+								},
+							},
+						},
+					},
+				},
+			},
+			routerConfig: map[string]*runtime.UDPRouterInfo{
+				"foo": {
+					UDPRouter: &dynamic.UDPRouter{
+						EntryPoints: []string{"web"},
+						Service:     "foo-service",
+						Rule:        "HostSNI(`foo.com`)",
+					},
+				},
+			},
+			expectedError: 1,
+		},
+		{
+			desc: "Router with unknown middleware",
+			serviceConfig: map[string]*runtime.UDPServiceInfo{
+				"foo-service": {
+					UDPService: &dynamic.UDPService{
+						LoadBalancer: &dynamic.UDPServersLoadBalancer{
+							Servers: []dynamic.UDPServer{
+								{
+									Address: "127.0.0.1:80",
+								},
+							},
+						},
+					},
+				},
+			},
+			routerConfig: map[string]*runtime.UDPRouterInfo{
+				"foo": {
+					UDPRouter: &dynamic.UDPRouter{
+						EntryPoints: []string{"web"},
+						Service:     "foo-service",
+						Middlewares: []string{"unknown"},
+					},
+				},
+			},
+			expectedError: 1,
+		},
+		{
+			desc: "Router with invalid middleware",
+			serviceConfig: map[string]*runtime.UDPServiceInfo{
+				"foo-service": {
+					UDPService: &dynamic.UDPService{
+						LoadBalancer: &dynamic.UDPServersLoadBalancer{
+							Servers: []dynamic.UDPServer{
+								{
+									Address: "127.0.0.1:80",
+								},
+							},
+						},
+					},
+				},
+			},
+			middlewareConfig: map[string]*runtime.UDPMiddlewareInfo{
+				"allowlist": {
+					UDPMiddleware: &dynamic.UDPMiddleware{
+						IPAllowList: &dynamic.UDPIPAllowList{},
+					},
+				},
+			},
+			routerConfig: map[string]*runtime.UDPRouterInfo{
+				"foo": {
+					UDPRouter: &dynamic.UDPRouter{
+						EntryPoints: []string{"web"},
+						Service:     "foo-service",
+						Middlewares: []string{"allowlist"},
+					},
+				},
+			},
+			expectedError: 2,
+		},
 	}
 
 	for _, test := range testCases {
@@ -114,11 +251,13 @@ func TestRuntimeConfiguration(t *testing.T) {
 			entryPoints := []string{"web"}
 
 			conf := &runtime.Configuration{
-				UDPServices: test.serviceConfig,
-				UDPRouters:  test.routerConfig,
+				UDPServices:    test.serviceConfig,
+				UDPRouters:     test.routerConfig,
+				UDPMiddlewares: test.middlewareConfig,
 			}
 			serviceManager := udp.NewManager(conf)
-			routerManager := NewManager(conf, serviceManager)
+			middlewaresBuilder := udpmiddleware.NewBuilder(conf.UDPMiddlewares)
+			routerManager := NewManager(conf, serviceManager, middlewaresBuilder)
 
 			_ = routerManager.BuildHandlers(context.Background(), entryPoints)
 
@@ -136,6 +275,11 @@ func TestRuntimeConfiguration(t *testing.T) {
 					allErrors++
 				}
 			}
+			for _, v := range conf.UDPMiddlewares {
+				if len(v.Err) > 0 {
+					allErrors++
+				}
+			}
 			assert.Equal(t, test.expectedError, allErrors)
 		})
 	}
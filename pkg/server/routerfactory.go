@@ -7,15 +7,19 @@ import (
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/config/static"
 	"github.com/traefik/traefik/v2/pkg/metrics"
+	"github.com/traefik/traefik/v2/pkg/middlewares/retry"
 	"github.com/traefik/traefik/v2/pkg/server/middleware"
 	tcpmiddleware "github.com/traefik/traefik/v2/pkg/server/middleware/tcp"
+	udpmiddleware "github.com/traefik/traefik/v2/pkg/server/middleware/udp"
 	"github.com/traefik/traefik/v2/pkg/server/router"
 	tcprouter "github.com/traefik/traefik/v2/pkg/server/router/tcp"
 	udprouter "github.com/traefik/traefik/v2/pkg/server/router/udp"
 	"github.com/traefik/traefik/v2/pkg/server/service"
 	"github.com/traefik/traefik/v2/pkg/server/service/tcp"
 	"github.com/traefik/traefik/v2/pkg/server/service/udp"
+	"github.com/traefik/traefik/v2/pkg/sticky"
 	"github.com/traefik/traefik/v2/pkg/tls"
+	"github.com/traefik/traefik/v2/pkg/types"
 	udptypes "github.com/traefik/traefik/v2/pkg/udp"
 )
 
@@ -32,6 +36,14 @@ type RouterFactory struct {
 	chainBuilder *middleware.ChainBuilder
 	tlsManager   *tls.Manager
 
+	// locality is the location of this Traefik instance, for the locality-aware load-balancing.
+	locality *types.Locality
+	// tcpSessionTables and udpSessionTables keep the affinity tables of the TCP and UDP services across the service managers.
+	tcpSessionTables *sticky.Tables
+	udpSessionTables *sticky.Tables
+	// retryBudgets keeps the budgets of the retry middlewares across the middleware builders.
+	retryBudgets *retry.Budgets
+
 	cancelPrevState func()
 }
 
@@ -55,13 +67,17 @@ func NewRouterFactory(staticConfiguration static.Configuration, managerFactory *
 	}
 
 	return &RouterFactory{
-		entryPointsTCP:  entryPointsTCP,
-		entryPointsUDP:  entryPointsUDP,
-		managerFactory:  managerFactory,
-		metricsRegistry: metricsRegistry,
-		tlsManager:      tlsManager,
-		chainBuilder:    chainBuilder,
-		pluginBuilder:   pluginBuilder,
+		entryPointsTCP:   entryPointsTCP,
+		entryPointsUDP:   entryPointsUDP,
+		managerFactory:   managerFactory,
+		metricsRegistry:  metricsRegistry,
+		tlsManager:       tlsManager,
+		chainBuilder:     chainBuilder,
+		pluginBuilder:    pluginBuilder,
+		locality:         staticConfiguration.Locality,
+		tcpSessionTables: sticky.NewTables(),
+		udpSessionTables: sticky.NewTables(),
+		retryBudgets:     retry.NewBudgets(),
 	}
 }
 
@@ -77,7 +93,13 @@ func (f *RouterFactory) CreateRouters(rtConf *runtime.Configuration) (map[string
 	// HTTP
 	serviceManager := f.managerFactory.Build(rtConf)
 
+	f.retryBudgets.Retain(func(middlewareName string) bool {
+		_, ok := rtConf.Middlewares[middlewareName]
+		return ok
+	})
+
 	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, f.pluginBuilder)
+	middlewaresBuilder.SetRetryBudgets(f.retryBudgets)
 
 	routerManager := router.NewManager(rtConf, serviceManager, middlewaresBuilder, f.chainBuilder, f.metricsRegistry)
 
@@ -88,17 +110,43 @@ func (f *RouterFactory) CreateRouters(rtConf *runtime.Configuration) (map[string
 
 	// TCP
 	svcTCPManager := tcp.NewManager(rtConf)
+	svcTCPManager.SetLocality(f.locality)
+
+	f.tcpSessionTables.Retain(func(serviceName string) bool {
+		_, ok := rtConf.TCPServices[serviceName]
+		return ok
+	})
+	svcTCPManager.SetSessionTables(f.tcpSessionTables)
 
 	middlewaresTCPBuilder := tcpmiddleware.NewBuilder(rtConf.TCPMiddlewares)
 
 	rtTCPManager := tcprouter.NewManager(rtConf, svcTCPManager, middlewaresTCPBuilder, handlersNonTLS, handlersTLS, f.tlsManager)
 	routersTCP := rtTCPManager.BuildHandlers(ctx, f.entryPointsTCP)
 
+	for entryPointName, rt := range routersTCP {
+		rt.SetHTTPMuxers(routerManager.GetMuxer(entryPointName, false), routerManager.GetMuxer(entryPointName, true))
+	}
+
+	svcTCPManager.LaunchHealthCheck(ctx)
+
 	// UDP
 	svcUDPManager := udp.NewManager(rtConf)
-	rtUDPManager := udprouter.NewManager(rtConf, svcUDPManager)
+
+	f.udpSessionTables.Retain(func(serviceName string) bool {
+		_, ok := rtConf.UDPServices[serviceName]
+		return ok
+	})
+	svcUDPManager.SetSessionTables(f.udpSessionTables)
+
+	middlewaresUDPBuilder := udpmiddleware.NewBuilder(rtConf.UDPMiddlewares)
+
+	rtUDPManager := udprouter.NewManager(rtConf, svcUDPManager, middlewaresUDPBuilder)
 	routersUDP := rtUDPManager.BuildHandlers(ctx, f.entryPointsUDP)
 
+	svcUDPManager.LaunchHealthCheck(ctx)
+
+	router.LintRouters(ctx, rtConf)
+
 	rtConf.PopulateUsedBy()
 
 	return routersTCP, routersUDP
@@ -18,12 +18,16 @@ import (
 	"github.com/pires/go-proxyproto"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/static"
 	"github.com/traefik/traefik/v2/pkg/ip"
 	"github.com/traefik/traefik/v2/pkg/logs"
 	"github.com/traefik/traefik/v2/pkg/middlewares"
+	"github.com/traefik/traefik/v2/pkg/middlewares/auth"
 	"github.com/traefik/traefik/v2/pkg/middlewares/forwardedheaders"
 	"github.com/traefik/traefik/v2/pkg/middlewares/requestdecorator"
+	httpmuxer "github.com/traefik/traefik/v2/pkg/muxer/http"
+	tcpmuxer "github.com/traefik/traefik/v2/pkg/muxer/tcp"
 	"github.com/traefik/traefik/v2/pkg/safe"
 	"github.com/traefik/traefik/v2/pkg/server/router"
 	tcprouter "github.com/traefik/traefik/v2/pkg/server/router/tcp"
@@ -123,6 +127,27 @@ func (eps TCPEntryPoints) Switch(routersTCP map[string]*tcprouter.Router) {
 	}
 }
 
+// InspectHTTP passes the request through the handlers applied before routing on the given entry point,
+// and calls inspect with the resulting request, and with the muxer routing it, if any.
+func (eps TCPEntryPoints) InspectHTTP(entryPointName string, tls bool, req *http.Request, inspect func(*http.Request, *httpmuxer.Muxer)) error {
+	entryPoint, ok := eps[entryPointName]
+	if !ok {
+		return fmt.Errorf("%s is not a TCP entry point", entryPointName)
+	}
+
+	return entryPoint.InspectHTTP(tls, req, inspect)
+}
+
+// TCPMuxer returns the muxer routing the connections, without or with TLS, on the given entry point.
+func (eps TCPEntryPoints) TCPMuxer(entryPointName string, tls bool) (*tcpmuxer.Muxer, error) {
+	entryPoint, ok := eps[entryPointName]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a TCP entry point", entryPointName)
+	}
+
+	return entryPoint.TCPMuxer(tls), nil
+}
+
 // TCPEntryPoint is the TCP server.
 type TCPEntryPoint struct {
 	listener               net.Listener
@@ -337,6 +362,47 @@ func (e *TCPEntryPoint) SwitchRouter(rt *tcprouter.Router) {
 	}
 }
 
+// InspectHTTP passes the request through the handlers applied before routing,
+// and calls inspect with the resulting request, and with the muxer routing it, if any.
+func (e *TCPEntryPoint) InspectHTTP(tls bool, req *http.Request, inspect func(*http.Request, *httpmuxer.Muxer)) error {
+	server := e.httpServer
+	var muxer *httpmuxer.Muxer
+	if rt, ok := e.switcher.GetHandler().(*tcprouter.Router); ok {
+		muxer = rt.GetHTTPMuxer()
+		if tls {
+			muxer = rt.GetHTTPSMuxer()
+		}
+	}
+
+	if tls {
+		server = e.httpsServer
+	}
+
+	var inspected bool
+	ctx := context.WithValue(req.Context(), routingInspectionKey{}, func(req *http.Request) {
+		inspected = true
+		inspect(req, muxer)
+	})
+
+	server.Handler.ServeHTTP(&inspectionResponseWriter{header: make(http.Header)}, req.WithContext(ctx))
+
+	if !inspected {
+		return errors.New("the request has been answered before routing")
+	}
+
+	return nil
+}
+
+// TCPMuxer returns the muxer routing the connections, without or with TLS.
+func (e *TCPEntryPoint) TCPMuxer(tls bool) *tcpmuxer.Muxer {
+	rt, ok := e.switcher.GetHandler().(*tcprouter.Router)
+	if !ok {
+		return nil
+	}
+
+	return rt.GetTCPMuxer(tls)
+}
+
 // writeCloserWrapper wraps together a connection, and the concrete underlying
 // connection type that was found to satisfy WriteCloser.
 type writeCloserWrapper struct {
@@ -348,6 +414,27 @@ func (c *writeCloserWrapper) CloseWrite() error {
 	return c.writeCloser.CloseWrite()
 }
 
+// ProxyTLVs returns the TLVs of the PROXY protocol v2 header of the connection, if any.
+func (c *writeCloserWrapper) ProxyTLVs() []proxyproto.TLV {
+	proxyConn, ok := c.Conn.(*proxyproto.Conn)
+	if !ok {
+		return nil
+	}
+
+	header := proxyConn.ProxyHeader()
+	if header == nil {
+		return nil
+	}
+
+	tlvs, err := header.TLVs()
+	if err != nil {
+		log.Debug().Err(err).Msg("Error while reading PROXY protocol TLVs")
+		return nil
+	}
+
+	return tlvs
+}
+
 // writeCloser returns the given connection, augmented with the WriteCloser
 // implementation, if any was found within the underlying conn.
 func writeCloser(conn net.Conn) (tcp.WriteCloser, error) {
@@ -512,6 +599,60 @@ type httpServer struct {
 	Server    stoppableServer
 	Forwarder *httpForwarder
 	Switcher  *middlewares.HTTPHandlerSwitcher
+	// Handler is the handler of the server, which applies the handlers of the entry point before routing with the Switcher.
+	Handler http.Handler
+}
+
+// routingInspectionKey is the context key of the function inspecting the routing of a request.
+type routingInspectionKey struct{}
+
+// routingInspector hands the requests carrying a routing inspection function over to it, instead of routing them,
+// so that the routing of a request can be inspected after the handlers applied before routing on the entry point.
+type routingInspector struct {
+	next http.Handler
+}
+
+func (r routingInspector) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if inspect, ok := req.Context().Value(routingInspectionKey{}).(func(*http.Request)); ok {
+		inspect(req)
+		return
+	}
+
+	r.next.ServeHTTP(rw, req)
+}
+
+// inspectionResponseWriter discards the response to a request whose routing is inspected,
+// which is only written if the request is answered before routing.
+type inspectionResponseWriter struct {
+	header http.Header
+}
+
+func (w *inspectionResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *inspectionResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *inspectionResponseWriter) WriteHeader(int) {}
+
+// jwtClaimsDecorator returns a constructor of the handler validating the bearer tokens before routing,
+// so that the JWTClaim matcher can use their claims.
+func jwtClaimsDecorator(ctx context.Context, config *static.JWTConfig) alice.Constructor {
+	return func(next http.Handler) (http.Handler, error) {
+		return auth.NewJWTClaimsDecorator(ctx, next, dynamic.JWT{
+			Secret:                 config.Secret,
+			PublicKey:              config.PublicKey,
+			JWKSURL:                config.JWKSURL,
+			JWKSRefreshInterval:    config.JWKSRefreshInterval,
+			Algorithms:             config.Algorithms,
+			Issuer:                 config.Issuer,
+			Audience:               config.Audience,
+			ClockSkew:              config.ClockSkew,
+			AllowMissingExpiration: config.AllowMissingExpiration,
+		}, "entrypoint-jwt")
+	}
 }
 
 func createHTTPServer(ctx context.Context, ln net.Listener, configuration *static.EntryPoint, withH2c bool, reqDecorator *requestdecorator.RequestDecorator) (*httpServer, error) {
@@ -521,7 +662,12 @@ func createHTTPServer(ctx context.Context, ln net.Listener, configuration *stati
 
 	httpSwitcher := middlewares.NewHandlerSwitcher(router.BuildDefaultHTTPRouter())
 
-	next, err := alice.New(requestdecorator.WrapHandler(reqDecorator)).Then(httpSwitcher)
+	chain := alice.New(requestdecorator.WrapHandler(reqDecorator))
+	if configuration.HTTP.JWT != nil {
+		chain = chain.Append(jwtClaimsDecorator(ctx, configuration.HTTP.JWT))
+	}
+
+	next, err := chain.Then(routingInspector{next: httpSwitcher})
 	if err != nil {
 		return nil, err
 	}
@@ -576,6 +722,7 @@ func createHTTPServer(ctx context.Context, ln net.Listener, configuration *stati
 		Server:    serverHTTP,
 		Forwarder: listener,
 		Switcher:  httpSwitcher,
+		Handler:   handler,
 	}, nil
 }
 
@@ -596,3 +743,12 @@ func (t *trackedConnection) Close() error {
 	t.tracker.RemoveConnection(t.WriteCloser)
 	return t.WriteCloser.Close()
 }
+
+// ProxyTLVs returns the TLVs of the PROXY protocol v2 header of the connection, if any.
+func (t *trackedConnection) ProxyTLVs() []proxyproto.TLV {
+	if proxyConn, ok := t.WriteCloser.(tcp.ProxyProtocolConn); ok {
+		return proxyConn.ProxyTLVs()
+	}
+
+	return nil
+}
@@ -7,14 +7,17 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v2/pkg/config/static"
+	httpmuxer "github.com/traefik/traefik/v2/pkg/muxer/http"
 	tcprouter "github.com/traefik/traefik/v2/pkg/server/router/tcp"
 	"github.com/traefik/traefik/v2/pkg/tcp"
 )
@@ -47,7 +50,7 @@ func TestShutdownTCP(t *testing.T) {
 	router, err := tcprouter.NewRouter()
 	require.NoError(t, err)
 
-	err = router.AddRoute("HostSNI(`*`)", 0, tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+	err = router.AddRoute("foo", "HostSNI(`*`)", 0, tcp.HandlerFunc(func(conn tcp.WriteCloser) {
 		for {
 			_, err := http.ReadRequest(bufio.NewReader(conn))
 
@@ -66,6 +69,63 @@ func TestShutdownTCP(t *testing.T) {
 	testShutdown(t, router)
 }
 
+func TestTCPEntryPoint_InspectHTTP(t *testing.T) {
+	epConfig := &static.EntryPointsTransport{}
+	epConfig.SetDefaults()
+
+	entryPoint, err := NewTCPEntryPoint(context.Background(), &static.EntryPoint{
+		Address:          "127.0.0.1:0",
+		Transport:        epConfig,
+		ForwardedHeaders: &static.ForwardedHeaders{},
+		HTTP: static.HTTPConfig{
+			JWT: &static.JWTConfig{Secret: "secret"},
+		},
+		HTTP2: &static.HTTP2Config{},
+	}, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { entryPoint.Shutdown(context.Background()) })
+
+	muxer, err := httpmuxer.NewMuxer()
+	require.NoError(t, err)
+
+	err = muxer.AddNamedRoute("admin", "Host(`admin.bar`) && JWTClaim(`group`, `admin`)", 0, http.NotFoundHandler())
+	require.NoError(t, err)
+
+	muxer.SortRoutes()
+
+	router, err := tcprouter.NewRouter()
+	require.NoError(t, err)
+
+	router.SetHTTPMuxers(muxer, nil)
+	entryPoint.SwitchRouter(router)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"group": "admin",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://admin.bar/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var results []httpmuxer.MatchResult
+	err = entryPoint.InspectHTTP(false, req, func(req *http.Request, muxer *httpmuxer.Muxer) {
+		results = muxer.MatchAll(req)
+	})
+	require.NoError(t, err)
+
+	// The claims of the token are only known once the token has been validated by the entry point.
+	require.Len(t, results, 1)
+	assert.Equal(t, "admin", results[0].Name)
+	assert.True(t, results[0].Matched)
+
+	err = entryPoint.InspectHTTP(true, req, func(_ *http.Request, muxer *httpmuxer.Muxer) {
+		assert.Nil(t, muxer)
+	})
+	require.NoError(t, err)
+}
+
 func testShutdown(t *testing.T, router *tcprouter.Router) {
 	t.Helper()
 
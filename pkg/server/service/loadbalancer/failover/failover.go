@@ -4,33 +4,27 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"sync"
+	"time"
 
-	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/priority"
+	"github.com/traefik/traefik/v2/pkg/server/service/loadbalancer/wrr"
 )
 
-// Failover is an http.Handler that can forward requests to the fallback handler
-// when the main handler status is down.
+// Failover is an http.Handler that forwards requests to the first eligible tier of handlers,
+// among tiers ordered by priority.
+// A tier is eligible when at least its minimum number of handlers have an UP status.
 type Failover struct {
 	wantsHealthCheck bool
-	handler          http.Handler
-	fallbackHandler  http.Handler
-	// updaters is the list of hooks that are run (to update the Failover
-	// parent(s)), whenever the Failover status changes.
-	updaters []func(bool)
-
-	handlerStatusMu sync.RWMutex
-	handlerStatus   bool
-
-	fallbackStatusMu sync.RWMutex
-	fallbackStatus   bool
+	selector         *priority.Selector
+	tiers            []*wrr.Balancer
 }
 
-// New creates a new Failover handler.
-func New(hc *dynamic.HealthCheck) *Failover {
+// New creates a new Failover handler, failing back to a higher priority tier after the given delay.
+func New(hc *dynamic.HealthCheck, failbackDelay time.Duration) *Failover {
 	return &Failover{
 		wantsHealthCheck: hc != nil,
+		selector:         priority.NewSelector(failbackDelay),
 	}
 }
 
@@ -42,99 +36,43 @@ func (f *Failover) RegisterStatusUpdater(fn func(up bool)) error {
 		return errors.New("healthCheck not enabled in config for this failover service")
 	}
 
-	f.updaters = append(f.updaters, fn)
+	f.selector.RegisterStatusUpdater(fn)
 
 	return nil
 }
 
 func (f *Failover) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	f.handlerStatusMu.RLock()
-	handlerStatus := f.handlerStatus
-	f.handlerStatusMu.RUnlock()
-
-	if handlerStatus {
-		f.handler.ServeHTTP(w, req)
-		return
-	}
-
-	f.fallbackStatusMu.RLock()
-	fallbackStatus := f.fallbackStatus
-	f.fallbackStatusMu.RUnlock()
-
-	if fallbackStatus {
-		f.fallbackHandler.ServeHTTP(w, req)
+	tier, ok := f.selector.Active()
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
 		return
 	}
 
-	http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	f.tiers[tier].ServeHTTP(w, req)
 }
 
-// SetHandler sets the main http.Handler.
-func (f *Failover) SetHandler(handler http.Handler) {
-	f.handlerStatusMu.Lock()
-	defer f.handlerStatusMu.Unlock()
+// AddTier appends a tier of lower priority than the existing ones, and returns its index.
+// The tier is eligible when at least minHealthy of its handlers have an UP status.
+// Not thread safe.
+func (f *Failover) AddTier(minHealthy int) int {
+	f.tiers = append(f.tiers, wrr.New(nil, dynamic.BalancerStrategyRoundRobin, true))
 
-	f.handler = handler
-	f.handlerStatus = true
+	return f.selector.AddTier(minHealthy)
 }
 
-// SetHandlerStatus sets the main handler status.
-func (f *Failover) SetHandlerStatus(ctx context.Context, up bool) {
-	f.handlerStatusMu.Lock()
-	defer f.handlerStatusMu.Unlock()
-
-	status := "DOWN"
-	if up {
-		status = "UP"
-	}
-
-	if up == f.handlerStatus {
-		// We're still with the same status, no need to propagate.
-		log.Ctx(ctx).Debug().Msgf("Still %s, no need to propagate", status)
+// AddHandler adds a handler to the given tier.
+// A handler with a non-positive weight is ignored.
+func (f *Failover) AddHandler(tier int, name string, handler http.Handler, weight *int) {
+	if weight != nil && *weight <= 0 {
 		return
 	}
 
-	log.Ctx(ctx).Debug().Msgf("Propagating new %s status", status)
-	f.handlerStatus = up
-
-	for _, fn := range f.updaters {
-		// Failover service status is set to DOWN
-		// when main and fallback handlers have a DOWN status.
-		fn(f.handlerStatus || f.fallbackStatus)
-	}
-}
-
-// SetFallbackHandler sets the fallback http.Handler.
-func (f *Failover) SetFallbackHandler(handler http.Handler) {
-	f.fallbackStatusMu.Lock()
-	defer f.fallbackStatusMu.Unlock()
-
-	f.fallbackHandler = handler
-	f.fallbackStatus = true
+	f.tiers[tier].Add(name, handler, weight)
+	f.selector.AddService(tier, name)
 }
 
-// SetFallbackHandlerStatus sets the fallback handler status.
-func (f *Failover) SetFallbackHandlerStatus(ctx context.Context, up bool) {
-	f.fallbackStatusMu.Lock()
-	defer f.fallbackStatusMu.Unlock()
-
-	status := "DOWN"
-	if up {
-		status = "UP"
-	}
-
-	if up == f.fallbackStatus {
-		// We're still with the same status, no need to propagate.
-		log.Ctx(ctx).Debug().Msgf("Still %s, no need to propagate", status)
-		return
-	}
-
-	log.Ctx(ctx).Debug().Msgf("Propagating new %s status", status)
-	f.fallbackStatus = up
-
-	for _, fn := range f.updaters {
-		// Failover service status is set to DOWN
-		// when main and fallback handlers have a DOWN status.
-		fn(f.handlerStatus || f.fallbackStatus)
-	}
+// SetHandlerStatus sets the status of the given handler of the given tier.
+func (f *Failover) SetHandlerStatus(ctx context.Context, tier int, name string, up bool) {
+	f.tiers[tier].SetStatus(ctx, name, up)
+	f.selector.SetStatus(ctx, tier, name, up)
 }
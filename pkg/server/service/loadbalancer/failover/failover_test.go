@@ -26,22 +26,15 @@ func (r *responseRecorder) WriteHeader(statusCode int) {
 }
 
 func TestFailover(t *testing.T) {
-	failover := New(&dynamic.HealthCheck{})
+	failover := New(&dynamic.HealthCheck{}, 0)
 
 	status := true
 	require.NoError(t, failover.RegisterStatusUpdater(func(up bool) {
 		status = up
 	}))
 
-	failover.SetHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		rw.Header().Set("server", "handler")
-		rw.WriteHeader(http.StatusOK)
-	}))
-
-	failover.SetFallbackHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		rw.Header().Set("server", "fallback")
-		rw.WriteHeader(http.StatusOK)
-	}))
+	failover.AddHandler(failover.AddTier(1), "handler", newHandler("handler"), nil)
+	failover.AddHandler(failover.AddTier(1), "fallback", newHandler("fallback"), nil)
 
 	recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
 	failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -51,7 +44,7 @@ func TestFailover(t *testing.T) {
 	assert.Equal(t, []int{200}, recorder.status)
 	assert.True(t, status)
 
-	failover.SetHandlerStatus(context.Background(), false)
+	failover.SetHandlerStatus(context.Background(), 0, "handler", false)
 
 	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
 	failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -61,7 +54,7 @@ func TestFailover(t *testing.T) {
 	assert.Equal(t, []int{200}, recorder.status)
 	assert.True(t, status)
 
-	failover.SetFallbackHandlerStatus(context.Background(), false)
+	failover.SetHandlerStatus(context.Background(), 1, "fallback", false)
 
 	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
 	failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -73,17 +66,10 @@ func TestFailover(t *testing.T) {
 }
 
 func TestFailoverDownThenUp(t *testing.T) {
-	failover := New(nil)
-
-	failover.SetHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		rw.Header().Set("server", "handler")
-		rw.WriteHeader(http.StatusOK)
-	}))
+	failover := New(nil, 0)
 
-	failover.SetFallbackHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		rw.Header().Set("server", "fallback")
-		rw.WriteHeader(http.StatusOK)
-	}))
+	failover.AddHandler(failover.AddTier(1), "handler", newHandler("handler"), nil)
+	failover.AddHandler(failover.AddTier(1), "fallback", newHandler("fallback"), nil)
 
 	recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
 	failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -92,7 +78,7 @@ func TestFailoverDownThenUp(t *testing.T) {
 	assert.Equal(t, 0, recorder.save["fallback"])
 	assert.Equal(t, []int{200}, recorder.status)
 
-	failover.SetHandlerStatus(context.Background(), false)
+	failover.SetHandlerStatus(context.Background(), 0, "handler", false)
 
 	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
 	failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -101,7 +87,7 @@ func TestFailoverDownThenUp(t *testing.T) {
 	assert.Equal(t, 1, recorder.save["fallback"])
 	assert.Equal(t, []int{200}, recorder.status)
 
-	failover.SetHandlerStatus(context.Background(), true)
+	failover.SetHandlerStatus(context.Background(), 0, "handler", true)
 
 	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
 	failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -112,24 +98,15 @@ func TestFailoverDownThenUp(t *testing.T) {
 }
 
 func TestFailoverPropagate(t *testing.T) {
-	failover := New(&dynamic.HealthCheck{})
-	failover.SetHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		rw.Header().Set("server", "handler")
-		rw.WriteHeader(http.StatusOK)
-	}))
-	failover.SetFallbackHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		rw.Header().Set("server", "fallback")
-		rw.WriteHeader(http.StatusOK)
-	}))
+	failover := New(&dynamic.HealthCheck{}, 0)
+	failover.AddHandler(failover.AddTier(1), "handler", newHandler("handler"), nil)
+	failover.AddHandler(failover.AddTier(1), "fallback", newHandler("fallback"), nil)
 
-	topFailover := New(nil)
-	topFailover.SetHandler(failover)
-	topFailover.SetFallbackHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		rw.Header().Set("server", "topFailover")
-		rw.WriteHeader(http.StatusOK)
-	}))
+	topFailover := New(nil, 0)
+	topFailover.AddHandler(topFailover.AddTier(1), "failover", failover, nil)
+	topFailover.AddHandler(topFailover.AddTier(1), "topFailover", newHandler("topFailover"), nil)
 	err := failover.RegisterStatusUpdater(func(up bool) {
-		topFailover.SetHandlerStatus(context.Background(), up)
+		topFailover.SetHandlerStatus(context.Background(), 0, "failover", up)
 	})
 	require.NoError(t, err)
 
@@ -141,7 +118,7 @@ func TestFailoverPropagate(t *testing.T) {
 	assert.Equal(t, 0, recorder.save["topFailover"])
 	assert.Equal(t, []int{200}, recorder.status)
 
-	failover.SetHandlerStatus(context.Background(), false)
+	failover.SetHandlerStatus(context.Background(), 0, "handler", false)
 
 	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
 	topFailover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -151,7 +128,7 @@ func TestFailoverPropagate(t *testing.T) {
 	assert.Equal(t, 0, recorder.save["topFailover"])
 	assert.Equal(t, []int{200}, recorder.status)
 
-	failover.SetFallbackHandlerStatus(context.Background(), false)
+	failover.SetHandlerStatus(context.Background(), 1, "fallback", false)
 
 	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
 	topFailover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -161,3 +138,69 @@ func TestFailoverPropagate(t *testing.T) {
 	assert.Equal(t, 1, recorder.save["topFailover"])
 	assert.Equal(t, []int{200}, recorder.status)
 }
+
+func TestFailoverTiers(t *testing.T) {
+	failover := New(nil, 0)
+
+	primary := failover.AddTier(2)
+	failover.AddHandler(primary, "primary1", newHandler("primary1"), nil)
+	failover.AddHandler(primary, "primary2", newHandler("primary2"), nil)
+	failover.AddHandler(primary, "primary3", newHandler("primary3"), nil)
+
+	secondary := failover.AddTier(1)
+	failover.AddHandler(secondary, "secondary1", newHandler("secondary1"), nil)
+	failover.AddHandler(secondary, "secondary2", newHandler("secondary2"), nil)
+
+	failover.AddHandler(failover.AddTier(1), "tertiary", newHandler("tertiary"), nil)
+
+	recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 3; i++ {
+		failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, map[string]int{"primary1": 1, "primary2": 1, "primary3": 1}, recorder.save)
+
+	// The primary tier still has its minimum of healthy handlers.
+	failover.SetHandlerStatus(context.Background(), primary, "primary1", false)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, map[string]int{"primary2": 2, "primary3": 2}, recorder.save)
+
+	// The primary tier is below its minimum of healthy handlers.
+	failover.SetHandlerStatus(context.Background(), primary, "primary2", false)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, map[string]int{"secondary1": 2, "secondary2": 2}, recorder.save)
+
+	failover.SetHandlerStatus(context.Background(), secondary, "secondary1", false)
+	failover.SetHandlerStatus(context.Background(), secondary, "secondary2", false)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, map[string]int{"tertiary": 1}, recorder.save)
+
+	failover.SetHandlerStatus(context.Background(), primary, "primary1", true)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 2; i++ {
+		failover.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, map[string]int{"primary1": 1, "primary3": 1}, recorder.save)
+}
+
+func newHandler(name string) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("server", name)
+		rw.WriteHeader(http.StatusOK)
+	})
+}
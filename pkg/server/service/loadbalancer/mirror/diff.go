@@ -0,0 +1,240 @@
+package mirror
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"math/rand"
+	"net"
+	"net/http"
+	"sort"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+// Names of the compared fields, as reported in the metrics and logs.
+const (
+	fieldStatus  = "status"
+	fieldHeaders = "headers"
+	fieldBody    = "body"
+)
+
+type metricsMirror interface {
+	ServiceMirrorMismatchesCounter() gokitmetrics.Counter
+}
+
+// differ compares the responses of the mirrors with the one of the mirrored service,
+// and reports the mismatches.
+type differ struct {
+	serviceName    string
+	headers        []string
+	ignoredHeaders map[string]struct{}
+	ignoreBody     bool
+	logSampleRate  float64
+	metrics        metricsMirror
+
+	random func() float64
+}
+
+func newDiffer(serviceName string, config *dynamic.MirroringDiff, metrics metricsMirror) *differ {
+	d := &differ{
+		serviceName:    serviceName,
+		ignoredHeaders: make(map[string]struct{}),
+		ignoreBody:     config.IgnoreBody,
+		logSampleRate:  config.LogSampleRate,
+		metrics:        metrics,
+		random:         rand.Float64,
+	}
+
+	for _, name := range config.Headers {
+		d.headers = append(d.headers, http.CanonicalHeaderKey(name))
+	}
+
+	for _, name := range config.IgnoredHeaders {
+		d.ignoredHeaders[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+
+	return d
+}
+
+// compare reports the fields of the mirror response which differ from the primary response.
+func (d *differ) compare(logger *zerolog.Logger, mirrorName string, primary, mirror *responseCapture) {
+	var fields []string
+
+	if primary.status() != mirror.status() {
+		fields = append(fields, fieldStatus)
+	}
+
+	headers := d.diffHeaders(primary.header, mirror.header)
+	if len(headers) > 0 {
+		fields = append(fields, fieldHeaders)
+	}
+
+	if !d.ignoreBody && string(primary.bodyHash.Sum(nil)) != string(mirror.bodyHash.Sum(nil)) {
+		fields = append(fields, fieldBody)
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	if d.metrics != nil {
+		for _, field := range fields {
+			d.metrics.ServiceMirrorMismatchesCounter().
+				With("service", d.serviceName, "mirror", mirrorName, "field", field).
+				Add(1)
+		}
+	}
+
+	if d.logSampleRate <= 0 || d.random() >= d.logSampleRate {
+		return
+	}
+
+	logger.Info().
+		Str("mirror", mirrorName).
+		Strs("fields", fields).
+		Int("primaryStatus", primary.status()).
+		Int("mirrorStatus", mirror.status()).
+		Strs("headers", headers).
+		Msg("Mirror response differs from the primary response")
+}
+
+// diffHeaders returns the sorted names of the compared headers which differ between the two given headers.
+// When no header names are configured, all the headers but the ignored ones are compared.
+func (d *differ) diffHeaders(primary, mirror http.Header) []string {
+	names := d.headers
+	if len(names) == 0 {
+		seen := make(map[string]struct{})
+		for _, header := range []http.Header{primary, mirror} {
+			for name := range header {
+				if _, ok := seen[name]; ok {
+					continue
+				}
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+
+	var diff []string
+	for _, name := range names {
+		if _, ok := d.ignoredHeaders[name]; ok {
+			continue
+		}
+
+		if !equalValues(primary.Values(name), mirror.Values(name)) {
+			diff = append(diff, name)
+		}
+	}
+
+	sort.Strings(diff)
+
+	return diff
+}
+
+func equalValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// responseCapture is an http.ResponseWriter which records the status code,
+// the headers and a hash of the body of a response.
+// When it wraps a ResponseWriter, the response is also written to it,
+// otherwise the response is discarded.
+type responseCapture struct {
+	rw          http.ResponseWriter
+	ownedHeader http.Header
+
+	// header is the headers of the response, as they were when the status code was written.
+	header   http.Header
+	code     int
+	bodyHash hash.Hash
+	hijacked bool
+}
+
+func newResponseCapture(rw http.ResponseWriter) *responseCapture {
+	return &responseCapture{
+		rw:          rw,
+		bodyHash:    sha256.New(),
+		ownedHeader: make(http.Header),
+	}
+}
+
+func (c *responseCapture) Header() http.Header {
+	if c.rw != nil {
+		return c.rw.Header()
+	}
+
+	return c.ownedHeader
+}
+
+func (c *responseCapture) WriteHeader(code int) {
+	if c.code == 0 {
+		c.code = code
+		c.header = c.Header().Clone()
+	}
+
+	if c.rw != nil {
+		c.rw.WriteHeader(code)
+	}
+}
+
+func (c *responseCapture) Write(data []byte) (int, error) {
+	if c.code == 0 {
+		c.WriteHeader(http.StatusOK)
+	}
+
+	c.bodyHash.Write(data)
+
+	if c.rw != nil {
+		return c.rw.Write(data)
+	}
+
+	return len(data), nil
+}
+
+func (c *responseCapture) Flush() {
+	if flusher, ok := c.rw.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (c *responseCapture) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := c.rw.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("connection on responseCapture cannot be hijacked")
+	}
+
+	c.hijacked = true
+
+	return hijacker.Hijack()
+}
+
+// status returns the status code of the response,
+// which is http.StatusOK if nothing was written.
+func (c *responseCapture) status() int {
+	if c.code == 0 {
+		return http.StatusOK
+	}
+
+	return c.code
+}
+
+// finish records the headers of the response if nothing was written.
+func (c *responseCapture) finish() {
+	if c.header == nil {
+		c.header = c.Header().Clone()
+	}
+}
@@ -0,0 +1,178 @@
+package mirror
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/safe"
+)
+
+type response struct {
+	status  int
+	headers map[string]string
+	body    string
+}
+
+func (r response) handler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		for name, value := range r.headers {
+			rw.Header().Set(name, value)
+		}
+		rw.WriteHeader(r.status)
+		_, _ = rw.Write([]byte(r.body))
+	})
+}
+
+func TestMirroringDiff(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		config             dynamic.MirroringDiff
+		primary            response
+		mirror             response
+		expectedMismatches map[string]float64
+	}{
+		{
+			desc:    "same responses",
+			primary: response{status: http.StatusOK, headers: map[string]string{"X-Foo": "bar"}, body: "foo"},
+			mirror:  response{status: http.StatusOK, headers: map[string]string{"X-Foo": "bar"}, body: "foo"},
+		},
+		{
+			desc:               "different status",
+			primary:            response{status: http.StatusOK, body: "foo"},
+			mirror:             response{status: http.StatusInternalServerError, body: "foo"},
+			expectedMismatches: map[string]float64{"mirror/status": 1},
+		},
+		{
+			desc:               "different header",
+			primary:            response{status: http.StatusOK, headers: map[string]string{"X-Foo": "bar"}},
+			mirror:             response{status: http.StatusOK, headers: map[string]string{"X-Foo": "baz"}},
+			expectedMismatches: map[string]float64{"mirror/headers": 1},
+		},
+		{
+			desc:               "missing header",
+			primary:            response{status: http.StatusOK, headers: map[string]string{"X-Foo": "bar"}},
+			mirror:             response{status: http.StatusOK},
+			expectedMismatches: map[string]float64{"mirror/headers": 1},
+		},
+		{
+			desc:    "different ignored header",
+			config:  dynamic.MirroringDiff{IgnoredHeaders: []string{"date"}},
+			primary: response{status: http.StatusOK, headers: map[string]string{"Date": "Mon, 01 Jan 2024 00:00:00 GMT"}},
+			mirror:  response{status: http.StatusOK, headers: map[string]string{"Date": "Mon, 01 Jan 2024 00:00:01 GMT"}},
+		},
+		{
+			desc:    "different header not compared",
+			config:  dynamic.MirroringDiff{Headers: []string{"x-bar"}},
+			primary: response{status: http.StatusOK, headers: map[string]string{"X-Foo": "bar", "X-Bar": "foo"}},
+			mirror:  response{status: http.StatusOK, headers: map[string]string{"X-Foo": "baz", "X-Bar": "foo"}},
+		},
+		{
+			desc:               "different compared header",
+			config:             dynamic.MirroringDiff{Headers: []string{"x-bar"}},
+			primary:            response{status: http.StatusOK, headers: map[string]string{"X-Bar": "foo"}},
+			mirror:             response{status: http.StatusOK, headers: map[string]string{"X-Bar": "bar"}},
+			expectedMismatches: map[string]float64{"mirror/headers": 1},
+		},
+		{
+			desc:               "different body",
+			primary:            response{status: http.StatusOK, body: "foo"},
+			mirror:             response{status: http.StatusOK, body: "bar"},
+			expectedMismatches: map[string]float64{"mirror/body": 1},
+		},
+		{
+			desc:    "different ignored body",
+			config:  dynamic.MirroringDiff{IgnoreBody: true},
+			primary: response{status: http.StatusOK, body: "foo"},
+			mirror:  response{status: http.StatusOK, body: "bar"},
+		},
+		{
+			desc:               "everything different",
+			primary:            response{status: http.StatusOK, headers: map[string]string{"X-Foo": "bar"}, body: "foo"},
+			mirror:             response{status: http.StatusNotFound, body: "bar"},
+			expectedMismatches: map[string]float64{"mirror/status": 1, "mirror/headers": 1, "mirror/body": 1},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			pool := safe.NewPool(context.Background())
+			metrics := &mismatchesMetrics{mismatches: make(map[string]float64)}
+
+			mirror := New(test.primary.handler(), pool, defaultMaxBodySize, nil)
+			require.NoError(t, mirror.AddMirror("mirror", test.mirror.handler(), 100))
+			mirror.SetDiff("service", &test.config, metrics)
+
+			recorder := httptest.NewRecorder()
+			mirror.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			pool.Stop()
+
+			// The primary response is still sent to the client.
+			assert.Equal(t, test.primary.status, recorder.Code)
+			assert.Equal(t, test.primary.body, recorder.Body.String())
+
+			expected := test.expectedMismatches
+			if expected == nil {
+				expected = map[string]float64{}
+			}
+			assert.Equal(t, expected, metrics.mismatches)
+		})
+	}
+}
+
+func TestMirroringDiff_logSampling(t *testing.T) {
+	d := newDiffer("service", &dynamic.MirroringDiff{LogSampleRate: 0.5}, nil)
+
+	var draws int
+	d.random = func() float64 {
+		draws++
+		return 0.7
+	}
+
+	primary := newResponseCapture(nil)
+	primary.WriteHeader(http.StatusOK)
+
+	mirrored := newResponseCapture(nil)
+	mirrored.WriteHeader(http.StatusNotFound)
+
+	logger := zerolog.Nop()
+	d.compare(&logger, "mirror", primary, mirrored)
+	assert.Equal(t, 1, draws)
+
+	// No sampling when the responses do not differ.
+	d.compare(&logger, "mirror", primary, primary)
+	assert.Equal(t, 1, draws)
+}
+
+// mismatchesMetrics records the mismatches counts by mirror and field.
+type mismatchesMetrics struct {
+	mismatches map[string]float64
+	labels     []string
+}
+
+func (m *mismatchesMetrics) ServiceMirrorMismatchesCounter() gokitmetrics.Counter {
+	return m
+}
+
+func (m *mismatchesMetrics) With(labelValues ...string) gokitmetrics.Counter {
+	return &mismatchesMetrics{mismatches: m.mismatches, labels: labelValues}
+}
+
+func (m *mismatchesMetrics) Add(delta float64) {
+	labels := make(map[string]string)
+	for i := 0; i+1 < len(m.labels); i += 2 {
+		labels[m.labels[i]] = m.labels[i+1]
+	}
+
+	m.mismatches[labels["mirror"]+"/"+labels["field"]] += delta
+}
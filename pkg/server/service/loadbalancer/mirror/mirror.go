@@ -27,6 +27,7 @@ type Mirroring struct {
 
 	maxBodySize      int64
 	wantsHealthCheck bool
+	differ           *differ
 
 	lock  sync.RWMutex
 	total uint64
@@ -52,16 +53,17 @@ func (m *Mirroring) inc() uint64 {
 
 type mirrorHandler struct {
 	http.Handler
+	name    string
 	percent int
 
 	lock  sync.RWMutex
 	count uint64
 }
 
-func (m *Mirroring) getActiveMirrors() []http.Handler {
+func (m *Mirroring) getActiveMirrors() []*mirrorHandler {
 	total := m.inc()
 
-	var mirrors []http.Handler
+	var mirrors []*mirrorHandler
 	for _, handler := range m.mirrorHandlers {
 		handler.lock.Lock()
 		if handler.count*100 < total*uint64(handler.percent) {
@@ -97,8 +99,18 @@ func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	var primary *responseCapture
+	if m.differ != nil {
+		primary = newResponseCapture(rw)
+		rw = primary
+	}
+
 	m.handler.ServeHTTP(rw, rr.clone(req.Context()))
 
+	if primary != nil {
+		primary.finish()
+	}
+
 	select {
 	case <-req.Context().Done():
 		// No mirroring if request has been canceled during main handler ServeHTTP
@@ -124,20 +136,41 @@ func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 			// which would trigger a cancellation of the ongoing mirrored requests.
 			// Therefore, we give a new, non-cancellable context  to each of the mirrored calls,
 			// so they can terminate by themselves.
-			handler.ServeHTTP(m.rw, r.WithContext(contextStopPropagation{ctx}))
+			if primary == nil || primary.hijacked {
+				handler.ServeHTTP(m.rw, r.WithContext(contextStopPropagation{ctx}))
+				continue
+			}
+
+			mirrored := newResponseCapture(nil)
+			handler.ServeHTTP(mirrored, r.WithContext(contextStopPropagation{ctx}))
+			mirrored.finish()
+
+			m.differ.compare(logger, handler.name, primary, mirrored)
 		}
 	})
 }
 
 // AddMirror adds an httpHandler to mirror to.
-func (m *Mirroring) AddMirror(handler http.Handler, percent int) error {
+func (m *Mirroring) AddMirror(name string, handler http.Handler, percent int) error {
 	if percent < 0 || percent > 100 {
 		return errors.New("percent must be between 0 and 100")
 	}
-	m.mirrorHandlers = append(m.mirrorHandlers, &mirrorHandler{Handler: handler, percent: percent})
+	m.mirrorHandlers = append(m.mirrorHandlers, &mirrorHandler{Handler: handler, name: name, percent: percent})
 	return nil
 }
 
+// SetDiff enables the comparison of the mirror responses with the response of the mirrored service.
+// The mismatches are reported as metrics, and as log entries sampled according to the configuration.
+// Not thread safe.
+func (m *Mirroring) SetDiff(serviceName string, config *dynamic.MirroringDiff, metrics metricsMirror) {
+	if config == nil {
+		m.differ = nil
+		return
+	}
+
+	m.differ = newDiffer(serviceName, config, metrics)
+}
+
 // RegisterStatusUpdater adds fn to the list of hooks that are run when the
 // status of handler of the Mirroring changes.
 // Not thread safe.
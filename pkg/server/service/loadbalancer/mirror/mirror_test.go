@@ -22,12 +22,12 @@ func TestMirroringOn100(t *testing.T) {
 	})
 	pool := safe.NewPool(context.Background())
 	mirror := New(handler, pool, defaultMaxBodySize, nil)
-	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		atomic.AddInt32(&countMirror1, 1)
 	}), 10)
 	assert.NoError(t, err)
 
-	err = mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err = mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		atomic.AddInt32(&countMirror2, 1)
 	}), 50)
 	assert.NoError(t, err)
@@ -51,12 +51,12 @@ func TestMirroringOn10(t *testing.T) {
 	})
 	pool := safe.NewPool(context.Background())
 	mirror := New(handler, pool, defaultMaxBodySize, nil)
-	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		atomic.AddInt32(&countMirror1, 1)
 	}), 10)
 	assert.NoError(t, err)
 
-	err = mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err = mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		atomic.AddInt32(&countMirror2, 1)
 	}), 50)
 	assert.NoError(t, err)
@@ -75,16 +75,16 @@ func TestMirroringOn10(t *testing.T) {
 
 func TestInvalidPercent(t *testing.T) {
 	mirror := New(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), safe.NewPool(context.Background()), defaultMaxBodySize, nil)
-	err := mirror.AddMirror(nil, -1)
+	err := mirror.AddMirror("mirror", nil, -1)
 	assert.Error(t, err)
 
-	err = mirror.AddMirror(nil, 101)
+	err = mirror.AddMirror("mirror", nil, 101)
 	assert.Error(t, err)
 
-	err = mirror.AddMirror(nil, 100)
+	err = mirror.AddMirror("mirror", nil, 100)
 	assert.NoError(t, err)
 
-	err = mirror.AddMirror(nil, 0)
+	err = mirror.AddMirror("mirror", nil, 0)
 	assert.NoError(t, err)
 }
 
@@ -96,7 +96,7 @@ func TestHijack(t *testing.T) {
 	mirror := New(handler, pool, defaultMaxBodySize, nil)
 
 	var mirrorRequest bool
-	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		hijacker, ok := rw.(http.Hijacker)
 		assert.Equal(t, true, ok)
 
@@ -120,7 +120,7 @@ func TestFlush(t *testing.T) {
 	mirror := New(handler, pool, defaultMaxBodySize, nil)
 
 	var mirrorRequest bool
-	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		hijacker, ok := rw.(http.Flusher)
 		assert.Equal(t, true, ok)
 
@@ -157,7 +157,7 @@ func TestMirroringWithBody(t *testing.T) {
 	mirror := New(handler, pool, defaultMaxBodySize, nil)
 
 	for i := 0; i < numMirrors; i++ {
-		err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 			assert.NotNil(t, r.Body)
 			bb, err := io.ReadAll(r.Body)
 			assert.NoError(t, err)
@@ -0,0 +1,191 @@
+package wrr
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+// hashReplicas is the number of points on the hash ring of a server of weight 1.
+const hashReplicas = 160
+
+// hashKeyFunc extracts the hash key from the request.
+// It returns false when the request does not hold any key.
+type hashKeyFunc func(req *http.Request) (string, bool)
+
+type ringPoint struct {
+	hash    uint64
+	handler *namedHandler
+}
+
+// NewConsistentHash creates a new load balancer picking the servers with consistent hashing.
+// The servers are placed on a hash ring, and a request is sent to the first healthy server following its hash key on the ring,
+// unless the load of that server is above the configured bound.
+func NewConsistentHash(sticky *dynamic.Sticky, config *dynamic.ConsistentHash, wantHealthCheck bool) (*Balancer, error) {
+	if config == nil {
+		config = &dynamic.ConsistentHash{}
+		config.SetDefaults()
+	}
+
+	if config.BoundedLoad != 0 && config.BoundedLoad < 100 {
+		return nil, fmt.Errorf("bounded load must be at least 100%%, got %d%%", config.BoundedLoad)
+	}
+
+	hashKey, err := newHashKeyFunc(config)
+	if err != nil {
+		return nil, err
+	}
+
+	balancer := New(sticky, dynamic.BalancerStrategyConsistentHash, wantHealthCheck)
+	balancer.hashKey = hashKey
+	balancer.boundedLoad = float64(config.BoundedLoad) / 100
+
+	return balancer, nil
+}
+
+func newHashKeyFunc(config *dynamic.ConsistentHash) (hashKeyFunc, error) {
+	var sources int
+	for _, defined := range []bool{config.Header != "", config.Cookie != "", config.Query != "", config.Path, config.IPStrategy != nil} {
+		if defined {
+			sources++
+		}
+	}
+
+	if sources > 1 {
+		return nil, errors.New("header, cookie, query, path, and ipStrategy are mutually exclusive")
+	}
+
+	switch {
+	case config.Header != "":
+		return func(req *http.Request) (string, bool) {
+			value := req.Header.Get(config.Header)
+			return value, value != ""
+		}, nil
+
+	case config.Cookie != "":
+		return func(req *http.Request) (string, bool) {
+			cookie, err := req.Cookie(config.Cookie)
+			if err != nil || cookie.Value == "" {
+				return "", false
+			}
+			return cookie.Value, true
+		}, nil
+
+	case config.Query != "":
+		return func(req *http.Request) (string, bool) {
+			value := req.URL.Query().Get(config.Query)
+			return value, value != ""
+		}, nil
+
+	case config.Path:
+		return func(req *http.Request) (string, bool) {
+			return req.URL.Path, true
+		}, nil
+	}
+
+	strategy, err := config.IPStrategy.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(req *http.Request) (string, bool) {
+		value := strategy.GetIP(req)
+		return value, value != ""
+	}, nil
+}
+
+// hashServer returns the eligible server for the given hash key, or nil if there is no eligible server.
+// As the ring holds all the servers, whatever their status, flipping the status of a server
+// only moves the keys of this server.
+// It must be called with the mutex held.
+func (b *Balancer) hashServer(key string, eligible func(handler *namedHandler) bool) *namedHandler {
+	if b.ring == nil {
+		b.ring = buildRing(b.handlers)
+	}
+
+	if len(b.ring) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	var totalLoad, totalWeight float64
+	for _, handler := range b.handlers {
+		if eligible(handler) {
+			totalLoad += float64(atomic.LoadInt64(&handler.inflight))
+			totalWeight += b.weight(handler, now)
+		}
+	}
+
+	keyHash := hash(key)
+	start := sort.Search(len(b.ring), func(i int) bool { return b.ring[i].hash >= keyHash })
+
+	var fallback *namedHandler
+	for i := 0; i < len(b.ring); i++ {
+		handler := b.ring[(start+i)%len(b.ring)].handler
+		if !eligible(handler) {
+			continue
+		}
+
+		if b.boundedLoad == 0 {
+			return handler
+		}
+
+		if fallback == nil {
+			fallback = handler
+		}
+
+		// Each server accepts up to its share of the total load, including the new request, times the bound.
+		capacity := math.Ceil(b.boundedLoad * (totalLoad + 1) * b.weight(handler, now) / totalWeight)
+		if float64(atomic.LoadInt64(&handler.inflight))+1 <= capacity {
+			return handler
+		}
+	}
+
+	return fallback
+}
+
+// buildRing returns the hash ring of the given servers,
+// where each server has a number of points proportional to its weight.
+func buildRing(handlers []*namedHandler) []ringPoint {
+	ring := make([]ringPoint, 0)
+	for _, handler := range handlers {
+		replicas := int(math.Ceil(hashReplicas * handler.weight))
+		for i := 0; i < replicas; i++ {
+			ring = append(ring, ringPoint{hash: hash(handler.name + "-" + strconv.Itoa(i)), handler: handler})
+		}
+	}
+
+	// The servers are sorted by name for equal hashes, so that the ring does not depend on the order the servers were added.
+	sort.Slice(ring, func(i, j int) bool {
+		if ring[i].hash == ring[j].hash {
+			return ring[i].handler.name < ring[j].handler.name
+		}
+		return ring[i].hash < ring[j].hash
+	})
+
+	return ring
+}
+
+// hash returns the FNV-1a hash of the given key, with its bits mixed to spread similar keys over the ring.
+func hash(key string) uint64 {
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(key)) // this will never return an error.
+
+	h := hasher.Sum64()
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+
+	return h
+}
@@ -0,0 +1,301 @@
+package wrr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+func TestNewConsistentHash(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		config        *dynamic.ConsistentHash
+		expectedError string
+	}{
+		{
+			desc: "default configuration",
+		},
+		{
+			desc:   "header",
+			config: &dynamic.ConsistentHash{Header: "X-Key", BoundedLoad: 150},
+		},
+		{
+			desc:          "several sources",
+			config:        &dynamic.ConsistentHash{Header: "X-Key", Path: true},
+			expectedError: "header, cookie, query, path, and ipStrategy are mutually exclusive",
+		},
+		{
+			desc:          "bounded load below average",
+			config:        &dynamic.ConsistentHash{BoundedLoad: 50},
+			expectedError: "bounded load must be at least 100%, got 50%",
+		},
+		{
+			desc:          "invalid excluded IPs",
+			config:        &dynamic.ConsistentHash{IPStrategy: &dynamic.IPStrategy{ExcludedIPs: []string{"foobar"}}},
+			expectedError: `parsing CIDR trusted IPs <nil>: invalid CIDR address: foobar`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			balancer, err := NewConsistentHash(nil, test.config, false)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, balancer)
+		})
+	}
+}
+
+func TestConsistentHash_hashKey(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      *dynamic.ConsistentHash
+		req         func() *http.Request
+		expectedKey string
+		expectedOk  bool
+	}{
+		{
+			desc:   "default to the client IP",
+			config: &dynamic.ConsistentHash{},
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = "10.0.0.1:1234"
+				return req
+			},
+			expectedKey: "10.0.0.1",
+			expectedOk:  true,
+		},
+		{
+			desc:   "client IP with depth",
+			config: &dynamic.ConsistentHash{IPStrategy: &dynamic.IPStrategy{Depth: 1}},
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("X-Forwarded-For", "10.0.0.2, 10.0.0.3")
+				return req
+			},
+			expectedKey: "10.0.0.3",
+			expectedOk:  true,
+		},
+		{
+			desc:   "header",
+			config: &dynamic.ConsistentHash{Header: "X-Key"},
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("X-Key", "foo")
+				return req
+			},
+			expectedKey: "foo",
+			expectedOk:  true,
+		},
+		{
+			desc:   "missing header",
+			config: &dynamic.ConsistentHash{Header: "X-Key"},
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/", nil)
+			},
+		},
+		{
+			desc:   "cookie",
+			config: &dynamic.ConsistentHash{Cookie: "session"},
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.AddCookie(&http.Cookie{Name: "session", Value: "bar"})
+				return req
+			},
+			expectedKey: "bar",
+			expectedOk:  true,
+		},
+		{
+			desc:   "missing cookie",
+			config: &dynamic.ConsistentHash{Cookie: "session"},
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/", nil)
+			},
+		},
+		{
+			desc:   "query parameter",
+			config: &dynamic.ConsistentHash{Query: "id"},
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/?id=42", nil)
+			},
+			expectedKey: "42",
+			expectedOk:  true,
+		},
+		{
+			desc:   "path",
+			config: &dynamic.ConsistentHash{Path: true},
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/foo/bar?id=42", nil)
+			},
+			expectedKey: "/foo/bar",
+			expectedOk:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			hashKey, err := newHashKeyFunc(test.config)
+			require.NoError(t, err)
+
+			key, ok := hashKey(test.req())
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedKey, key)
+		})
+	}
+}
+
+func TestConsistentHash_sameKeySameServer(t *testing.T) {
+	balancer := newHashBalancer(t, &dynamic.ConsistentHash{Header: "X-Key"}, "first", "second", "third")
+
+	servers := map[string]struct{}{}
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+
+		recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+		for j := 0; j < 3; j++ {
+			balancer.ServeHTTP(recorder, keyRequest(key))
+		}
+
+		assert.Len(t, recorder.save, 1)
+		servers[recorder.sequence[0]] = struct{}{}
+	}
+
+	assert.Len(t, servers, 3)
+}
+
+func TestConsistentHash_withoutKey(t *testing.T) {
+	balancer := newHashBalancer(t, &dynamic.ConsistentHash{Header: "X-Key"}, "first", "second")
+
+	recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, 2, recorder.save["first"])
+	assert.Equal(t, 2, recorder.save["second"])
+}
+
+func TestConsistentHash_addOrder(t *testing.T) {
+	balancer := newHashBalancer(t, &dynamic.ConsistentHash{Header: "X-Key"}, "first", "second", "third")
+	reversed := newHashBalancer(t, &dynamic.ConsistentHash{Header: "X-Key"}, "third", "second", "first")
+
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		assert.Equal(t, serverFor(balancer, key), serverFor(reversed, key))
+	}
+}
+
+func TestConsistentHash_serverDown(t *testing.T) {
+	balancer := newHashBalancer(t, &dynamic.ConsistentHash{Header: "X-Key"}, "first", "second", "third", "fourth")
+
+	before := map[string]string{}
+	for i := 0; i < 200; i++ {
+		key := strconv.Itoa(i)
+		before[key] = serverFor(balancer, key)
+	}
+
+	balancer.SetStatus(context.Background(), "second", false)
+
+	for key, server := range before {
+		after := serverFor(balancer, key)
+		if server == "second" {
+			assert.NotEqual(t, "second", after)
+			continue
+		}
+
+		// Only the keys of the server which went down are moved.
+		assert.Equal(t, server, after)
+	}
+
+	balancer.SetStatus(context.Background(), "second", true)
+
+	for key, server := range before {
+		assert.Equal(t, server, serverFor(balancer, key))
+	}
+}
+
+func TestConsistentHash_boundedLoad(t *testing.T) {
+	balancer, err := NewConsistentHash(nil, &dynamic.ConsistentHash{Header: "X-Key", BoundedLoad: 100}, false)
+	require.NoError(t, err)
+
+	entered := make(chan string, 1)
+	release := make(chan struct{})
+	handler := func(name string) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("block") != "" {
+				entered <- name
+				<-release
+			}
+			rw.Header().Set("server", name)
+			rw.WriteHeader(http.StatusOK)
+		})
+	}
+
+	balancer.Add("first", handler("first"), Int(1))
+	balancer.Add("second", handler("second"), Int(1))
+
+	home := serverFor(balancer, "foo")
+
+	done := make(chan struct{})
+	go func() {
+		req := keyRequest("foo")
+		req.Header.Set("block", "true")
+		balancer.ServeHTTP(httptest.NewRecorder(), req)
+		close(done)
+	}()
+
+	assert.Equal(t, home, <-entered)
+
+	// The server of the key is above the average load, so the next server on the ring is picked.
+	assert.NotEqual(t, home, serverFor(balancer, "foo"))
+
+	close(release)
+	<-done
+
+	assert.Equal(t, home, serverFor(balancer, "foo"))
+}
+
+func newHashBalancer(t *testing.T, config *dynamic.ConsistentHash, names ...string) *Balancer {
+	t.Helper()
+
+	balancer, err := NewConsistentHash(nil, config, false)
+	require.NoError(t, err)
+
+	for _, name := range names {
+		name := name
+		balancer.Add(name, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("server", name)
+			rw.WriteHeader(http.StatusOK)
+		}), Int(1))
+	}
+
+	return balancer
+}
+
+func keyRequest(key string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Key", key)
+	return req
+}
+
+func serverFor(balancer *Balancer, key string) string {
+	recorder := httptest.NewRecorder()
+	balancer.ServeHTTP(recorder, keyRequest(key))
+	return recorder.Header().Get("server")
+}
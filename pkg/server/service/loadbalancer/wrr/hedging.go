@@ -0,0 +1,353 @@
+package wrr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/middlewares/accesslog"
+)
+
+const (
+	// hedgingSamples is the number of the latest response latencies from which the percentile hedging delay is computed.
+	hedgingSamples = 512
+	// hedgingMinSamples is the number of response latencies to observe before using the percentile hedging delay.
+	hedgingMinSamples = 20
+	// hedgingRefreshInterval is the number of observed response latencies after which the percentile hedging delay is recomputed.
+	hedgingRefreshInterval = 16
+)
+
+type metricsHedging interface {
+	ServiceHedgedReqsCounter() gokitmetrics.Counter
+}
+
+// hedging sends a second request to another server, when the first one has not responded within a delay.
+type hedging struct {
+	serviceName string
+	methods     map[string]struct{}
+	delay       time.Duration
+	percentile  float64
+	metrics     metricsHedging
+
+	latencies *latencies
+}
+
+func newHedging(serviceName string, config *dynamic.Hedging, metrics metricsHedging) (*hedging, error) {
+	if config.Percentile < 0 || config.Percentile >= 100 {
+		return nil, fmt.Errorf("hedging percentile must be between 0 and 100, got %v", config.Percentile)
+	}
+
+	if config.Delay <= 0 && config.Percentile == 0 {
+		return nil, errors.New("hedging requires a delay or a percentile")
+	}
+
+	methods := config.Methods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead}
+	}
+
+	h := &hedging{
+		serviceName: serviceName,
+		methods:     make(map[string]struct{}),
+		delay:       time.Duration(config.Delay),
+		percentile:  config.Percentile,
+		metrics:     metrics,
+	}
+
+	for _, method := range methods {
+		h.methods[strings.ToUpper(method)] = struct{}{}
+	}
+
+	if h.percentile > 0 {
+		h.latencies = newLatencies(h.percentile)
+	}
+
+	return h, nil
+}
+
+// hedgeable returns whether the given request can be hedged,
+// i.e. whether it is a request without body, of one of the hedged methods, which is not a protocol upgrade.
+func (h *hedging) hedgeable(req *http.Request) bool {
+	if _, ok := h.methods[req.Method]; !ok {
+		return false
+	}
+
+	if req.ContentLength != 0 || (req.Body != nil && req.Body != http.NoBody) {
+		return false
+	}
+
+	return req.Header.Get("Upgrade") == ""
+}
+
+// hedgingDelay returns the delay after which the request is hedged.
+// It returns false if the request should not be hedged.
+func (h *hedging) hedgingDelay() (time.Duration, bool) {
+	if h.latencies != nil {
+		if delay, ok := h.latencies.value(); ok {
+			return delay, true
+		}
+	}
+
+	return h.delay, h.delay > 0
+}
+
+func (h *hedging) observe(latency time.Duration) {
+	if h.latencies != nil {
+		h.latencies.observe(latency)
+	}
+}
+
+func (h *hedging) countHedge(won bool) {
+	if h.metrics == nil {
+		return
+	}
+
+	result := "lost"
+	if won {
+		result = "won"
+	}
+
+	h.metrics.ServiceHedgedReqsCounter().With("service", h.serviceName, "result", result).Add(1)
+}
+
+// SetHedging enables the request hedging, reporting the hedged requests as metrics of the given service.
+func (b *Balancer) SetHedging(serviceName string, config *dynamic.Hedging, metrics metricsHedging) error {
+	h, err := newHedging(serviceName, config, metrics)
+	if err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.hedging = h
+	return nil
+}
+
+// serveHedged forwards the request to the given server,
+// and to a second server if the first one has not responded within the hedging delay.
+// The first response is written to w, and the other request is canceled.
+func (b *Balancer) serveHedged(w http.ResponseWriter, req *http.Request, server *namedHandler) {
+	race := &hedgeRace{rw: w, decided: make(chan struct{})}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	start := time.Now()
+	original := b.startAttempt(race, server, req.WithContext(ctx))
+
+	var hedge *hedgeWriter
+
+	delay, ok := b.hedging.hedgingDelay()
+	if ok {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-race.decided:
+		case <-timer.C:
+			hedge = b.startHedge(race, req.WithContext(ctx), server.name)
+		}
+	}
+
+	<-race.decided
+	winner := race.winner
+
+	if winner == original {
+		b.hedging.observe(original.decidedAt.Sub(start))
+	} else {
+		// The original request was slower than the hedged one.
+		b.hedging.observe(time.Since(start))
+	}
+
+	if hedge != nil {
+		b.hedging.countHedge(winner == hedge)
+	}
+
+	// The request ends with the winning attempt, and the losing one is canceled on return.
+	<-winner.done
+}
+
+// startHedge starts the hedged request to a server other than the given one.
+// It returns nil if there is no other server available.
+func (b *Balancer) startHedge(race *hedgeRace, req *http.Request, excluded string) *hedgeWriter {
+	server, err := b.nextServer(req, excluded)
+	if err != nil {
+		log.Ctx(req.Context()).Debug().Err(err).Msg("No hedged request")
+		return nil
+	}
+
+	// The accessLog datatable found in the request's context is not shared with the hedged request,
+	// so that both requests do not mutate it concurrently.
+	ctx := context.WithValue(req.Context(), accesslog.DataTableKey, nil)
+
+	return b.startAttempt(race, server, req.Clone(ctx))
+}
+
+// startAttempt forwards the request to the given server, in the background.
+func (b *Balancer) startAttempt(race *hedgeRace, server *namedHandler, req *http.Request) *hedgeWriter {
+	attempt := &hedgeWriter{race: race, header: make(http.Header), done: make(chan struct{})}
+
+	if b.stickyCookie != nil {
+		if cookie := b.stickyCookie.cookie(server.name); cookie != nil {
+			http.SetCookie(attempt, cookie)
+		}
+	}
+
+	go func() {
+		defer close(attempt.done)
+
+		b.serve(server, b.sessionWriter(attempt, server), req)
+
+		// An attempt which did not write anything still responded.
+		attempt.claim()
+	}()
+
+	return attempt
+}
+
+// hedgeRace decides which of the attempts of a hedged request writes its response,
+// i.e. the first one to write its headers.
+type hedgeRace struct {
+	rw http.ResponseWriter
+
+	mu     sync.Mutex
+	winner *hedgeWriter
+	// decided is closed once the winner is known.
+	decided chan struct{}
+}
+
+// hedgeWriter is the http.ResponseWriter of an attempt of a hedged request.
+// Only the response of the winning attempt is written, the other one is discarded.
+type hedgeWriter struct {
+	race   *hedgeRace
+	header http.Header
+	done   chan struct{}
+
+	decidedAt time.Time
+}
+
+// claim makes the attempt the winner if there is none yet, and returns whether it is the winner.
+func (w *hedgeWriter) claim() bool {
+	r := w.race
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.winner != nil {
+		return r.winner == w
+	}
+
+	r.winner = w
+	w.decidedAt = time.Now()
+
+	for name, values := range w.header {
+		r.rw.Header()[name] = values
+	}
+
+	close(r.decided)
+
+	return true
+}
+
+func (w *hedgeWriter) won() bool {
+	w.race.mu.Lock()
+	defer w.race.mu.Unlock()
+
+	return w.race.winner == w
+}
+
+func (w *hedgeWriter) Header() http.Header {
+	if w.won() {
+		return w.race.rw.Header()
+	}
+
+	return w.header
+}
+
+func (w *hedgeWriter) WriteHeader(code int) {
+	if w.claim() {
+		w.race.rw.WriteHeader(code)
+	}
+}
+
+func (w *hedgeWriter) Write(data []byte) (int, error) {
+	if w.claim() {
+		return w.race.rw.Write(data)
+	}
+
+	return len(data), nil
+}
+
+func (w *hedgeWriter) Flush() {
+	if !w.won() {
+		return
+	}
+
+	if flusher, ok := w.race.rw.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// latencies computes a percentile of the latest observed response latencies.
+type latencies struct {
+	percentile float64
+
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	// pending is the number of latencies observed since the percentile was last computed.
+	pending int
+	current time.Duration
+}
+
+func newLatencies(percentile float64) *latencies {
+	return &latencies{percentile: percentile, samples: make([]time.Duration, 0, hedgingSamples)}
+}
+
+func (l *latencies) observe(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.samples) < hedgingSamples {
+		l.samples = append(l.samples, latency)
+	} else {
+		l.samples[l.next] = latency
+		l.next = (l.next + 1) % hedgingSamples
+	}
+
+	l.pending++
+	if len(l.samples) < hedgingMinSamples || (l.current > 0 && l.pending < hedgingRefreshInterval) {
+		return
+	}
+
+	sorted := make([]time.Duration, len(l.samples))
+	copy(sorted, l.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	index := int(math.Ceil(l.percentile/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+
+	l.current = sorted[index]
+	l.pending = 0
+}
+
+// value returns the percentile of the observed latencies,
+// or false if not enough latencies were observed yet.
+func (l *latencies) value() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.current, l.current > 0
+}
@@ -0,0 +1,221 @@
+package wrr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/testhelpers"
+)
+
+type hedgingMetrics struct {
+	counter *testhelpers.CollectingCounter
+}
+
+func (m *hedgingMetrics) ServiceHedgedReqsCounter() gokitmetrics.Counter {
+	return m.counter
+}
+
+func TestBalancerHedging(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		method           string
+		body             string
+		firstDelay       time.Duration
+		servers          []string
+		expectedServer   string
+		expectedHedges   float64
+		expectedResult   string
+		expectedCanceled bool
+	}{
+		{
+			desc:           "fast first server",
+			method:         http.MethodGet,
+			servers:        []string{"first", "second"},
+			expectedServer: "first",
+		},
+		{
+			desc:             "slow first server",
+			method:           http.MethodGet,
+			firstDelay:       time.Second,
+			servers:          []string{"first", "second"},
+			expectedServer:   "second",
+			expectedHedges:   1,
+			expectedResult:   "won",
+			expectedCanceled: true,
+		},
+		{
+			desc:           "slow first server without other server",
+			method:         http.MethodGet,
+			firstDelay:     100 * time.Millisecond,
+			servers:        []string{"first"},
+			expectedServer: "first",
+		},
+		{
+			desc:           "method not hedged",
+			method:         http.MethodPost,
+			firstDelay:     100 * time.Millisecond,
+			servers:        []string{"first", "second"},
+			expectedServer: "first",
+		},
+		{
+			desc:           "request with body",
+			method:         http.MethodGet,
+			body:           "foo",
+			firstDelay:     100 * time.Millisecond,
+			servers:        []string{"first", "second"},
+			expectedServer: "first",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			canceled := make(chan struct{})
+
+			balancer := New(nil, "", false)
+			for _, name := range test.servers {
+				name := name
+				balancer.Add(name, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					if name == "first" && test.firstDelay > 0 {
+						select {
+						case <-time.After(test.firstDelay):
+						case <-req.Context().Done():
+							close(canceled)
+							return
+						}
+					}
+
+					rw.Header().Set("server", name)
+					rw.WriteHeader(http.StatusOK)
+					_, _ = rw.Write([]byte(name))
+				}), nil)
+			}
+
+			counter := &testhelpers.CollectingCounter{}
+			err := balancer.SetHedging("service", &dynamic.Hedging{Delay: ptypes.Duration(10 * time.Millisecond)}, &hedgingMetrics{counter: counter})
+			require.NoError(t, err)
+
+			var body io.Reader
+			if test.body != "" {
+				body = strings.NewReader(test.body)
+			}
+
+			recorder := httptest.NewRecorder()
+			balancer.ServeHTTP(recorder, httptest.NewRequest(test.method, "/", body))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, test.expectedServer, recorder.Header().Get("server"))
+			assert.Equal(t, test.expectedServer, recorder.Body.String())
+
+			assert.Equal(t, test.expectedHedges, counter.CounterValue)
+			if test.expectedHedges > 0 {
+				assert.Equal(t, []string{"service", "service", "result", test.expectedResult}, counter.LastLabelValues)
+			}
+
+			if test.expectedCanceled {
+				select {
+				case <-canceled:
+				case <-time.After(time.Second):
+					t.Error("the slow request was not canceled")
+				}
+			}
+		})
+	}
+}
+
+func TestBalancerHedging_config(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		config      dynamic.Hedging
+		expectedErr bool
+	}{
+		{
+			desc:   "delay",
+			config: dynamic.Hedging{Delay: ptypes.Duration(time.Second)},
+		},
+		{
+			desc:   "percentile",
+			config: dynamic.Hedging{Percentile: 95},
+		},
+		{
+			desc:        "no delay nor percentile",
+			config:      dynamic.Hedging{},
+			expectedErr: true,
+		},
+		{
+			desc:        "percentile too high",
+			config:      dynamic.Hedging{Percentile: 100},
+			expectedErr: true,
+		},
+		{
+			desc:        "negative percentile",
+			config:      dynamic.Hedging{Percentile: -1},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := New(nil, "", false).SetHedging("service", &test.config, nil)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHedgingDelay(t *testing.T) {
+	h, err := newHedging("service", &dynamic.Hedging{Delay: ptypes.Duration(time.Second), Percentile: 90}, nil)
+	require.NoError(t, err)
+
+	// The fixed delay is used until enough latencies are observed.
+	for i := 1; i < hedgingMinSamples; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+
+	delay, ok := h.hedgingDelay()
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+
+	h.observe(hedgingMinSamples * time.Millisecond)
+
+	delay, ok = h.hedgingDelay()
+	assert.True(t, ok)
+	assert.Equal(t, 18*time.Millisecond, delay)
+}
+
+func TestBalancerNextServerExcluded(t *testing.T) {
+	balancer := New(nil, "", false)
+	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), nil)
+	balancer.Add("second", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for i := 0; i < 4; i++ {
+		server, err := balancer.nextServer(req, "first")
+		require.NoError(t, err)
+		assert.Equal(t, "second", server.name)
+	}
+
+	balancer.SetStatus(context.Background(), "second", false)
+
+	_, err := balancer.nextServer(req, "first")
+	assert.ErrorIs(t, err, errNoAvailableServer)
+}
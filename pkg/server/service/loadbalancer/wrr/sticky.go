@@ -0,0 +1,317 @@
+package wrr
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mailgun/ttlmap"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+// maxSessionEntries is the maximum number of opaque cookie identifiers, or application sessions, remembered by a load balancer.
+const maxSessionEntries = 65536
+
+type stickyCookie struct {
+	name     string
+	secure   bool
+	httpOnly bool
+	sameSite http.SameSite
+	maxAge   int
+	path     string
+	domain   string
+
+	// ids holds the name of the server of an opaque cookie, keyed by cookie value.
+	// It is only set for opaque cookies.
+	ids   *ttlmap.TtlMap
+	idTTL int // in seconds.
+}
+
+func newStickyCookie(cookie *dynamic.Cookie) *stickyCookie {
+	sticky := &stickyCookie{
+		name:     cookie.Name,
+		secure:   cookie.Secure,
+		httpOnly: cookie.HTTPOnly,
+		sameSite: convertSameSite(cookie.SameSite),
+		maxAge:   cookie.MaxAge,
+		path:     cookie.Path,
+		domain:   cookie.Domain,
+	}
+
+	if sticky.path == "" {
+		sticky.path = "/"
+	}
+
+	if !cookie.Opaque {
+		return sticky
+	}
+
+	// This never fails, as the capacity is positive.
+	sticky.ids, _ = ttlmap.NewConcurrent(maxSessionEntries)
+	sticky.idTTL = cookie.MaxAge
+	if sticky.idTTL <= 0 {
+		sticky.idTTL = int(time.Duration(dynamic.DefaultStickySessionTimeout) / time.Second)
+	}
+
+	return sticky
+}
+
+// server returns the name of the server held by the cookie of the request, if any.
+func (s *stickyCookie) server(req *http.Request) (string, bool) {
+	cookie, err := req.Cookie(s.name)
+	if err != nil {
+		if !errors.Is(err, http.ErrNoCookie) {
+			log.Warn().Err(err).Msg("Error while reading cookie")
+		}
+		return "", false
+	}
+
+	if s.ids == nil {
+		return cookie.Value, true
+	}
+
+	name, ok := s.ids.Get(cookie.Value)
+	if !ok {
+		return "", false
+	}
+
+	// Refreshes the expiration of the entry.
+	if err := s.ids.Set(cookie.Value, name, s.idTTL); err != nil {
+		log.Error().Err(err).Msg("Error while saving sticky cookie identifier")
+	}
+
+	return name.(string), true
+}
+
+// cookie returns the cookie to send to the client, for it to stick to the given server.
+func (s *stickyCookie) cookie(serverName string) *http.Cookie {
+	value := serverName
+	if s.ids != nil {
+		id, err := newSessionID()
+		if err != nil {
+			log.Error().Err(err).Msg("Error while generating sticky cookie identifier")
+			return nil
+		}
+
+		if err := s.ids.Set(id, serverName, s.idTTL); err != nil {
+			log.Error().Err(err).Msg("Error while saving sticky cookie identifier")
+			return nil
+		}
+
+		value = id
+	}
+
+	return &http.Cookie{
+		Name:     s.name,
+		Value:    value,
+		Path:     s.path,
+		Domain:   s.domain,
+		MaxAge:   s.maxAge,
+		HttpOnly: s.httpOnly,
+		Secure:   s.secure,
+		SameSite: s.sameSite,
+	}
+}
+
+func newSessionID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+func convertSameSite(sameSite string) http.SameSite {
+	switch strings.ToLower(sameSite) {
+	case "none":
+		return http.SameSiteNoneMode
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	default:
+		return 0
+	}
+}
+
+// appSession forwards the requests of an application session to the server which issued the session identifier.
+type appSession struct {
+	cookie string
+	header string
+
+	// sessions holds the name of the server of a session identifier, keyed by session identifier.
+	sessions *ttlmap.TtlMap
+	ttl      int // in seconds.
+}
+
+func newAppSession(config *dynamic.AppSession) (*appSession, error) {
+	if (config.Cookie == "") == (config.Header == "") {
+		return nil, errors.New("exactly one of cookie and header must be defined for the application session")
+	}
+
+	session := &appSession{
+		cookie: config.Cookie,
+		header: config.Header,
+		ttl:    int(time.Duration(config.Timeout) / time.Second),
+	}
+
+	if session.ttl < 1 {
+		session.ttl = int(time.Duration(dynamic.DefaultStickySessionTimeout) / time.Second)
+	}
+
+	var err error
+	session.sessions, err = ttlmap.NewConcurrent(maxSessionEntries)
+	if err != nil {
+		return nil, fmt.Errorf("creating session table: %w", err)
+	}
+
+	return session, nil
+}
+
+// server returns the name of the server of the session held by the request, if known.
+func (s *appSession) server(req *http.Request) (string, bool) {
+	id := s.requestID(req)
+	if id == "" {
+		return "", false
+	}
+
+	name, ok := s.sessions.Get(id)
+	if !ok {
+		return "", false
+	}
+
+	// Refreshes the expiration of the entry.
+	if err := s.sessions.Set(id, name, s.ttl); err != nil {
+		log.Error().Err(err).Msg("Error while saving application session")
+	}
+
+	return name.(string), true
+}
+
+func (s *appSession) requestID(req *http.Request) string {
+	if s.header != "" {
+		return req.Header.Get(s.header)
+	}
+
+	cookie, err := req.Cookie(s.cookie)
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+// learn records the session identifier issued by the given server in its response headers, if any.
+func (s *appSession) learn(header http.Header, serverName string) {
+	var id string
+	if s.header != "" {
+		id = header.Get(s.header)
+	} else {
+		for _, cookie := range (&http.Response{Header: header}).Cookies() {
+			if cookie.Name == s.cookie && cookie.MaxAge >= 0 {
+				id = cookie.Value
+			}
+		}
+	}
+
+	if id == "" {
+		return
+	}
+
+	if err := s.sessions.Set(id, serverName, s.ttl); err != nil {
+		log.Error().Err(err).Msg("Error while saving application session")
+	}
+}
+
+// sessionResponseWriter learns the session identifier of the application session from the response headers,
+// before they are written.
+type sessionResponseWriter struct {
+	http.ResponseWriter
+
+	session    *appSession
+	serverName string
+	learned    bool
+}
+
+func (w *sessionResponseWriter) learn() {
+	if w.learned {
+		return
+	}
+
+	w.learned = true
+	w.session.learn(w.ResponseWriter.Header(), w.serverName)
+}
+
+func (w *sessionResponseWriter) WriteHeader(code int) {
+	w.learn()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *sessionResponseWriter) Write(b []byte) (int, error) {
+	w.learn()
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends any buffered data to the client.
+func (w *sessionResponseWriter) Flush() {
+	w.learn()
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hijacks the connection.
+func (w *sessionResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.learn()
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, fmt.Errorf("%T is not a http.Hijacker", w.ResponseWriter)
+}
+
+// stickyServer returns the healthy server the request sticks to, either through the sticky cookie,
+// or through the application session, if any.
+func (b *Balancer) stickyServer(req *http.Request) *namedHandler {
+	if b.stickyCookie != nil {
+		if name, ok := b.stickyCookie.server(req); ok {
+			if handler := b.healthyServer(name); handler != nil {
+				return handler
+			}
+		}
+	}
+
+	if b.appSession != nil {
+		if name, ok := b.appSession.server(req); ok {
+			if handler := b.healthyServer(name); handler != nil {
+				return handler
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *Balancer) healthyServer(name string) *namedHandler {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	if _, ok := b.status[name]; !ok {
+		return nil
+	}
+
+	for _, handler := range b.handlers {
+		if handler.name == name {
+			return handler
+		}
+	}
+
+	return nil
+}
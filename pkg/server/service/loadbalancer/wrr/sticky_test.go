@@ -0,0 +1,273 @@
+package wrr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+)
+
+func TestStickyCookie_attributes(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		cookie   *dynamic.Cookie
+		expected *http.Cookie
+	}{
+		{
+			desc:   "default attributes",
+			cookie: &dynamic.Cookie{Name: "test"},
+			expected: &http.Cookie{
+				Name:  "test",
+				Value: "first",
+				Path:  "/",
+				Raw:   "test=first; Path=/",
+			},
+		},
+		{
+			desc: "all attributes",
+			cookie: &dynamic.Cookie{
+				Name:     "test",
+				Secure:   true,
+				HTTPOnly: true,
+				SameSite: "strict",
+				MaxAge:   42,
+				Path:     "/foo",
+				Domain:   "example.com",
+			},
+			expected: &http.Cookie{
+				Name:     "test",
+				Value:    "first",
+				Path:     "/foo",
+				Domain:   "example.com",
+				MaxAge:   42,
+				Secure:   true,
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+				Raw:      "test=first; Path=/foo; Domain=example.com; Max-Age=42; HttpOnly; Secure; SameSite=Strict",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			balancer := New(&dynamic.Sticky{Cookie: test.cookie}, "", false)
+			balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			}), Int(1))
+
+			recorder := httptest.NewRecorder()
+			balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			cookies := recorder.Result().Cookies()
+			require.Len(t, cookies, 1)
+			assert.Equal(t, test.expected, cookies[0])
+		})
+	}
+}
+
+func TestStickyCookie_opaque(t *testing.T) {
+	balancer := newStickyBalancer(&dynamic.Sticky{Cookie: &dynamic.Cookie{Name: "test", Opaque: true}})
+
+	recorder := httptest.NewRecorder()
+	balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	server := recorder.Header().Get("server")
+	assert.NotEqual(t, server, cookies[0].Value)
+	assert.Len(t, cookies[0].Value, 32)
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(cookies[0])
+
+		recorder = httptest.NewRecorder()
+		balancer.ServeHTTP(recorder, req)
+
+		assert.Equal(t, server, recorder.Header().Get("server"))
+		assert.Empty(t, recorder.Result().Cookies())
+	}
+
+	// A client cannot pick its server with the server name.
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "test", Value: server})
+
+	recorder = httptest.NewRecorder()
+	balancer.ServeHTTP(recorder, req)
+
+	assert.Len(t, recorder.Result().Cookies(), 1)
+}
+
+func TestAppSession(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		appSession *dynamic.AppSession
+		issue      func(rw http.ResponseWriter, id string)
+		send       func(req *http.Request, id string)
+	}{
+		{
+			desc:       "cookie",
+			appSession: &dynamic.AppSession{Cookie: "JSESSIONID"},
+			issue: func(rw http.ResponseWriter, id string) {
+				http.SetCookie(rw, &http.Cookie{Name: "JSESSIONID", Value: id})
+			},
+			send: func(req *http.Request, id string) {
+				req.AddCookie(&http.Cookie{Name: "JSESSIONID", Value: id})
+			},
+		},
+		{
+			desc:       "header",
+			appSession: &dynamic.AppSession{Header: "X-Session"},
+			issue: func(rw http.ResponseWriter, id string) {
+				rw.Header().Set("X-Session", id)
+			},
+			send: func(req *http.Request, id string) {
+				req.Header.Set("X-Session", id)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			balancer := New(nil, "", false)
+			require.NoError(t, balancer.SetAppSession(test.appSession))
+
+			for _, name := range []string{"first", "second", "third"} {
+				name := name
+				balancer.Add(name, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					if req.URL.Path == "/login" {
+						test.issue(rw, "session-"+name)
+					}
+					rw.Header().Set("server", name)
+					rw.WriteHeader(http.StatusOK)
+				}), Int(1))
+			}
+
+			recorder := httptest.NewRecorder()
+			balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/login", nil))
+
+			server := recorder.Header().Get("server")
+
+			for i := 0; i < 5; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				test.send(req, "session-"+server)
+
+				recorder = httptest.NewRecorder()
+				balancer.ServeHTTP(recorder, req)
+
+				assert.Equal(t, server, recorder.Header().Get("server"))
+			}
+
+			// Unknown sessions are load-balanced.
+			servers := map[string]struct{}{}
+			for i := 0; i < 6; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				test.send(req, "unknown")
+
+				recorder = httptest.NewRecorder()
+				balancer.ServeHTTP(recorder, req)
+
+				servers[recorder.Header().Get("server")] = struct{}{}
+			}
+
+			assert.Len(t, servers, 3)
+		})
+	}
+}
+
+func TestAppSession_serverDown(t *testing.T) {
+	balancer := New(nil, "", false)
+	require.NoError(t, balancer.SetAppSession(&dynamic.AppSession{Header: "X-Session"}))
+
+	for _, name := range []string{"first", "second"} {
+		name := name
+		balancer.Add(name, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("X-Session") == "" {
+				rw.Header().Set("X-Session", "session-"+name)
+			}
+			rw.Header().Set("server", name)
+			rw.WriteHeader(http.StatusOK)
+		}), Int(1))
+	}
+
+	recorder := httptest.NewRecorder()
+	balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	server := recorder.Header().Get("server")
+	balancer.SetStatus(context.Background(), server, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Session", "session-"+server)
+
+	recorder = httptest.NewRecorder()
+	balancer.ServeHTTP(recorder, req)
+
+	assert.NotEqual(t, server, recorder.Header().Get("server"))
+}
+
+func TestSetAppSession(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		appSession    *dynamic.AppSession
+		expectedError bool
+	}{
+		{
+			desc:       "cookie",
+			appSession: &dynamic.AppSession{Cookie: "JSESSIONID"},
+		},
+		{
+			desc:       "header",
+			appSession: &dynamic.AppSession{Header: "X-Session"},
+		},
+		{
+			desc:          "cookie and header",
+			appSession:    &dynamic.AppSession{Cookie: "JSESSIONID", Header: "X-Session"},
+			expectedError: true,
+		},
+		{
+			desc:          "neither cookie nor header",
+			appSession:    &dynamic.AppSession{},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := New(nil, "", false).SetAppSession(test.appSession)
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func newStickyBalancer(sticky *dynamic.Sticky) *Balancer {
+	balancer := New(sticky, "", false)
+
+	for _, name := range []string{"first", "second"} {
+		name := name
+		balancer.Add(name, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("server", name)
+			rw.WriteHeader(http.StatusOK)
+		}), Int(1))
+	}
+
+	return balancer
+}
@@ -4,24 +4,42 @@ import (
 	"container/heap"
 	"context"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/attempt"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/ewma"
+	"github.com/traefik/traefik/v2/pkg/locality"
+	"github.com/traefik/traefik/v2/pkg/sticky"
 )
 
+// unmeasuredPenalty is the cost of a server with outstanding requests,
+// but without any observed latency yet, for the peak-EWMA strategy.
+const unmeasuredPenalty = float64(math.MaxInt64 >> 16)
+
+// slowStartMinFactor is the minimum ratio of its weight given to a server during its slow start.
+const slowStartMinFactor = 0.1
+
 type namedHandler struct {
 	http.Handler
 	name     string
 	weight   float64
 	deadline float64
-}
 
-type stickyCookie struct {
-	name     string
-	secure   bool
-	httpOnly bool
+	// inflight is the number of outstanding requests, only maintained by load-aware strategies.
+	inflight int64
+	latency  *ewma.Peak
+	// started is the time at which the server was added, or last came back up, for the slow start.
+	started time.Time
+	// zone and region are the location of the server, for the locality-aware load-balancing.
+	zone   string
+	region string
 }
 
 // Balancer is a WeightedRoundRobin load balancer based on Earliest Deadline First (EDF).
@@ -29,13 +47,29 @@ type stickyCookie struct {
 // Each pick from the schedule has the earliest deadline entry selected.
 // Entries have deadlines set at currentDeadline + 1 / weight,
 // providing weighted round-robin behavior with floating point weights and an O(log n) pick time.
+//
+// When a load-aware strategy is used, the server with the least cost is picked instead,
+// and the deadlines are only used to break ties between servers of the same cost.
 type Balancer struct {
 	stickyCookie     *stickyCookie
+	appSession       *appSession
 	wantsHealthCheck bool
+	strategy         string
+	// hashKey and boundedLoad are only set for the consistent hash strategy.
+	hashKey     hashKeyFunc
+	boundedLoad float64
+	// slowStart is the duration over which the weight of a server ramps up, after it is added or comes back up.
+	slowStart time.Duration
+	// locality picks the locality tier of the servers, when the locality-aware load-balancing is enabled.
+	locality *locality.Picker
+	// hedging sends a second request to another server, when the first one has not responded within a delay.
+	hedging *hedging
 
 	mutex       sync.RWMutex
 	handlers    []*namedHandler
 	curDeadline float64
+	// ring is the hash ring of the consistent hash strategy, lazily built from the handlers.
+	ring []ringPoint
 	// status is a record of which child services of the Balancer are healthy, keyed
 	// by name of child service. A service is initially added to the map when it is
 	// created via Add, and it is later removed or added to the map as needed,
@@ -46,22 +80,118 @@ type Balancer struct {
 	updaters []func(bool)
 }
 
-// New creates a new load balancer.
-func New(sticky *dynamic.Sticky, wantHealthCheck bool) *Balancer {
+// New creates a new load balancer, using the given strategy to pick the servers.
+// An empty strategy means the weighted round robin one.
+func New(sticky *dynamic.Sticky, strategy string, wantHealthCheck bool) *Balancer {
 	balancer := &Balancer{
 		status:           make(map[string]struct{}),
 		wantsHealthCheck: wantHealthCheck,
+		strategy:         strategy,
 	}
 	if sticky != nil && sticky.Cookie != nil {
-		balancer.stickyCookie = &stickyCookie{
-			name:     sticky.Cookie.Name,
-			secure:   sticky.Cookie.Secure,
-			httpOnly: sticky.Cookie.HTTPOnly,
-		}
+		balancer.stickyCookie = newStickyCookie(sticky.Cookie)
 	}
 	return balancer
 }
 
+// SetAppSession makes the requests of an application session stick to the server which issued the session identifier.
+func (b *Balancer) SetAppSession(config *dynamic.AppSession) error {
+	session, err := newAppSession(config)
+	if err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.appSession = session
+	return nil
+}
+
+// ShareSessions makes the balancer use the session tables of the given service held by the given tables,
+// for its opaque sticky cookies and its application sessions,
+// so that the sessions are kept when the balancer is rebuilt on a dynamic configuration reload.
+// It must be called after SetAppSession.
+func (b *Balancer) ShareSessions(tables *sticky.Tables, serviceName string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.stickyCookie != nil && b.stickyCookie.ids != nil {
+		ids, err := tables.Get(serviceName, "cookie", maxSessionEntries)
+		if err != nil {
+			return fmt.Errorf("getting sticky cookie table: %w", err)
+		}
+		b.stickyCookie.ids = ids
+	}
+
+	if b.appSession != nil {
+		sessions, err := tables.Get(serviceName, "appSession", maxSessionEntries)
+		if err != nil {
+			return fmt.Errorf("getting application session table: %w", err)
+		}
+		b.appSession.sessions = sessions
+	}
+
+	return nil
+}
+
+// SetSlowStart makes the weight of the servers ramp up linearly over the given duration,
+// from a tenth of their weight, after they are added or come back up.
+func (b *Balancer) SetSlowStart(slowStart time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.slowStart = slowStart
+}
+
+// SetStartTime sets the time at which the given server started, from which its slow start is computed.
+// It allows a server to keep its start time when the balancer is rebuilt.
+func (b *Balancer) SetStartTime(childName string, started time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, handler := range b.handlers {
+		if handler.name == childName {
+			handler.started = started
+		}
+	}
+}
+
+// SetLocality enables the locality-aware load-balancing, with the given picker.
+func (b *Balancer) SetLocality(picker *locality.Picker) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.locality = picker
+}
+
+// SetServerLocality sets the zone and region of the given server, for the locality-aware load-balancing.
+func (b *Balancer) SetServerLocality(childName, zone, region string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, handler := range b.handlers {
+		if handler.name == childName {
+			handler.zone = zone
+			handler.region = region
+		}
+	}
+}
+
+// weight returns the effective weight of the given server at the given time, according to its slow start.
+func (b *Balancer) weight(handler *namedHandler, now time.Time) float64 {
+	if b.slowStart <= 0 {
+		return handler.weight
+	}
+
+	elapsed := now.Sub(handler.started)
+	if elapsed >= b.slowStart {
+		return handler.weight
+	}
+
+	return handler.weight * math.Max(float64(elapsed)/float64(b.slowStart), slowStartMinFactor)
+}
+
 // Len implements heap.Interface/sort.Interface.
 func (b *Balancer) Len() int { return len(b.handlers) }
 
@@ -109,6 +239,9 @@ func (b *Balancer) SetStatus(ctx context.Context, childName string, up bool) {
 	log.Ctx(ctx).Debug().Msgf("Setting status of %s to %v", childName, status)
 
 	if up {
+		if _, ok := b.status[childName]; !ok {
+			b.restart(childName)
+		}
 		b.status[childName] = struct{}{}
 	} else {
 		delete(b.status, childName)
@@ -134,6 +267,17 @@ func (b *Balancer) SetStatus(ctx context.Context, childName string, up bool) {
 	}
 }
 
+// restart resets the start time of the given server, which came back up, to start it slowly.
+// It must be called with the mutex held.
+func (b *Balancer) restart(childName string) {
+	now := time.Now()
+	for _, handler := range b.handlers {
+		if handler.name == childName {
+			handler.started = now
+		}
+	}
+}
+
 // RegisterStatusUpdater adds fn to the list of hooks that are run when the
 // status of the Balancer changes.
 // Not thread safe.
@@ -147,7 +291,14 @@ func (b *Balancer) RegisterStatusUpdater(fn func(up bool)) error {
 
 var errNoAvailableServer = errors.New("no available server")
 
-func (b *Balancer) nextServer() (*namedHandler, error) {
+// nextServer returns the server to which the given request is forwarded, among the servers which are not excluded.
+func (b *Balancer) nextServer(req *http.Request, excluded ...string) (*namedHandler, error) {
+	var key string
+	var hasKey bool
+	if b.hashKey != nil {
+		key, hasKey = b.hashKey(req)
+	}
+
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
@@ -155,6 +306,40 @@ func (b *Balancer) nextServer() (*namedHandler, error) {
 		return nil, errNoAvailableServer
 	}
 
+	eligible := b.eligibility()
+
+	if len(excluded) > 0 {
+		eligible = excluding(eligible, excluded)
+
+		// The weighted round robin below expects at least one eligible server.
+		if !b.anyEligible(eligible) {
+			return nil, errNoAvailableServer
+		}
+	}
+
+	// Requests without any hash key are load-balanced with the weighted round robin.
+	if hasKey {
+		handler := b.hashServer(key, eligible)
+		if handler == nil {
+			return nil, errNoAvailableServer
+		}
+
+		log.Debug().Msgf("Service selected by %s: %s", b.strategy, handler.name)
+		return handler, nil
+	}
+
+	if b.strategy == dynamic.BalancerStrategyLeastRequests || b.strategy == dynamic.BalancerStrategyPeakEWMA {
+		handler := b.leastCostServer(eligible)
+		if handler == nil {
+			return nil, errNoAvailableServer
+		}
+
+		log.Debug().Msgf("Service selected by %s: %s", b.strategy, handler.name)
+		return handler, nil
+	}
+
+	now := time.Now()
+
 	var handler *namedHandler
 	for {
 		// Pick handler with closest deadline.
@@ -162,10 +347,10 @@ func (b *Balancer) nextServer() (*namedHandler, error) {
 
 		// curDeadline should be handler's deadline so that new added entry would have a fair competition environment with the old ones.
 		b.curDeadline = handler.deadline
-		handler.deadline += 1 / handler.weight
+		handler.deadline += 1 / b.weight(handler, now)
 
 		heap.Push(b, handler)
-		if _, ok := b.status[handler.name]; ok {
+		if eligible(handler) {
 			break
 		}
 	}
@@ -174,36 +359,146 @@ func (b *Balancer) nextServer() (*namedHandler, error) {
 	return handler, nil
 }
 
-func (b *Balancer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	if b.stickyCookie != nil {
-		cookie, err := req.Cookie(b.stickyCookie.name)
+// eligibility returns the function reporting whether a server can be picked for the next request.
+// A server can be picked when it is healthy, and, with the locality-aware load-balancing,
+// when it belongs to the locality tier picked for the request.
+// It must be called with the mutex held.
+func (b *Balancer) eligibility() func(handler *namedHandler) bool {
+	healthy := func(handler *namedHandler) bool {
+		_, ok := b.status[handler.name]
+		return ok
+	}
 
-		if err != nil && !errors.Is(err, http.ErrNoCookie) {
-			log.Warn().Err(err).Msg("Error while reading cookie")
-		}
+	if b.locality == nil {
+		return healthy
+	}
+
+	var capacities locality.Capacities
+	for _, handler := range b.handlers {
+		capacities.Add(b.locality.Tier(handler.zone, handler.region), handler.weight, healthy(handler))
+	}
 
-		if err == nil && cookie != nil {
-			for _, handler := range b.handlers {
-				if handler.name != cookie.Value {
-					continue
-				}
+	tier, ok := b.locality.Pick(capacities)
+	if !ok {
+		return healthy
+	}
 
-				b.mutex.RLock()
-				_, ok := b.status[handler.name]
-				b.mutex.RUnlock()
-				if !ok {
-					// because we already are in the only iteration that matches the cookie, so none
-					// of the following iterations are going to be a match for the cookie anyway.
-					break
-				}
+	return func(handler *namedHandler) bool {
+		return healthy(handler) && b.locality.Tier(handler.zone, handler.region) == tier
+	}
+}
 
-				handler.ServeHTTP(w, req)
-				return
+// excluding returns the eligibility function which also excludes the servers of the given names.
+func excluding(eligible func(handler *namedHandler) bool, excluded []string) func(handler *namedHandler) bool {
+	return func(handler *namedHandler) bool {
+		for _, name := range excluded {
+			if handler.name == name {
+				return false
 			}
 		}
+
+		return eligible(handler)
+	}
+}
+
+// anyEligible returns whether any server is eligible.
+// It must be called with the mutex held.
+func (b *Balancer) anyEligible(eligible func(handler *namedHandler) bool) bool {
+	for _, handler := range b.handlers {
+		if eligible(handler) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// leastCostServer returns the eligible server with the least cost, according to the load-aware strategy.
+// Servers of the same cost are picked in a weighted round robin fashion.
+func (b *Balancer) leastCostServer(eligible func(handler *namedHandler) bool) *namedHandler {
+	now := time.Now()
+
+	index := -1
+	var minCost float64
+	for i, handler := range b.handlers {
+		if !eligible(handler) {
+			continue
+		}
+
+		cost := b.cost(handler, b.weight(handler, now))
+		if index == -1 || cost < minCost || (cost == minCost && handler.deadline < b.handlers[index].deadline) {
+			index = i
+			minCost = cost
+		}
 	}
 
-	server, err := b.nextServer()
+	if index == -1 {
+		return nil
+	}
+
+	handler := b.handlers[index]
+	b.curDeadline = handler.deadline
+	handler.deadline += 1 / b.weight(handler, now)
+	heap.Fix(b, index)
+
+	return handler
+}
+
+// cost returns the cost of sending a new request to the given server, of the given effective weight.
+func (b *Balancer) cost(handler *namedHandler, weight float64) float64 {
+	inflight := float64(atomic.LoadInt64(&handler.inflight))
+
+	if b.strategy != dynamic.BalancerStrategyPeakEWMA {
+		return (inflight + 1) / weight
+	}
+
+	latency := handler.latency.Value()
+	if latency == 0 && inflight > 0 {
+		return unmeasuredPenalty
+	}
+
+	return latency * (inflight + 1) / weight
+}
+
+// serve forwards the request to the given server,
+// while tracking the load of the server for the load-aware strategies.
+func (b *Balancer) serve(handler *namedHandler, w http.ResponseWriter, req *http.Request) {
+	switch b.strategy {
+	case dynamic.BalancerStrategyLeastRequests, dynamic.BalancerStrategyConsistentHash:
+		atomic.AddInt64(&handler.inflight, 1)
+		defer atomic.AddInt64(&handler.inflight, -1)
+
+	case dynamic.BalancerStrategyPeakEWMA:
+		atomic.AddInt64(&handler.inflight, 1)
+		defer atomic.AddInt64(&handler.inflight, -1)
+
+		start := time.Now()
+		defer func() {
+			now := time.Now()
+			handler.latency.Observe(now.Sub(start), now)
+		}()
+	}
+
+	handler.ServeHTTP(w, req)
+}
+
+func (b *Balancer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if handler := b.stickyServer(req); handler != nil {
+		b.serve(handler, b.sessionWriter(w, handler), req)
+		return
+	}
+
+	// The retries of a request are forwarded to servers which were not attempted yet, when there is any.
+	var attempted []string
+	attempts := attempt.FromContext(req.Context())
+	if attempts != nil {
+		attempted = attempts.Servers()
+	}
+
+	server, err := b.nextServer(req, attempted...)
+	if errors.Is(err, errNoAvailableServer) && len(attempted) > 0 {
+		server, err = b.nextServer(req)
+	}
 	if err != nil {
 		if errors.Is(err, errNoAvailableServer) {
 			http.Error(w, errNoAvailableServer.Error(), http.StatusServiceUnavailable)
@@ -213,12 +508,31 @@ func (b *Balancer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if attempts != nil {
+		attempts.Add(server.name)
+	}
+
+	if b.hedging != nil && b.hedging.hedgeable(req) {
+		b.serveHedged(w, req, server)
+		return
+	}
+
 	if b.stickyCookie != nil {
-		cookie := &http.Cookie{Name: b.stickyCookie.name, Value: server.name, Path: "/", HttpOnly: b.stickyCookie.httpOnly, Secure: b.stickyCookie.secure}
-		http.SetCookie(w, cookie)
+		if cookie := b.stickyCookie.cookie(server.name); cookie != nil {
+			http.SetCookie(w, cookie)
+		}
+	}
+
+	b.serve(server, b.sessionWriter(w, server), req)
+}
+
+// sessionWriter returns the response writer learning the application session from the response of the given server.
+func (b *Balancer) sessionWriter(w http.ResponseWriter, handler *namedHandler) http.ResponseWriter {
+	if b.appSession == nil {
+		return w
 	}
 
-	server.ServeHTTP(w, req)
+	return &sessionResponseWriter{ResponseWriter: w, session: b.appSession, serverName: handler.name}
 }
 
 // Add adds a handler.
@@ -233,11 +547,12 @@ func (b *Balancer) Add(name string, handler http.Handler, weight *int) {
 		return
 	}
 
-	h := &namedHandler{Handler: handler, name: name, weight: float64(w)}
+	h := &namedHandler{Handler: handler, name: name, weight: float64(w), latency: ewma.NewPeak(ewma.DefaultDecay), started: time.Now()}
 
 	b.mutex.Lock()
-	h.deadline = b.curDeadline + 1/h.weight
+	h.deadline = b.curDeadline + 1/b.weight(h, h.started)
 	heap.Push(b, h)
 	b.status[name] = struct{}{}
+	b.ring = nil
 	b.mutex.Unlock()
 }
@@ -5,13 +5,18 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/locality"
+	"github.com/traefik/traefik/v2/pkg/middlewares/retry"
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 func TestBalancer(t *testing.T) {
-	balancer := New(nil, false)
+	balancer := New(nil, "", false)
 
 	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.Header().Set("server", "first")
@@ -33,7 +38,7 @@ func TestBalancer(t *testing.T) {
 }
 
 func TestBalancerNoService(t *testing.T) {
-	balancer := New(nil, false)
+	balancer := New(nil, "", false)
 
 	recorder := httptest.NewRecorder()
 	balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
@@ -42,7 +47,7 @@ func TestBalancerNoService(t *testing.T) {
 }
 
 func TestBalancerOneServerZeroWeight(t *testing.T) {
-	balancer := New(nil, false)
+	balancer := New(nil, "", false)
 
 	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.Header().Set("server", "first")
@@ -64,7 +69,7 @@ type key string
 const serviceName key = "serviceName"
 
 func TestBalancerNoServiceUp(t *testing.T) {
-	balancer := New(nil, false)
+	balancer := New(nil, "", false)
 
 	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -84,7 +89,7 @@ func TestBalancerNoServiceUp(t *testing.T) {
 }
 
 func TestBalancerOneServerDown(t *testing.T) {
-	balancer := New(nil, false)
+	balancer := New(nil, "", false)
 
 	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.Header().Set("server", "first")
@@ -105,7 +110,7 @@ func TestBalancerOneServerDown(t *testing.T) {
 }
 
 func TestBalancerDownThenUp(t *testing.T) {
-	balancer := New(nil, false)
+	balancer := New(nil, "", false)
 
 	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.Header().Set("server", "first")
@@ -134,7 +139,7 @@ func TestBalancerDownThenUp(t *testing.T) {
 }
 
 func TestBalancerPropagate(t *testing.T) {
-	balancer1 := New(nil, true)
+	balancer1 := New(nil, "", true)
 
 	balancer1.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.Header().Set("server", "first")
@@ -145,7 +150,7 @@ func TestBalancerPropagate(t *testing.T) {
 		rw.WriteHeader(http.StatusOK)
 	}), Int(1))
 
-	balancer2 := New(nil, true)
+	balancer2 := New(nil, "", true)
 	balancer2.Add("third", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.Header().Set("server", "third")
 		rw.WriteHeader(http.StatusOK)
@@ -155,7 +160,7 @@ func TestBalancerPropagate(t *testing.T) {
 		rw.WriteHeader(http.StatusOK)
 	}), Int(1))
 
-	topBalancer := New(nil, true)
+	topBalancer := New(nil, "", true)
 	topBalancer.Add("balancer1", balancer1, Int(1))
 	_ = balancer1.RegisterStatusUpdater(func(up bool) {
 		topBalancer.SetStatus(context.WithValue(context.Background(), serviceName, "top"), "balancer1", up)
@@ -207,7 +212,7 @@ func TestBalancerPropagate(t *testing.T) {
 }
 
 func TestBalancerAllServersZeroWeight(t *testing.T) {
-	balancer := New(nil, false)
+	balancer := New(nil, "", false)
 
 	balancer.Add("test", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), Int(0))
 	balancer.Add("test2", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), Int(0))
@@ -221,7 +226,7 @@ func TestBalancerAllServersZeroWeight(t *testing.T) {
 func TestSticky(t *testing.T) {
 	balancer := New(&dynamic.Sticky{
 		Cookie: &dynamic.Cookie{Name: "test"},
-	}, false)
+	}, "", false)
 
 	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.Header().Set("server", "first")
@@ -249,10 +254,35 @@ func TestSticky(t *testing.T) {
 	assert.Equal(t, 3, recorder.save["second"])
 }
 
+func TestBalancerRetryOtherServer(t *testing.T) {
+	balancer := New(nil, "", false)
+
+	var attempted []string
+	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempted = append(attempted, "first")
+		rw.WriteHeader(http.StatusBadGateway)
+	}), Int(3))
+
+	balancer.Add("second", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempted = append(attempted, "second")
+		rw.WriteHeader(http.StatusBadGateway)
+	}), Int(1))
+
+	handler, err := retry.New(context.Background(), balancer, dynamic.Retry{Attempts: 3, Status: []string{"502"}}, nil, retry.Listeners{}, "retry")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// The retry goes to the other server, and the last one to any server once they were all attempted.
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+	assert.Equal(t, []string{"first", "second", "first"}, attempted)
+}
+
 // TestBalancerBias makes sure that the WRR algorithm spreads elements evenly right from the start,
 // and that it does not "over-favor" the high-weighted ones with a biased start-up regime.
 func TestBalancerBias(t *testing.T) {
-	balancer := New(nil, false)
+	balancer := New(nil, "", false)
 
 	balancer.Add("first", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		rw.Header().Set("server", "A")
@@ -275,6 +305,90 @@ func TestBalancerBias(t *testing.T) {
 	assert.Equal(t, wantSequence, recorder.sequence)
 }
 
+func TestBalancerLeastRequests(t *testing.T) {
+	balancer := New(nil, dynamic.BalancerStrategyLeastRequests, false)
+
+	entered := make(chan string, 1)
+	release := make(chan struct{})
+	handler := func(name string) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("block") != "" {
+				entered <- name
+				<-release
+			}
+			rw.Header().Set("server", name)
+			rw.WriteHeader(http.StatusOK)
+		})
+	}
+
+	balancer.Add("first", handler("first"), Int(1))
+	balancer.Add("second", handler("second"), Int(1))
+
+	done := make(chan struct{})
+	go func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("block", "true")
+		balancer.ServeHTTP(httptest.NewRecorder(), req)
+		close(done)
+	}()
+
+	busy := <-entered
+
+	recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	close(release)
+	<-done
+
+	assert.Equal(t, 0, recorder.save[busy])
+	assert.Len(t, recorder.sequence, 4)
+
+	// Once the outstanding request is done, the servers have the same cost again,
+	// and the server which received fewer requests is favored.
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, []string{busy}, recorder.sequence)
+}
+
+func TestBalancerPeakEWMA(t *testing.T) {
+	balancer := New(nil, dynamic.BalancerStrategyPeakEWMA, false)
+
+	for _, name := range []string{"fast", "slow"} {
+		name := name
+		balancer.Add(name, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("server", name)
+			rw.WriteHeader(http.StatusOK)
+		}), Int(1))
+	}
+
+	now := time.Now()
+	for _, handler := range balancer.handlers {
+		latency := 10 * time.Millisecond
+		if handler.name == "slow" {
+			latency = time.Second
+		}
+		handler.latency.Observe(latency, now)
+	}
+
+	recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, 4, recorder.save["fast"])
+
+	// The slow server is picked when the fast one is down.
+	balancer.SetStatus(context.Background(), "fast", false)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, 1, recorder.save["slow"])
+}
+
 func Int(v int) *int { return &v }
 
 type responseRecorder struct {
@@ -290,3 +404,107 @@ func (r *responseRecorder) WriteHeader(statusCode int) {
 	r.status = append(r.status, statusCode)
 	r.ResponseRecorder.WriteHeader(statusCode)
 }
+
+func TestBalancerSlowStart(t *testing.T) {
+	balancer := New(nil, "", false)
+	balancer.SetSlowStart(10 * time.Second)
+
+	for _, name := range []string{"old", "new"} {
+		name := name
+		balancer.Add(name, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("server", name)
+			rw.WriteHeader(http.StatusOK)
+		}), Int(1))
+	}
+
+	balancer.SetStartTime("old", time.Now().Add(-time.Minute))
+
+	// The new server starts with a tenth of its weight.
+	recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 110; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.InDelta(t, 100, recorder.save["old"], 2)
+	assert.InDelta(t, 10, recorder.save["new"], 2)
+
+	// Halfway through its slow start, the new server has half of its weight.
+	balancer.SetStartTime("new", time.Now().Add(-5*time.Second))
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 90; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.InDelta(t, 60, recorder.save["old"], 3)
+	assert.InDelta(t, 30, recorder.save["new"], 3)
+
+	// A server coming back up starts slowly again.
+	balancer.SetStartTime("new", time.Now().Add(-time.Minute))
+	balancer.SetStatus(context.Background(), "new", false)
+	balancer.SetStatus(context.Background(), "new", true)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 110; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.InDelta(t, 10, recorder.save["new"], 3)
+}
+
+func TestBalancerLocality(t *testing.T) {
+	balancer := New(nil, "", false)
+	balancer.SetLocality(locality.NewPicker(&dynamic.Locality{MinHealthyPercent: 50}, &types.Locality{Zone: "a", Region: "eu"}))
+
+	servers := map[string][2]string{
+		"local1":  {"a", "eu"},
+		"local2":  {"a", "eu"},
+		"region":  {"b", "eu"},
+		"faraway": {"c", "us"},
+	}
+
+	for name, location := range servers {
+		name := name
+		balancer.Add(name, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("server", name)
+			rw.WriteHeader(http.StatusOK)
+		}), Int(1))
+		balancer.SetServerLocality(name, location[0], location[1])
+	}
+
+	recorder := &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, map[string]int{"local1": 2, "local2": 2}, recorder.save)
+
+	// Half of the local capacity is still healthy, which is enough to keep all the traffic local.
+	balancer.SetStatus(context.Background(), "local1", false)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, map[string]int{"local2": 4}, recorder.save)
+
+	// Without any local server, the traffic goes to the region, then anywhere.
+	balancer.SetStatus(context.Background(), "local2", false)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, map[string]int{"region": 4}, recorder.save)
+
+	balancer.SetStatus(context.Background(), "region", false)
+
+	recorder = &responseRecorder{ResponseRecorder: httptest.NewRecorder(), save: map[string]int{}}
+	for i := 0; i < 4; i++ {
+		balancer.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, map[string]int{"faraway": 4}, recorder.save)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/traefik/traefik/v2/pkg/api"
@@ -10,6 +11,8 @@ import (
 	"github.com/traefik/traefik/v2/pkg/config/static"
 	"github.com/traefik/traefik/v2/pkg/metrics"
 	"github.com/traefik/traefik/v2/pkg/safe"
+	"github.com/traefik/traefik/v2/pkg/sticky"
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 // ManagerFactory a factory of service manager.
@@ -26,19 +29,30 @@ type ManagerFactory struct {
 	acmeHTTPHandler  http.Handler
 
 	routinesPool *safe.Pool
+
+	// serverStarts keeps the start times of the servers across the service managers, for the slow start.
+	serverStarts *serverStarts
+	// sessionTables keeps the session tables of the sticky sessions across the service managers.
+	sessionTables *sticky.Tables
+	// locality is the location of this Traefik instance, for the locality-aware load-balancing.
+	locality *types.Locality
 }
 
 // NewManagerFactory creates a new ManagerFactory.
-func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *safe.Pool, metricsRegistry metrics.Registry, roundTripperManager *RoundTripperManager, acmeHTTPHandler http.Handler) *ManagerFactory {
+// The routingInspector, if any, gives the API access to the routing of the entry points.
+func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *safe.Pool, metricsRegistry metrics.Registry, roundTripperManager *RoundTripperManager, acmeHTTPHandler http.Handler, routingInspector api.RoutingInspector) *ManagerFactory {
 	factory := &ManagerFactory{
 		metricsRegistry:     metricsRegistry,
 		routinesPool:        routinesPool,
 		roundTripperManager: roundTripperManager,
 		acmeHTTPHandler:     acmeHTTPHandler,
+		serverStarts:        newServerStarts(),
+		sessionTables:       sticky.NewTables(),
+		locality:            staticConfiguration.Locality,
 	}
 
 	if staticConfiguration.API != nil {
-		apiRouterBuilder := api.NewBuilder(staticConfiguration)
+		apiRouterBuilder := api.NewBuilder(staticConfiguration, routingInspector)
 
 		if staticConfiguration.API.Dashboard {
 			factory.dashboardHandler = dashboard.Handler{}
@@ -73,6 +87,14 @@ func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *s
 // Build creates a service manager.
 func (f *ManagerFactory) Build(configuration *runtime.Configuration) *InternalHandlers {
 	svcManager := NewManager(configuration.Services, f.metricsRegistry, f.routinesPool, f.roundTripperManager)
+	svcManager.serverStarts = f.serverStarts.update(configuration.Services, time.Now())
+
+	f.sessionTables.Retain(func(serviceName string) bool {
+		_, ok := configuration.Services[serviceName]
+		return ok
+	})
+	svcManager.sessionTables = f.sessionTables
+	svcManager.locality = f.locality
 
 	var apiHandler http.Handler
 	if f.api != nil {
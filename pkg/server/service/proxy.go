@@ -21,14 +21,35 @@ const StatusClientClosedRequest = 499
 // StatusClientClosedRequestText non-standard HTTP status for client disconnection.
 const StatusClientClosedRequestText = "Client Closed Request"
 
-func buildSingleHostProxy(target *url.URL, passHostHeader bool, flushInterval time.Duration, roundTripper http.RoundTripper, bufferPool httputil.BufferPool) http.Handler {
-	return &httputil.ReverseProxy{
+// resultObserver is notified of the outcome of each request forwarded to a server,
+// which failed on a 5xx response, or on an error other than the client closing the request.
+type resultObserver func(ctx context.Context, failed bool)
+
+func buildSingleHostProxy(target *url.URL, passHostHeader bool, flushInterval time.Duration, roundTripper http.RoundTripper, bufferPool httputil.BufferPool, observer resultObserver) http.Handler {
+	proxy := &httputil.ReverseProxy{
 		Director:      directorBuilder(target, passHostHeader),
 		Transport:     roundTripper,
 		FlushInterval: flushInterval,
 		BufferPool:    bufferPool,
 		ErrorHandler:  errorHandler,
 	}
+
+	if observer != nil {
+		proxy.ModifyResponse = func(resp *http.Response) error {
+			observer(resp.Request.Context(), resp.StatusCode >= http.StatusInternalServerError)
+			return nil
+		}
+
+		proxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+			if !errors.Is(err, context.Canceled) {
+				observer(req.Context(), true)
+			}
+
+			errorHandler(w, req, err)
+		}
+	}
+
+	return proxy
 }
 
 func directorBuilder(target *url.URL, passHostHeader bool) func(req *http.Request) {
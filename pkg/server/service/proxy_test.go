@@ -1,12 +1,15 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/traefik/traefik/v2/pkg/testhelpers"
 )
 
@@ -28,10 +31,78 @@ func BenchmarkProxy(b *testing.B) {
 	req := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar/", nil)
 
 	pool := newBufferPool()
-	handler := buildSingleHostProxy(req.URL, false, 0, &staticTransport{res}, pool)
+	handler := buildSingleHostProxy(req.URL, false, 0, &staticTransport{res}, pool, nil)
 
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		handler.ServeHTTP(w, req)
 	}
 }
+
+type observedTransport struct {
+	statusCode int
+	err        error
+}
+
+func (t *observedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	return &http.Response{
+		StatusCode: t.statusCode,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    r,
+	}, nil
+}
+
+func TestBuildSingleHostProxy_observer(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		transport *observedTransport
+		expected  []bool
+	}{
+		{
+			desc:      "success",
+			transport: &observedTransport{statusCode: http.StatusOK},
+			expected:  []bool{false},
+		},
+		{
+			desc:      "client error",
+			transport: &observedTransport{statusCode: http.StatusNotFound},
+			expected:  []bool{false},
+		},
+		{
+			desc:      "server error",
+			transport: &observedTransport{statusCode: http.StatusServiceUnavailable},
+			expected:  []bool{true},
+		},
+		{
+			desc:      "connection error",
+			transport: &observedTransport{err: errors.New("connection refused")},
+			expected:  []bool{true},
+		},
+		{
+			desc:      "client closed request",
+			transport: &observedTransport{err: context.Canceled},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var observed []bool
+			observer := func(_ context.Context, failed bool) {
+				observed = append(observed, failed)
+			}
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar/", nil)
+			handler := buildSingleHostProxy(req.URL, false, 0, test.transport, nil, observer)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, test.expected, observed)
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/healthcheck"
+	"github.com/traefik/traefik/v2/pkg/locality"
 	"github.com/traefik/traefik/v2/pkg/logs"
 	"github.com/traefik/traefik/v2/pkg/metrics"
 	"github.com/traefik/traefik/v2/pkg/middlewares/accesslog"
@@ -27,6 +28,8 @@ import (
 	"github.com/traefik/traefik/v2/pkg/server/service/loadbalancer/failover"
 	"github.com/traefik/traefik/v2/pkg/server/service/loadbalancer/mirror"
 	"github.com/traefik/traefik/v2/pkg/server/service/loadbalancer/wrr"
+	"github.com/traefik/traefik/v2/pkg/sticky"
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 const defaultMaxBodySize int64 = -1
@@ -43,10 +46,18 @@ type Manager struct {
 	bufferPool          httputil.BufferPool
 	roundTripperManager RoundTripperGetter
 
-	services       map[string]http.Handler
-	configs        map[string]*runtime.ServiceInfo
-	healthCheckers map[string]*healthcheck.ServiceHealthChecker
-	rand           *rand.Rand // For the initial shuffling of load-balancers.
+	services         map[string]http.Handler
+	configs          map[string]*runtime.ServiceInfo
+	healthCheckers   map[string]*healthcheck.ServiceHealthChecker
+	outlierDetectors map[string]*healthcheck.OutlierDetector
+	rand             *rand.Rand // For the initial shuffling of load-balancers.
+
+	// serverStarts holds the start times of the servers, keyed by serverStartKey, for the slow start.
+	serverStarts map[string]time.Time
+	// sessionTables holds the session tables of the services with sticky sessions, kept across the service managers.
+	sessionTables *sticky.Tables
+	// locality is the location of this Traefik instance, for the locality-aware load-balancing.
+	locality *types.Locality
 }
 
 // NewManager creates a new Manager.
@@ -59,6 +70,7 @@ func NewManager(configs map[string]*runtime.ServiceInfo, metricsRegistry metrics
 		services:            make(map[string]http.Handler),
 		configs:             configs,
 		healthCheckers:      make(map[string]*healthcheck.ServiceHealthChecker),
+		outlierDetectors:    make(map[string]*healthcheck.OutlierDetector),
 		rand:                rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
@@ -116,7 +128,7 @@ func (m *Manager) BuildHTTP(rootCtx context.Context, serviceName string) (http.H
 		}
 	case conf.Mirroring != nil:
 		var err error
-		lb, err = m.getMirrorServiceHandler(ctx, conf.Mirroring)
+		lb, err = m.getMirrorServiceHandler(ctx, serviceName, conf.Mirroring)
 		if err != nil {
 			conf.AddError(err, true)
 			return nil, err
@@ -140,53 +152,58 @@ func (m *Manager) BuildHTTP(rootCtx context.Context, serviceName string) (http.H
 }
 
 func (m *Manager) getFailoverServiceHandler(ctx context.Context, serviceName string, config *dynamic.Failover) (http.Handler, error) {
-	f := failover.New(config.HealthCheck)
-
-	serviceHandler, err := m.BuildHTTP(ctx, config.Service)
-	if err != nil {
-		return nil, err
+	tiers := config.Tiers
+	if len(tiers) == 0 {
+		tiers = []dynamic.FailoverTier{
+			{Services: []dynamic.WRRService{{Name: config.Service}}},
+			{Services: []dynamic.WRRService{{Name: config.Fallback}}},
+		}
+	} else if config.Service != "" || config.Fallback != "" {
+		return nil, errors.New("tiers cannot be defined along with service and fallback")
 	}
 
-	f.SetHandler(serviceHandler)
+	f := failover.New(config.HealthCheck, time.Duration(config.FailbackDelay))
 
-	updater, ok := serviceHandler.(healthcheck.StatusUpdater)
-	if !ok {
-		return nil, fmt.Errorf("child service %v of %v not a healthcheck.StatusUpdater (%T)", config.Service, serviceName, serviceHandler)
-	}
+	for index, tier := range tiers {
+		if len(tier.Services) == 0 {
+			return nil, fmt.Errorf("tier %d of %v does not have any service", index, serviceName)
+		}
 
-	if err := updater.RegisterStatusUpdater(func(up bool) {
-		f.SetHandlerStatus(ctx, up)
-	}); err != nil {
-		return nil, fmt.Errorf("cannot register %v as updater for %v: %w", config.Service, serviceName, err)
-	}
+		tierIndex := f.AddTier(tier.MinHealthy)
 
-	fallbackHandler, err := m.BuildHTTP(ctx, config.Fallback)
-	if err != nil {
-		return nil, err
-	}
+		// Only the health of the last tier does not matter, unless the failover reports its own health.
+		lastTier := index == len(tiers)-1
 
-	f.SetFallbackHandler(fallbackHandler)
+		for _, service := range tier.Services {
+			serviceHandler, err := m.BuildHTTP(ctx, service.Name)
+			if err != nil {
+				return nil, err
+			}
 
-	// Do not report the health of the fallback handler.
-	if config.HealthCheck == nil {
-		return f, nil
-	}
+			f.AddHandler(tierIndex, service.Name, serviceHandler, service.Weight)
 
-	fallbackUpdater, ok := fallbackHandler.(healthcheck.StatusUpdater)
-	if !ok {
-		return nil, fmt.Errorf("child service %v of %v not a healthcheck.StatusUpdater (%T)", config.Fallback, serviceName, fallbackHandler)
-	}
+			if lastTier && config.HealthCheck == nil {
+				continue
+			}
+
+			childName := service.Name
+			updater, ok := serviceHandler.(healthcheck.StatusUpdater)
+			if !ok {
+				return nil, fmt.Errorf("child service %v of %v not a healthcheck.StatusUpdater (%T)", childName, serviceName, serviceHandler)
+			}
 
-	if err := fallbackUpdater.RegisterStatusUpdater(func(up bool) {
-		f.SetFallbackHandlerStatus(ctx, up)
-	}); err != nil {
-		return nil, fmt.Errorf("cannot register %v as updater for %v: %w", config.Fallback, serviceName, err)
+			if err := updater.RegisterStatusUpdater(func(up bool) {
+				f.SetHandlerStatus(ctx, tierIndex, childName, up)
+			}); err != nil {
+				return nil, fmt.Errorf("cannot register %v as updater for %v: %w", childName, serviceName, err)
+			}
+		}
 	}
 
 	return f, nil
 }
 
-func (m *Manager) getMirrorServiceHandler(ctx context.Context, config *dynamic.Mirroring) (http.Handler, error) {
+func (m *Manager) getMirrorServiceHandler(ctx context.Context, serviceName string, config *dynamic.Mirroring) (http.Handler, error) {
 	serviceHandler, err := m.BuildHTTP(ctx, config.Service)
 	if err != nil {
 		return nil, err
@@ -203,11 +220,16 @@ func (m *Manager) getMirrorServiceHandler(ctx context.Context, config *dynamic.M
 			return nil, err
 		}
 
-		err = handler.AddMirror(mirrorHandler, mirrorConfig.Percent)
+		err = handler.AddMirror(mirrorConfig.Name, mirrorHandler, mirrorConfig.Percent)
 		if err != nil {
 			return nil, err
 		}
 	}
+
+	if config.Diff != nil {
+		handler.SetDiff(serviceName, config.Diff, m.metricsRegistry)
+	}
+
 	return handler, nil
 }
 
@@ -217,7 +239,19 @@ func (m *Manager) getWRRServiceHandler(ctx context.Context, serviceName string,
 		config.Sticky.Cookie.Name = cookie.GetName(config.Sticky.Cookie.Name, serviceName)
 	}
 
-	balancer := wrr.New(config.Sticky, config.HealthCheck != nil)
+	balancer := wrr.New(config.Sticky, dynamic.BalancerStrategyRoundRobin, config.HealthCheck != nil)
+	if config.Sticky != nil && config.Sticky.AppSession != nil {
+		if err := balancer.SetAppSession(config.Sticky.AppSession); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.sessionTables != nil {
+		if err := balancer.ShareSessions(m.sessionTables, serviceName); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, service := range shuffle(config.Services, m.rand) {
 		serviceHandler, err := m.BuildHTTP(ctx, service.Name)
 		if err != nil {
@@ -280,9 +314,60 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 		return nil, err
 	}
 
-	lb := wrr.New(service.Sticky, service.HealthCheck != nil)
+	var lb *wrr.Balancer
+	switch service.Strategy {
+	case "", dynamic.BalancerStrategyRoundRobin, dynamic.BalancerStrategyLeastRequests, dynamic.BalancerStrategyPeakEWMA:
+		lb = wrr.New(service.Sticky, service.Strategy, service.HealthCheck != nil)
+	case dynamic.BalancerStrategyConsistentHash:
+		lb, err = wrr.NewConsistentHash(service.Sticky, service.ConsistentHash, service.HealthCheck != nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating consistent hash load balancer: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown load-balancing strategy %q", service.Strategy)
+	}
+
+	if service.Sticky != nil && service.Sticky.AppSession != nil {
+		if err := lb.SetAppSession(service.Sticky.AppSession); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.sessionTables != nil {
+		if err := lb.ShareSessions(m.sessionTables, serviceName); err != nil {
+			return nil, err
+		}
+	}
+
+	if service.SlowStart > 0 {
+		lb.SetSlowStart(time.Duration(service.SlowStart))
+	}
+
+	if service.Hedging != nil {
+		if err := lb.SetHedging(serviceName, service.Hedging, m.metricsRegistry); err != nil {
+			return nil, err
+		}
+	}
+
+	if service.Locality != nil {
+		picker := locality.NewPicker(service.Locality, m.locality)
+		if picker == nil {
+			logger.Warn().Msg("Locality-aware load-balancing is disabled, as the location of Traefik is not configured")
+		} else {
+			lb.SetLocality(picker)
+		}
+	}
+
 	healthCheckTargets := make(map[string]*url.URL)
 
+	// The status of the servers is given to the balancer through the outlier detector, if any.
+	var statusSetter healthcheck.StatusSetter = lb
+	var outliers *healthcheck.OutlierDetector
+	if service.OutlierDetection != nil {
+		outliers = healthcheck.NewOutlierDetector(ctx, m.metricsRegistry, service.OutlierDetection, lb, info, healthCheckTargets)
+		statusSetter = outliers
+	}
+
 	for _, server := range shuffle(service.Servers, m.rand) {
 		hasher := fnv.New64a()
 		_, _ = hasher.Write([]byte(server.URL)) // this will never return an error.
@@ -297,7 +382,14 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 		logger.Debug().Str(logs.ServerName, proxyName).Stringer("target", target).
 			Msg("Creating server")
 
-		proxy := buildSingleHostProxy(target, passHostHeader, time.Duration(flushInterval), roundTripper, m.bufferPool)
+		var observer resultObserver
+		if outliers != nil {
+			observer = func(ctx context.Context, failed bool) {
+				outliers.Observe(ctx, proxyName, failed)
+			}
+		}
+
+		proxy := buildSingleHostProxy(target, passHostHeader, time.Duration(flushInterval), roundTripper, m.bufferPool, observer)
 
 		proxy = accesslog.NewFieldHandler(proxy, accesslog.ServiceURL, target.String(), nil)
 		proxy = accesslog.NewFieldHandler(proxy, accesslog.ServiceAddr, target.Host, nil)
@@ -308,6 +400,12 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 		}
 
 		lb.Add(proxyName, proxy, nil)
+		lb.SetServerLocality(proxyName, server.Zone, server.Region)
+
+		// servers keep their start time when the configuration is reloaded.
+		if started, ok := m.serverStarts[serverStartKey(serviceName, server.URL)]; ok {
+			lb.SetStartTime(proxyName, started)
+		}
 
 		// servers are considered UP by default.
 		info.UpdateServerStatus(target.String(), runtime.StatusUp)
@@ -320,13 +418,17 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 			ctx,
 			m.metricsRegistry,
 			service.HealthCheck,
-			lb,
+			statusSetter,
 			info,
 			roundTripper,
 			healthCheckTargets,
 		)
 	}
 
+	if outliers != nil {
+		m.outlierDetectors[serviceName] = outliers
+	}
+
 	return lb, nil
 }
 
@@ -336,6 +438,11 @@ func (m *Manager) LaunchHealthCheck(ctx context.Context) {
 		logger := log.Ctx(ctx).With().Str(logs.ServiceName, serviceName).Logger()
 		go hc.Launch(logger.WithContext(ctx))
 	}
+
+	for serviceName, outliers := range m.outlierDetectors {
+		logger := log.Ctx(ctx).With().Str(logs.ServiceName, serviceName).Logger()
+		go outliers.Launch(logger.WithContext(ctx))
+	}
 }
 
 func shuffle[T any](values []T, r *rand.Rand) []T {
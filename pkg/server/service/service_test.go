@@ -12,6 +12,7 @@ import (
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
+	"github.com/traefik/traefik/v2/pkg/sticky"
 	"github.com/traefik/traefik/v2/pkg/testhelpers"
 )
 
@@ -56,6 +57,44 @@ func TestGetLoadBalancer(t *testing.T) {
 			fwd:         &MockForwarder{},
 			expectError: false,
 		},
+		{
+			desc:        "Succeeds when strategy is PeakEWMA",
+			serviceName: "test",
+			service: &dynamic.ServersLoadBalancer{
+				Strategy: dynamic.BalancerStrategyPeakEWMA,
+			},
+			fwd:         &MockForwarder{},
+			expectError: false,
+		},
+		{
+			desc:        "Succeeds when strategy is ConsistentHash",
+			serviceName: "test",
+			service: &dynamic.ServersLoadBalancer{
+				Strategy:       dynamic.BalancerStrategyConsistentHash,
+				ConsistentHash: &dynamic.ConsistentHash{Header: "X-Key", BoundedLoad: 125},
+			},
+			fwd:         &MockForwarder{},
+			expectError: false,
+		},
+		{
+			desc:        "Fails when consistent hash sources are mutually exclusive",
+			serviceName: "test",
+			service: &dynamic.ServersLoadBalancer{
+				Strategy:       dynamic.BalancerStrategyConsistentHash,
+				ConsistentHash: &dynamic.ConsistentHash{Header: "X-Key", Path: true},
+			},
+			fwd:         &MockForwarder{},
+			expectError: true,
+		},
+		{
+			desc:        "Fails when strategy is unknown",
+			serviceName: "test",
+			service: &dynamic.ServersLoadBalancer{
+				Strategy: "foobar",
+			},
+			fwd:         &MockForwarder{},
+			expectError: true,
+		},
 	}
 
 	for _, test := range testCases {
@@ -347,6 +386,24 @@ func TestManager_Build(t *testing.T) {
 			},
 			providerName: "provider-1",
 		},
+		{
+			desc:        "Service with outlier detection",
+			serviceName: "serviceName",
+			configs: map[string]*runtime.ServiceInfo{
+				"serviceName": {
+					Service: &dynamic.Service{
+						LoadBalancer: &dynamic.ServersLoadBalancer{
+							OutlierDetection: &dynamic.OutlierDetection{ConsecutiveErrors: 5},
+							Servers: []dynamic.Server{
+								{
+									URL: "http://foo",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -371,6 +428,103 @@ func TestManager_Build(t *testing.T) {
 	}
 }
 
+func TestManager_BuildFailover(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		failover      *dynamic.Failover
+		expectedError bool
+	}{
+		{
+			desc:     "service and fallback",
+			failover: &dynamic.Failover{Service: "main", Fallback: "backup"},
+		},
+		{
+			desc: "tiers",
+			failover: &dynamic.Failover{
+				Tiers: []dynamic.FailoverTier{
+					{Services: []dynamic.WRRService{{Name: "main"}, {Name: "backup"}}, MinHealthy: 2},
+					{Services: []dynamic.WRRService{{Name: "unhealthy"}}},
+				},
+			},
+		},
+		{
+			desc: "tiers along with service",
+			failover: &dynamic.Failover{
+				Service: "main",
+				Tiers: []dynamic.FailoverTier{
+					{Services: []dynamic.WRRService{{Name: "backup"}}},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			desc: "tier without service",
+			failover: &dynamic.Failover{
+				Tiers: []dynamic.FailoverTier{
+					{},
+					{Services: []dynamic.WRRService{{Name: "backup"}}},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			desc: "tier child without health check",
+			failover: &dynamic.Failover{
+				Tiers: []dynamic.FailoverTier{
+					{Services: []dynamic.WRRService{{Name: "unhealthy"}}},
+					{Services: []dynamic.WRRService{{Name: "main"}}},
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			healthCheckedService := func() *runtime.ServiceInfo {
+				return &runtime.ServiceInfo{
+					Service: &dynamic.Service{
+						LoadBalancer: &dynamic.ServersLoadBalancer{
+							HealthCheck: &dynamic.ServerHealthCheck{Path: "/health"},
+							Servers:     []dynamic.Server{{URL: "http://foo"}},
+						},
+					},
+				}
+			}
+
+			configs := map[string]*runtime.ServiceInfo{
+				"failover": {Service: &dynamic.Service{Failover: test.failover}},
+				"main":     healthCheckedService(),
+				"backup":   healthCheckedService(),
+				"unhealthy": {
+					Service: &dynamic.Service{
+						LoadBalancer: &dynamic.ServersLoadBalancer{
+							Servers: []dynamic.Server{{URL: "http://bar"}},
+						},
+					},
+				},
+			}
+
+			manager := NewManager(configs, nil, nil, &RoundTripperManager{
+				roundTrippers: map[string]http.RoundTripper{
+					"default@internal": http.DefaultTransport,
+				},
+			})
+
+			_, err := manager.BuildHTTP(context.Background(), "failover")
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestMultipleTypeOnBuildHTTP(t *testing.T) {
 	services := map[string]*runtime.ServiceInfo{
 		"test@file": {
@@ -408,3 +562,94 @@ func newRtMock() RoundTripperGetter {
 func (r *rtMock) Get(_ string) (http.RoundTripper, error) {
 	return http.DefaultTransport, nil
 }
+
+func TestManager_stickySessionsAcrossRebuilds(t *testing.T) {
+	newServer := func(name string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-From", name)
+			if _, err := r.Cookie("JSESSIONID"); err != nil {
+				http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: name + "-session"})
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	server1 := newServer("first")
+	server2 := newServer("second")
+
+	testCases := []struct {
+		desc   string
+		sticky *dynamic.Sticky
+	}{
+		{
+			desc:   "opaque cookie",
+			sticky: &dynamic.Sticky{Cookie: &dynamic.Cookie{Name: "sticky", Opaque: true}},
+		},
+		{
+			desc:   "application session",
+			sticky: &dynamic.Sticky{AppSession: &dynamic.AppSession{Cookie: "JSESSIONID"}},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tables := sticky.NewTables()
+
+			// build returns the handler of the service, as built by a new manager on a dynamic configuration reload.
+			build := func() http.Handler {
+				t.Helper()
+
+				manager := NewManager(map[string]*runtime.ServiceInfo{
+					"test": {
+						Service: &dynamic.Service{
+							LoadBalancer: &dynamic.ServersLoadBalancer{
+								Sticky: test.sticky,
+								Servers: []dynamic.Server{
+									{URL: server1.URL},
+									{URL: server2.URL},
+								},
+							},
+						},
+					},
+				}, nil, nil, &RoundTripperManager{
+					roundTrippers: map[string]http.RoundTripper{
+						"default@internal": http.DefaultTransport,
+					},
+				})
+				manager.sessionTables = tables
+
+				handler, err := manager.BuildHTTP(context.Background(), "test")
+				require.NoError(t, err)
+
+				return handler
+			}
+
+			rw := httptest.NewRecorder()
+			build().ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "http://foo", nil))
+
+			from := rw.Header().Get("X-From")
+			require.NotEmpty(t, from)
+
+			cookies := rw.Result().Cookies()
+			require.NotEmpty(t, cookies)
+
+			handler := build()
+			for i := 0; i < 4; i++ {
+				req := httptest.NewRequest(http.MethodGet, "http://foo", nil)
+				for _, cookie := range cookies {
+					req.AddCookie(cookie)
+				}
+
+				rw := httptest.NewRecorder()
+				handler.ServeHTTP(rw, req)
+
+				assert.Equal(t, from, rw.Header().Get("X-From"))
+			}
+		})
+	}
+}
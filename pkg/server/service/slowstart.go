@@ -0,0 +1,58 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+// serverStarts records the time at which the servers of the load-balancers with a slow start were first seen,
+// across the dynamic configuration reloads, so that only the newly added servers are slowly started.
+type serverStarts struct {
+	mu     sync.Mutex
+	starts map[string]time.Time
+}
+
+func newServerStarts() *serverStarts {
+	return &serverStarts{starts: make(map[string]time.Time)}
+}
+
+// update records the servers of the given services first seen at the given time,
+// forgets the servers which are not part of the services anymore,
+// and returns the start times of the servers, keyed by serverStartKey.
+func (s *serverStarts) update(services map[string]*runtime.ServiceInfo, now time.Time) map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	starts := make(map[string]time.Time)
+	for serviceName, info := range services {
+		if info == nil || info.Service == nil || info.LoadBalancer == nil || info.LoadBalancer.SlowStart <= 0 {
+			continue
+		}
+
+		for _, server := range info.LoadBalancer.Servers {
+			key := serverStartKey(serviceName, server.URL)
+
+			start, ok := s.starts[key]
+			if !ok {
+				start = now
+			}
+
+			starts[key] = start
+		}
+	}
+
+	s.starts = starts
+
+	copied := make(map[string]time.Time, len(starts))
+	for key, start := range starts {
+		copied[key] = start
+	}
+
+	return copied
+}
+
+func serverStartKey(serviceName, serverURL string) string {
+	return serviceName + " " + serverURL
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/config/runtime"
+)
+
+func TestServerStarts_update(t *testing.T) {
+	services := func(slowStart ptypes.Duration, urls ...string) map[string]*runtime.ServiceInfo {
+		var servers []dynamic.Server
+		for _, u := range urls {
+			servers = append(servers, dynamic.Server{URL: u})
+		}
+
+		return map[string]*runtime.ServiceInfo{
+			"foo@file": {
+				Service: &dynamic.Service{
+					LoadBalancer: &dynamic.ServersLoadBalancer{
+						SlowStart: slowStart,
+						Servers:   servers,
+					},
+				},
+			},
+			"bar@file": {
+				Service: &dynamic.Service{
+					Weighted: &dynamic.WeightedRoundRobin{},
+				},
+			},
+		}
+	}
+
+	start := time.Now()
+	starts := newServerStarts()
+
+	got := starts.update(services(ptypes.Duration(time.Minute), "http://a", "http://b"), start)
+	assert.Equal(t, map[string]time.Time{
+		"foo@file http://a": start,
+		"foo@file http://b": start,
+	}, got)
+
+	// The servers which are still there keep their start time.
+	later := start.Add(time.Minute)
+	got = starts.update(services(ptypes.Duration(time.Minute), "http://b", "http://c"), later)
+	assert.Equal(t, map[string]time.Time{
+		"foo@file http://b": start,
+		"foo@file http://c": later,
+	}, got)
+
+	// The removed servers start again when they come back.
+	evenLater := later.Add(time.Minute)
+	got = starts.update(services(ptypes.Duration(time.Minute), "http://a", "http://b"), evenLater)
+	assert.Equal(t, map[string]time.Time{
+		"foo@file http://a": evenLater,
+		"foo@file http://b": start,
+	}, got)
+
+	// The servers are not recorded without slow start.
+	got = starts.update(services(0, "http://a", "http://b"), evenLater)
+	assert.Empty(t, got)
+}
@@ -4,35 +4,79 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"net"
+	"reflect"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/healthcheck"
+	"github.com/traefik/traefik/v2/pkg/locality"
 	"github.com/traefik/traefik/v2/pkg/logs"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
+	"github.com/traefik/traefik/v2/pkg/sticky"
 	"github.com/traefik/traefik/v2/pkg/tcp"
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 // Manager is the TCPHandlers factory.
 type Manager struct {
-	configs map[string]*runtime.TCPServiceInfo
-	rand    *rand.Rand // For the initial shuffling of load-balancers.
+	configs        map[string]*runtime.TCPServiceInfo
+	rand           *rand.Rand // For the initial shuffling of load-balancers.
+	healthCheckers map[string]*healthcheck.TCPServiceHealthChecker
+	// services holds the handlers already built, by qualified service name,
+	// so that a service used by several routers or parent services is built, and health checked, once.
+	services map[string]tcp.Handler
+	// locality is the location of this Traefik instance, for the locality-aware load-balancing.
+	locality *types.Locality
+	// sessionTables holds the affinity tables of the services with sticky sessions, kept across the managers.
+	sessionTables *sticky.Tables
 }
 
 // NewManager creates a new manager.
 func NewManager(conf *runtime.Configuration) *Manager {
 	return &Manager{
-		configs: conf.TCPServices,
-		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		configs:        conf.TCPServices,
+		rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		healthCheckers: make(map[string]*healthcheck.TCPServiceHealthChecker),
+		services:       make(map[string]tcp.Handler),
 	}
 }
 
+// SetLocality sets the location of this Traefik instance, for the locality-aware load-balancing.
+func (m *Manager) SetLocality(location *types.Locality) {
+	m.locality = location
+}
+
+// SetSessionTables sets the tables holding the affinities of the services with sticky sessions,
+// so that they are kept across the dynamic configuration reloads.
+func (m *Manager) SetSessionTables(tables *sticky.Tables) {
+	m.sessionTables = tables
+}
+
 // BuildTCP Creates a tcp.Handler for a service configuration.
 func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Handler, error) {
 	serviceQualifiedName := provider.GetQualifiedName(rootCtx, serviceName)
 
+	if handler, ok := m.services[serviceQualifiedName]; ok {
+		return handler, nil
+	}
+
+	handler, err := m.buildTCP(rootCtx, serviceQualifiedName)
+	if err != nil {
+		return nil, err
+	}
+
+	m.services[serviceQualifiedName] = handler
+
+	return handler, nil
+}
+
+func (m *Manager) buildTCP(rootCtx context.Context, serviceQualifiedName string) (tcp.Handler, error) {
+
 	logger := log.Ctx(rootCtx).With().Str(logs.ServiceName, serviceQualifiedName).Logger()
 	ctx := provider.AddInContext(rootCtx, serviceQualifiedName)
 
@@ -41,7 +85,7 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 		return nil, fmt.Errorf("the service %q does not exist", serviceQualifiedName)
 	}
 
-	if conf.LoadBalancer != nil && conf.Weighted != nil {
+	if countTypes(conf.TCPService) > 1 {
 		err := errors.New("cannot create service: multi-types service not supported, consider declaring two different pieces of service instead")
 		conf.AddError(err, true)
 		return nil, err
@@ -49,7 +93,26 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 
 	switch {
 	case conf.LoadBalancer != nil:
-		loadBalancer := tcp.NewWRRLoadBalancer()
+		switch conf.LoadBalancer.Strategy {
+		case "", dynamic.BalancerStrategyRoundRobin, dynamic.TCPBalancerStrategyLeastConnections, dynamic.BalancerStrategyPeakEWMA:
+		default:
+			err := fmt.Errorf("unknown load-balancing strategy %q", conf.LoadBalancer.Strategy)
+			conf.AddError(err, true)
+			return nil, err
+		}
+
+		loadBalancer, err := tcp.NewWRRLoadBalancer(conf.LoadBalancer.Strategy, conf.LoadBalancer.Sticky)
+		if err != nil {
+			conf.AddError(err, true)
+			return nil, err
+		}
+
+		if m.sessionTables != nil {
+			if err := loadBalancer.ShareSessions(m.sessionTables, serviceQualifiedName); err != nil {
+				conf.AddError(err, true)
+				return nil, err
+			}
+		}
 
 		if conf.LoadBalancer.TerminationDelay == nil {
 			defaultTerminationDelay := 100
@@ -57,6 +120,17 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 		}
 		duration := time.Duration(*conf.LoadBalancer.TerminationDelay) * time.Millisecond
 
+		if conf.LoadBalancer.Locality != nil {
+			picker := locality.NewPicker(conf.LoadBalancer.Locality, m.locality)
+			if picker == nil {
+				logger.Warn().Msg("Locality-aware load-balancing is disabled, as the location of Traefik is not configured")
+			} else {
+				loadBalancer.SetLocality(picker)
+			}
+		}
+
+		healthCheckTargets := make(map[string]string)
+
 		for index, server := range shuffle(conf.LoadBalancer.Servers, m.rand) {
 			srvLogger := logger.With().
 				Int(logs.ServerIndex, index).
@@ -73,14 +147,40 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 				continue
 			}
 
-			loadBalancer.AddServer(handler)
+			hasher := fnv.New64a()
+			_, _ = hasher.Write([]byte(server.Address)) // this will never return an error.
+
+			proxyName := fmt.Sprintf("%x", hasher.Sum(nil))
+
+			loadBalancer.AddServer(proxyName, handler)
+			loadBalancer.SetServerLocality(proxyName, server.Zone, server.Region)
+
+			// servers are considered UP by default.
+			conf.UpdateServerStatus(server.Address, runtime.StatusUp)
+
+			healthCheckTargets[proxyName] = server.Address
+
 			logger.Debug().Msg("Creating TCP server")
 		}
 
+		if conf.LoadBalancer.HealthCheck != nil {
+			hc, err := healthcheck.NewTCPServiceHealthChecker(ctx, conf.LoadBalancer.HealthCheck, loadBalancer, conf, healthCheckTargets)
+			if err != nil {
+				conf.AddError(err, true)
+				return nil, err
+			}
+
+			m.healthCheckers[serviceQualifiedName] = hc
+		}
+
 		return loadBalancer, nil
 
 	case conf.Weighted != nil:
-		loadBalancer := tcp.NewWRRLoadBalancer()
+		loadBalancer, err := tcp.NewWRRLoadBalancer(dynamic.BalancerStrategyRoundRobin, nil)
+		if err != nil {
+			conf.AddError(err, true)
+			return nil, err
+		}
 
 		for _, service := range shuffle(conf.Weighted.Services, m.rand) {
 			handler, err := m.BuildTCP(ctx, service.Name)
@@ -89,7 +189,62 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 				return nil, err
 			}
 
-			loadBalancer.AddWeightServer(handler, service.Weight)
+			loadBalancer.AddWeightServer(service.Name, handler, service.Weight)
+
+			updater, ok := handler.(healthcheck.StatusUpdater)
+			if !ok {
+				continue
+			}
+
+			childName := service.Name
+			if err := updater.RegisterStatusUpdater(func(up bool) {
+				loadBalancer.SetStatus(ctx, childName, up)
+			}); err != nil {
+				return nil, fmt.Errorf("cannot register %v as updater for %v: %w", childName, serviceQualifiedName, err)
+			}
+		}
+
+		return loadBalancer, nil
+
+	case conf.Failover != nil:
+		if len(conf.Failover.Tiers) == 0 {
+			err := errors.New("failover does not have any tier")
+			conf.AddError(err, true)
+			return nil, err
+		}
+
+		loadBalancer := tcp.NewFailoverLoadBalancer(time.Duration(conf.Failover.FailbackDelay))
+
+		for index, tier := range conf.Failover.Tiers {
+			if len(tier.Services) == 0 {
+				err := fmt.Errorf("tier %d does not have any service", index)
+				conf.AddError(err, true)
+				return nil, err
+			}
+
+			tierIndex := loadBalancer.AddTier(tier.MinHealthy)
+
+			for _, service := range tier.Services {
+				handler, err := m.BuildTCP(ctx, service.Name)
+				if err != nil {
+					logger.Error().Err(err).Msg("Failed to build TCP handler")
+					return nil, err
+				}
+
+				loadBalancer.AddWeightServer(tierIndex, service.Name, handler, service.Weight)
+
+				updater, ok := handler.(healthcheck.StatusUpdater)
+				if !ok {
+					continue
+				}
+
+				childName := service.Name
+				if err := updater.RegisterStatusUpdater(func(up bool) {
+					loadBalancer.SetStatus(ctx, tierIndex, childName, up)
+				}); err != nil {
+					return nil, fmt.Errorf("cannot register %v as updater for %v: %w", childName, serviceQualifiedName, err)
+				}
+			}
 		}
 
 		return loadBalancer, nil
@@ -101,6 +256,27 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 	}
 }
 
+// LaunchHealthCheck launches the health checks.
+func (m *Manager) LaunchHealthCheck(ctx context.Context) {
+	for serviceName, hc := range m.healthCheckers {
+		logger := log.Ctx(ctx).With().Str(logs.ServiceName, serviceName).Logger()
+		go hc.Launch(logger.WithContext(ctx))
+	}
+}
+
+// countTypes returns the number of kinds of service defined by the given configuration.
+func countTypes(conf *dynamic.TCPService) int {
+	value := reflect.ValueOf(*conf)
+	var count int
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Field(i).IsNil() {
+			count++
+		}
+	}
+
+	return count
+}
+
 func shuffle[T any](values []T, r *rand.Rand) []T {
 	shuffled := make([]T, len(values))
 	copy(shuffled, values)
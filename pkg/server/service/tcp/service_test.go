@@ -2,12 +2,16 @@ package tcp
 
 import (
 	"context"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/healthcheck"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
 )
 
@@ -171,6 +175,151 @@ func TestManager_BuildTCP(t *testing.T) {
 			},
 			providerName: "provider-1",
 		},
+		{
+			desc:        "Server with health check",
+			serviceName: "serviceName",
+			configs: map[string]*runtime.TCPServiceInfo{
+				"serviceName@provider-1": {
+					TCPService: &dynamic.TCPService{
+						LoadBalancer: &dynamic.TCPServersLoadBalancer{
+							Servers: []dynamic.TCPServer{
+								{
+									Address: "192.168.0.12:80",
+								},
+							},
+							HealthCheck: &dynamic.TCPServerHealthCheck{
+								Mode:    "tls",
+								Payload: "PING\r\n",
+								Expect:  "^\\+PONG",
+							},
+						},
+					},
+				},
+			},
+			providerName: "provider-1",
+		},
+		{
+			desc:        "unknown health check mode",
+			serviceName: "serviceName",
+			configs: map[string]*runtime.TCPServiceInfo{
+				"serviceName@provider-1": {
+					TCPService: &dynamic.TCPService{
+						LoadBalancer: &dynamic.TCPServersLoadBalancer{
+							Servers: []dynamic.TCPServer{
+								{
+									Address: "192.168.0.12:80",
+								},
+							},
+							HealthCheck: &dynamic.TCPServerHealthCheck{
+								Mode: "foobar",
+							},
+						},
+					},
+				},
+			},
+			providerName:  "provider-1",
+			expectedError: `unknown health check mode "foobar"`,
+		},
+		{
+			desc:        "unknown load-balancing strategy",
+			serviceName: "serviceName",
+			configs: map[string]*runtime.TCPServiceInfo{
+				"serviceName@provider-1": {
+					TCPService: &dynamic.TCPService{
+						LoadBalancer: &dynamic.TCPServersLoadBalancer{
+							Servers: []dynamic.TCPServer{
+								{
+									Address: "192.168.0.12:80",
+								},
+							},
+							Strategy: "foobar",
+						},
+					},
+				},
+			},
+			providerName:  "provider-1",
+			expectedError: `unknown load-balancing strategy "foobar"`,
+		},
+		{
+			desc:        "Server with least connections strategy",
+			serviceName: "serviceName",
+			configs: map[string]*runtime.TCPServiceInfo{
+				"serviceName@provider-1": {
+					TCPService: &dynamic.TCPService{
+						LoadBalancer: &dynamic.TCPServersLoadBalancer{
+							Servers: []dynamic.TCPServer{
+								{
+									Address: "192.168.0.12:80",
+								},
+							},
+							Strategy: dynamic.TCPBalancerStrategyLeastConnections,
+						},
+					},
+				},
+			},
+			providerName: "provider-1",
+		},
+		{
+			desc:        "failover with tiers",
+			serviceName: "failover",
+			configs: map[string]*runtime.TCPServiceInfo{
+				"failover": {
+					TCPService: &dynamic.TCPService{
+						Failover: &dynamic.TCPFailover{
+							Tiers: []dynamic.TCPFailoverTier{
+								{Services: []dynamic.TCPWRRService{{Name: "main"}}},
+								{Services: []dynamic.TCPWRRService{{Name: "backup"}}, MinHealthy: 1},
+							},
+						},
+					},
+				},
+				"main": {
+					TCPService: &dynamic.TCPService{
+						LoadBalancer: &dynamic.TCPServersLoadBalancer{
+							Servers: []dynamic.TCPServer{
+								{Address: "192.168.0.12:80"},
+							},
+						},
+					},
+				},
+				"backup": {
+					TCPService: &dynamic.TCPService{
+						LoadBalancer: &dynamic.TCPServersLoadBalancer{
+							Servers: []dynamic.TCPServer{
+								{Address: "192.168.0.13:80"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:        "failover tier without service",
+			serviceName: "failover",
+			configs: map[string]*runtime.TCPServiceInfo{
+				"failover": {
+					TCPService: &dynamic.TCPService{
+						Failover: &dynamic.TCPFailover{
+							Tiers: []dynamic.TCPFailoverTier{{}},
+						},
+					},
+				},
+			},
+			expectedError: "tier 0 does not have any service",
+		},
+		{
+			desc:        "multi-types service",
+			serviceName: "test",
+			configs: map[string]*runtime.TCPServiceInfo{
+				"test": {
+					TCPService: &dynamic.TCPService{
+						Weighted: &dynamic.TCPWeightedRoundRobin{},
+						Failover: &dynamic.TCPFailover{},
+					},
+				},
+			},
+			expectedError: "cannot create service: multi-types service not supported, consider declaring two different pieces of service instead",
+		},
 	}
 
 	for _, test := range testCases {
@@ -199,3 +348,61 @@ func TestManager_BuildTCP(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_BuildTCP_sharedService(t *testing.T) {
+	// The server is down, as nothing listens on its address anymore.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	manager := NewManager(&runtime.Configuration{
+		TCPServices: map[string]*runtime.TCPServiceInfo{
+			"shared@provider-1": {
+				TCPService: &dynamic.TCPService{
+					LoadBalancer: &dynamic.TCPServersLoadBalancer{
+						Servers: []dynamic.TCPServer{{Address: address}},
+						HealthCheck: &dynamic.TCPServerHealthCheck{
+							Interval: ptypes.Duration(10 * time.Millisecond),
+							Timeout:  ptypes.Duration(5 * time.Millisecond),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	ctx := provider.AddInContext(context.Background(), "router@provider-1")
+
+	// The service is used by two routers.
+	var statuses []chan bool
+	for i := 0; i < 2; i++ {
+		handler, err := manager.BuildTCP(ctx, "shared")
+		require.NoError(t, err)
+
+		updater, ok := handler.(healthcheck.StatusUpdater)
+		require.True(t, ok)
+
+		status := make(chan bool, 1)
+		require.NoError(t, updater.RegisterStatusUpdater(func(up bool) {
+			select {
+			case status <- up:
+			default:
+			}
+		}))
+		statuses = append(statuses, status)
+	}
+
+	hcCtx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	manager.LaunchHealthCheck(hcCtx)
+
+	for i, status := range statuses {
+		select {
+		case up := <-status:
+			assert.False(t, up, "router %d", i)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("router %d was not told that the service is down", i)
+		}
+	}
+}
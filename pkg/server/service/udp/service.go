@@ -4,35 +4,70 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"net"
+	"reflect"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/healthcheck"
 	"github.com/traefik/traefik/v2/pkg/logs"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
+	"github.com/traefik/traefik/v2/pkg/sticky"
 	"github.com/traefik/traefik/v2/pkg/udp"
 )
 
 // Manager handles UDP services creation.
 type Manager struct {
-	configs map[string]*runtime.UDPServiceInfo
-	rand    *rand.Rand // For the initial shuffling of load-balancers.
+	configs        map[string]*runtime.UDPServiceInfo
+	rand           *rand.Rand // For the initial shuffling of load-balancers.
+	healthCheckers map[string]*healthcheck.UDPServiceHealthChecker
+	// services holds the handlers already built, by qualified service name,
+	// so that a service used by several routers or parent services is built, and health checked, once.
+	services map[string]udp.Handler
+	// sessionTables holds the affinity tables of the services with sticky sessions, kept across the managers.
+	sessionTables *sticky.Tables
 }
 
 // NewManager creates a new manager.
 func NewManager(conf *runtime.Configuration) *Manager {
 	return &Manager{
-		configs: conf.UDPServices,
-		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		configs:        conf.UDPServices,
+		rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		healthCheckers: make(map[string]*healthcheck.UDPServiceHealthChecker),
+		services:       make(map[string]udp.Handler),
 	}
 }
 
+// SetSessionTables sets the tables holding the affinities of the services with sticky sessions,
+// so that they are kept across the dynamic configuration reloads.
+func (m *Manager) SetSessionTables(tables *sticky.Tables) {
+	m.sessionTables = tables
+}
+
 // BuildUDP creates the UDP handler for the given service name.
 func (m *Manager) BuildUDP(rootCtx context.Context, serviceName string) (udp.Handler, error) {
 	serviceQualifiedName := provider.GetQualifiedName(rootCtx, serviceName)
 
+	if handler, ok := m.services[serviceQualifiedName]; ok {
+		return handler, nil
+	}
+
+	handler, err := m.buildUDP(rootCtx, serviceQualifiedName)
+	if err != nil {
+		return nil, err
+	}
+
+	m.services[serviceQualifiedName] = handler
+
+	return handler, nil
+}
+
+func (m *Manager) buildUDP(rootCtx context.Context, serviceQualifiedName string) (udp.Handler, error) {
+
 	logger := log.Ctx(rootCtx).With().Str(logs.ServiceName, serviceQualifiedName).Logger()
 	ctx := provider.AddInContext(rootCtx, serviceQualifiedName)
 
@@ -41,7 +76,7 @@ func (m *Manager) BuildUDP(rootCtx context.Context, serviceName string) (udp.Han
 		return nil, fmt.Errorf("the UDP service %q does not exist", serviceQualifiedName)
 	}
 
-	if conf.LoadBalancer != nil && conf.Weighted != nil {
+	if countTypes(conf.UDPService) > 1 {
 		err := errors.New("cannot create service: multi-types service not supported, consider declaring two different pieces of service instead")
 		conf.AddError(err, true)
 		return nil, err
@@ -49,7 +84,20 @@ func (m *Manager) BuildUDP(rootCtx context.Context, serviceName string) (udp.Han
 
 	switch {
 	case conf.LoadBalancer != nil:
-		loadBalancer := udp.NewWRRLoadBalancer()
+		loadBalancer, err := udp.NewWRRLoadBalancer(conf.LoadBalancer.Sticky)
+		if err != nil {
+			conf.AddError(err, true)
+			return nil, err
+		}
+
+		if m.sessionTables != nil {
+			if err := loadBalancer.ShareSessions(m.sessionTables, serviceQualifiedName); err != nil {
+				conf.AddError(err, true)
+				return nil, err
+			}
+		}
+
+		healthCheckTargets := make(map[string]string)
 
 		for index, server := range shuffle(conf.LoadBalancer.Servers, m.rand) {
 			srvLogger := logger.With().
@@ -67,14 +115,39 @@ func (m *Manager) BuildUDP(rootCtx context.Context, serviceName string) (udp.Han
 				continue
 			}
 
-			loadBalancer.AddServer(handler)
+			hasher := fnv.New64a()
+			_, _ = hasher.Write([]byte(server.Address)) // this will never return an error.
+
+			proxyName := fmt.Sprintf("%x", hasher.Sum(nil))
+
+			loadBalancer.AddServer(proxyName, handler)
+
+			// servers are considered UP by default.
+			conf.UpdateServerStatus(server.Address, runtime.StatusUp)
+
+			healthCheckTargets[proxyName] = server.Address
+
 			srvLogger.Debug().Msg("Creating UDP server")
 		}
 
+		if conf.LoadBalancer.HealthCheck != nil {
+			hc, err := healthcheck.NewUDPServiceHealthChecker(ctx, conf.LoadBalancer.HealthCheck, loadBalancer, conf, healthCheckTargets)
+			if err != nil {
+				conf.AddError(err, true)
+				return nil, err
+			}
+
+			m.healthCheckers[serviceQualifiedName] = hc
+		}
+
 		return loadBalancer, nil
 
 	case conf.Weighted != nil:
-		loadBalancer := udp.NewWRRLoadBalancer()
+		loadBalancer, err := udp.NewWRRLoadBalancer(nil)
+		if err != nil {
+			conf.AddError(err, true)
+			return nil, err
+		}
 
 		for _, service := range shuffle(conf.Weighted.Services, m.rand) {
 			handler, err := m.BuildUDP(ctx, service.Name)
@@ -83,7 +156,62 @@ func (m *Manager) BuildUDP(rootCtx context.Context, serviceName string) (udp.Han
 				return nil, err
 			}
 
-			loadBalancer.AddWeightedServer(handler, service.Weight)
+			loadBalancer.AddWeightedServer(service.Name, handler, service.Weight)
+
+			updater, ok := handler.(healthcheck.StatusUpdater)
+			if !ok {
+				continue
+			}
+
+			childName := service.Name
+			if err := updater.RegisterStatusUpdater(func(up bool) {
+				loadBalancer.SetStatus(ctx, childName, up)
+			}); err != nil {
+				return nil, fmt.Errorf("cannot register %v as updater for %v: %w", childName, serviceQualifiedName, err)
+			}
+		}
+
+		return loadBalancer, nil
+
+	case conf.Failover != nil:
+		if len(conf.Failover.Tiers) == 0 {
+			err := errors.New("failover does not have any tier")
+			conf.AddError(err, true)
+			return nil, err
+		}
+
+		loadBalancer := udp.NewFailoverLoadBalancer(time.Duration(conf.Failover.FailbackDelay))
+
+		for index, tier := range conf.Failover.Tiers {
+			if len(tier.Services) == 0 {
+				err := fmt.Errorf("tier %d does not have any service", index)
+				conf.AddError(err, true)
+				return nil, err
+			}
+
+			tierIndex := loadBalancer.AddTier(tier.MinHealthy)
+
+			for _, service := range tier.Services {
+				handler, err := m.BuildUDP(ctx, service.Name)
+				if err != nil {
+					logger.Error().Err(err).Msg("Failed to build UDP handler")
+					return nil, err
+				}
+
+				loadBalancer.AddWeightedServer(tierIndex, service.Name, handler, service.Weight)
+
+				updater, ok := handler.(healthcheck.StatusUpdater)
+				if !ok {
+					continue
+				}
+
+				childName := service.Name
+				if err := updater.RegisterStatusUpdater(func(up bool) {
+					loadBalancer.SetStatus(ctx, tierIndex, childName, up)
+				}); err != nil {
+					return nil, fmt.Errorf("cannot register %v as updater for %v: %w", childName, serviceQualifiedName, err)
+				}
+			}
 		}
 
 		return loadBalancer, nil
@@ -95,6 +223,27 @@ func (m *Manager) BuildUDP(rootCtx context.Context, serviceName string) (udp.Han
 	}
 }
 
+// LaunchHealthCheck launches the health checks.
+func (m *Manager) LaunchHealthCheck(ctx context.Context) {
+	for serviceName, hc := range m.healthCheckers {
+		logger := log.Ctx(ctx).With().Str(logs.ServiceName, serviceName).Logger()
+		go hc.Launch(logger.WithContext(ctx))
+	}
+}
+
+// countTypes returns the number of kinds of service defined by the given configuration.
+func countTypes(conf *dynamic.UDPService) int {
+	value := reflect.ValueOf(*conf)
+	var count int
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Field(i).IsNil() {
+			count++
+		}
+	}
+
+	return count
+}
+
 func shuffle[T any](values []T, r *rand.Rand) []T {
 	shuffled := make([]T, len(values))
 	copy(shuffled, values)
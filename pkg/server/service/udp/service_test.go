@@ -2,12 +2,16 @@ package udp
 
 import (
 	"context"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v2/pkg/config/dynamic"
 	"github.com/traefik/traefik/v2/pkg/config/runtime"
+	"github.com/traefik/traefik/v2/pkg/healthcheck"
 	"github.com/traefik/traefik/v2/pkg/server/provider"
 )
 
@@ -171,6 +175,112 @@ func TestManager_BuildUDP(t *testing.T) {
 			},
 			providerName: "provider-1",
 		},
+		{
+			desc:        "Server with sticky and health check",
+			serviceName: "serviceName",
+			configs: map[string]*runtime.UDPServiceInfo{
+				"serviceName@provider-1": {
+					UDPService: &dynamic.UDPService{
+						LoadBalancer: &dynamic.UDPServersLoadBalancer{
+							Servers: []dynamic.UDPServer{
+								{
+									Address: "192.168.0.12:80",
+								},
+							},
+							Sticky: &dynamic.UDPSticky{},
+							HealthCheck: &dynamic.UDPServerHealthCheck{
+								Payload: "ping",
+								Expect:  "^pong$",
+							},
+						},
+					},
+				},
+			},
+			providerName: "provider-1",
+		},
+		{
+			desc:        "invalid health check expected reply pattern",
+			serviceName: "serviceName",
+			configs: map[string]*runtime.UDPServiceInfo{
+				"serviceName@provider-1": {
+					UDPService: &dynamic.UDPService{
+						LoadBalancer: &dynamic.UDPServersLoadBalancer{
+							Servers: []dynamic.UDPServer{
+								{
+									Address: "192.168.0.12:80",
+								},
+							},
+							HealthCheck: &dynamic.UDPServerHealthCheck{
+								Expect: "(",
+							},
+						},
+					},
+				},
+			},
+			providerName:  "provider-1",
+			expectedError: "compiling expected reply pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			desc:        "failover with tiers",
+			serviceName: "failover",
+			configs: map[string]*runtime.UDPServiceInfo{
+				"failover": {
+					UDPService: &dynamic.UDPService{
+						Failover: &dynamic.UDPFailover{
+							Tiers: []dynamic.UDPFailoverTier{
+								{Services: []dynamic.UDPWRRService{{Name: "main"}}},
+								{Services: []dynamic.UDPWRRService{{Name: "backup"}}, MinHealthy: 1},
+							},
+						},
+					},
+				},
+				"main": {
+					UDPService: &dynamic.UDPService{
+						LoadBalancer: &dynamic.UDPServersLoadBalancer{
+							Servers: []dynamic.UDPServer{
+								{Address: "192.168.0.12:80"},
+							},
+						},
+					},
+				},
+				"backup": {
+					UDPService: &dynamic.UDPService{
+						LoadBalancer: &dynamic.UDPServersLoadBalancer{
+							Servers: []dynamic.UDPServer{
+								{Address: "192.168.0.13:80"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:        "failover tier without service",
+			serviceName: "failover",
+			configs: map[string]*runtime.UDPServiceInfo{
+				"failover": {
+					UDPService: &dynamic.UDPService{
+						Failover: &dynamic.UDPFailover{
+							Tiers: []dynamic.UDPFailoverTier{{}},
+						},
+					},
+				},
+			},
+			expectedError: "tier 0 does not have any service",
+		},
+		{
+			desc:        "multi-types service",
+			serviceName: "test",
+			configs: map[string]*runtime.UDPServiceInfo{
+				"test": {
+					UDPService: &dynamic.UDPService{
+						Weighted: &dynamic.UDPWeightedRoundRobin{},
+						Failover: &dynamic.UDPFailover{},
+					},
+				},
+			},
+			expectedError: "cannot create service: multi-types service not supported, consider declaring two different pieces of service instead",
+		},
 	}
 
 	for _, test := range testCases {
@@ -199,3 +309,62 @@ func TestManager_BuildUDP(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_BuildUDP_sharedService(t *testing.T) {
+	// The server is down, as nothing listens on its address anymore.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := conn.LocalAddr().String()
+	require.NoError(t, conn.Close())
+
+	manager := NewManager(&runtime.Configuration{
+		UDPServices: map[string]*runtime.UDPServiceInfo{
+			"shared@provider-1": {
+				UDPService: &dynamic.UDPService{
+					LoadBalancer: &dynamic.UDPServersLoadBalancer{
+						Servers: []dynamic.UDPServer{{Address: address}},
+						HealthCheck: &dynamic.UDPServerHealthCheck{
+							Interval: ptypes.Duration(20 * time.Millisecond),
+							Timeout:  ptypes.Duration(10 * time.Millisecond),
+							Payload:  "PING",
+						},
+					},
+				},
+			},
+		},
+	})
+
+	ctx := provider.AddInContext(context.Background(), "router@provider-1")
+
+	// The service is used by two routers.
+	var statuses []chan bool
+	for i := 0; i < 2; i++ {
+		handler, err := manager.BuildUDP(ctx, "shared")
+		require.NoError(t, err)
+
+		updater, ok := handler.(healthcheck.StatusUpdater)
+		require.True(t, ok)
+
+		status := make(chan bool, 1)
+		require.NoError(t, updater.RegisterStatusUpdater(func(up bool) {
+			select {
+			case status <- up:
+			default:
+			}
+		}))
+		statuses = append(statuses, status)
+	}
+
+	hcCtx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	manager.LaunchHealthCheck(hcCtx)
+
+	for i, status := range statuses {
+		select {
+		case up := <-status:
+			assert.False(t, up, "router %d", i)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("router %d was not told that the service is down", i)
+		}
+	}
+}
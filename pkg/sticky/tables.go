@@ -0,0 +1,56 @@
+// Package sticky keeps the session tables of the load balancers with sticky sessions
+// across the dynamic configuration reloads, as the load balancers are rebuilt on every reload.
+package sticky
+
+import (
+	"sync"
+
+	"github.com/mailgun/ttlmap"
+)
+
+// Tables holds the session tables, by service name and kind of session.
+type Tables struct {
+	mu     sync.Mutex
+	tables map[string]map[string]*ttlmap.TtlMap
+}
+
+// NewTables creates the session tables.
+func NewTables() *Tables {
+	return &Tables{tables: make(map[string]map[string]*ttlmap.TtlMap)}
+}
+
+// Get returns the table of the given kind of sessions of the given service,
+// created with the given capacity if it does not exist yet.
+func (t *Tables) Get(serviceName, kind string, capacity int) (*ttlmap.TtlMap, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if table, ok := t.tables[serviceName][kind]; ok {
+		return table, nil
+	}
+
+	table, err := ttlmap.NewConcurrent(capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.tables[serviceName] == nil {
+		t.tables[serviceName] = make(map[string]*ttlmap.TtlMap)
+	}
+	t.tables[serviceName][kind] = table
+
+	return table, nil
+}
+
+// Retain forgets the tables of the services for which exists returns false,
+// i.e. of the services which are not part of the dynamic configuration anymore.
+func (t *Tables) Retain(exists func(serviceName string) bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for serviceName := range t.tables {
+		if !exists(serviceName) {
+			delete(t.tables, serviceName)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package sticky
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTables(t *testing.T) {
+	tables := NewTables()
+
+	cookies, err := tables.Get("foo", "cookie", 10)
+	require.NoError(t, err)
+	require.NoError(t, cookies.Set("id", "server", 60))
+
+	sessions, err := tables.Get("foo", "appSession", 10)
+	require.NoError(t, err)
+	assert.NotSame(t, cookies, sessions)
+
+	// The table is kept while the service exists.
+	tables.Retain(func(serviceName string) bool { return serviceName == "foo" })
+
+	got, err := tables.Get("foo", "cookie", 10)
+	require.NoError(t, err)
+	assert.Same(t, cookies, got)
+
+	// The table is forgotten once the service is removed.
+	tables.Retain(func(serviceName string) bool { return false })
+
+	got, err = tables.Get("foo", "cookie", 10)
+	require.NoError(t, err)
+	assert.NotSame(t, cookies, got)
+	assert.Equal(t, 0, got.Len())
+
+	_, err = tables.Get("bar", "cookie", 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package tcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/priority"
+)
+
+// FailoverLoadBalancer forwards the connections to the first eligible tier of servers,
+// among tiers ordered by priority.
+// A tier is eligible when at least its minimum number of servers are healthy.
+type FailoverLoadBalancer struct {
+	selector *priority.Selector
+	tiers    []*WRRLoadBalancer
+}
+
+// NewFailoverLoadBalancer creates a new FailoverLoadBalancer, failing back to a higher priority tier after the given delay.
+func NewFailoverLoadBalancer(failbackDelay time.Duration) *FailoverLoadBalancer {
+	return &FailoverLoadBalancer{
+		selector: priority.NewSelector(failbackDelay),
+	}
+}
+
+// ServeTCP forwards the connection to the active tier.
+func (b *FailoverLoadBalancer) ServeTCP(conn WriteCloser) {
+	tier, ok := b.selector.Active()
+	if !ok {
+		log.Error().Err(errNoAvailableServer).Msg("Error during load balancing")
+		conn.Close()
+		return
+	}
+
+	b.tiers[tier].ServeTCP(conn)
+}
+
+// AddTier appends a tier of lower priority than the existing ones, and returns its index.
+// The tier is eligible when at least minHealthy of its servers are healthy.
+// Not thread safe.
+func (b *FailoverLoadBalancer) AddTier(minHealthy int) int {
+	// This never fails, as there is no sticky configuration.
+	balancer, _ := NewWRRLoadBalancer(dynamic.BalancerStrategyRoundRobin, nil)
+	b.tiers = append(b.tiers, balancer)
+
+	return b.selector.AddTier(minHealthy)
+}
+
+// AddWeightServer adds a server with a weight to the given tier.
+// The server is considered healthy until SetStatus is called with its name.
+// A server with a non-positive weight is ignored.
+func (b *FailoverLoadBalancer) AddWeightServer(tier int, name string, serverHandler Handler, weight *int) {
+	if weight != nil && *weight <= 0 {
+		return
+	}
+
+	b.tiers[tier].AddWeightServer(name, serverHandler, weight)
+	b.selector.AddService(tier, name)
+}
+
+// SetStatus sets the status of the given server of the given tier.
+func (b *FailoverLoadBalancer) SetStatus(ctx context.Context, tier int, name string, up bool) {
+	b.tiers[tier].SetStatus(ctx, name, up)
+	b.selector.SetStatus(ctx, tier, name, up)
+}
+
+// RegisterStatusUpdater adds fn to the list of hooks that are run when the status of the balancer changes.
+// Not thread safe.
+func (b *FailoverLoadBalancer) RegisterStatusUpdater(fn func(up bool)) error {
+	b.selector.RegisterStatusUpdater(fn)
+	return nil
+}
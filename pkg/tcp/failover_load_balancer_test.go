@@ -0,0 +1,73 @@
+package tcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailoverLoadBalancer(t *testing.T) {
+	balancer := NewFailoverLoadBalancer(0)
+
+	var updates []bool
+	err := balancer.RegisterStatusUpdater(func(up bool) {
+		updates = append(updates, up)
+	})
+	require.NoError(t, err)
+
+	primary := balancer.AddTier(2)
+	secondary := balancer.AddTier(1)
+
+	for tier, servers := range map[int][]string{primary: {"p1", "p2"}, secondary: {"s1"}} {
+		for _, server := range servers {
+			server := server
+			balancer.AddWeightServer(tier, server, HandlerFunc(func(conn WriteCloser) {
+				_, err := conn.Write([]byte(server))
+				require.NoError(t, err)
+			}), nil)
+		}
+	}
+
+	conn := &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 4; i++ {
+		balancer.ServeTCP(conn)
+	}
+	assert.Equal(t, map[string]int{"p1": 2, "p2": 2}, conn.writeCall)
+
+	// The primary tier is below its minimum of healthy servers.
+	balancer.SetStatus(context.Background(), primary, "p1", false)
+
+	conn = &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 2; i++ {
+		balancer.ServeTCP(conn)
+	}
+	assert.Equal(t, map[string]int{"s1": 2}, conn.writeCall)
+
+	balancer.SetStatus(context.Background(), secondary, "s1", false)
+	assert.Empty(t, updates)
+
+	// The primary tier is the only one with a healthy server.
+	conn = &fakeConn{writeCall: make(map[string]int)}
+	balancer.ServeTCP(conn)
+	assert.Equal(t, map[string]int{"p2": 1}, conn.writeCall)
+
+	balancer.SetStatus(context.Background(), primary, "p2", false)
+	assert.Equal(t, []bool{false}, updates)
+
+	conn = &fakeConn{writeCall: make(map[string]int)}
+	balancer.ServeTCP(conn)
+	assert.Empty(t, conn.writeCall)
+	assert.Equal(t, 1, conn.closeCall)
+
+	balancer.SetStatus(context.Background(), primary, "p1", true)
+	balancer.SetStatus(context.Background(), primary, "p2", true)
+	assert.Equal(t, []bool{false, true}, updates)
+
+	conn = &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 2; i++ {
+		balancer.ServeTCP(conn)
+	}
+	assert.Equal(t, map[string]int{"p1": 1, "p2": 1}, conn.writeCall)
+}
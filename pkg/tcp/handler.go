@@ -2,6 +2,8 @@ package tcp
 
 import (
 	"net"
+
+	"github.com/pires/go-proxyproto"
 )
 
 // Handler is the TCP Handlers interface.
@@ -26,3 +28,9 @@ type WriteCloser interface {
 	// It corresponds to sending a FIN packet.
 	CloseWrite() error
 }
+
+// ProxyProtocolConn is implemented by the connections which can carry a PROXY protocol header.
+type ProxyProtocolConn interface {
+	// ProxyTLVs returns the TLVs of the PROXY protocol v2 header of the connection, if any.
+	ProxyTLVs() []proxyproto.TLV
+}
@@ -19,6 +19,9 @@ type Proxy struct {
 	tcpAddr          *net.TCPAddr
 	terminationDelay time.Duration
 	proxyProtocol    *dynamic.ProxyProtocol
+
+	// dialObserver, if set, is called with the duration of each dial to the backend.
+	dialObserver func(latency time.Duration, err error)
 }
 
 // NewProxy creates a new Proxy.
@@ -53,7 +56,11 @@ func (p *Proxy) ServeTCP(conn WriteCloser) {
 	// needed because of e.g. server.trackedConnection
 	defer conn.Close()
 
+	start := time.Now()
 	connBackend, err := p.dialBackend()
+	if p.dialObserver != nil {
+		p.dialObserver(time.Since(start), err)
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("Error while connecting to backend")
 		return
@@ -89,6 +96,10 @@ func (p *Proxy) ServeTCP(conn WriteCloser) {
 	<-errChan
 }
 
+func (p *Proxy) observeDial(fn func(latency time.Duration, err error)) {
+	p.dialObserver = fn
+}
+
 func (p Proxy) dialBackend() (*net.TCPConn, error) {
 	// Dial using directly the TCPAddr for IP based addresses.
 	if p.tcpAddr != nil {
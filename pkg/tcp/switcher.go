@@ -20,6 +20,12 @@ func (s *HandlerSwitcher) ServeTCP(conn WriteCloser) {
 	}
 }
 
+// GetHandler returns the current TCP handler.
+func (s *HandlerSwitcher) GetHandler() Handler {
+	handler, _ := s.router.Get().(Handler)
+	return handler
+}
+
 // Switch sets the new TCP handler to use for new connections.
 func (s *HandlerSwitcher) Switch(handler Handler) {
 	s.router.Set(handler)
@@ -1,36 +1,113 @@
 package tcp
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/mailgun/ttlmap"
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/ewma"
+	"github.com/traefik/traefik/v2/pkg/locality"
+	"github.com/traefik/traefik/v2/pkg/sticky"
 )
 
+// dialErrorLatency is the latency recorded for a failed dial to a server, for the peak-EWMA strategy.
+const dialErrorLatency = time.Second
+
+// unmeasuredPenalty is the cost of a server with open connections,
+// but without any observed latency yet, for the peak-EWMA strategy.
+const unmeasuredPenalty = float64(math.MaxInt64 >> 16)
+
+// maxAffinityEntries is the maximum number of client IPs remembered by a load balancer with source IP affinity.
+const maxAffinityEntries = 65536
+
+// dialObservable is implemented by the handlers reporting the latency of their dials to the servers.
+type dialObservable interface {
+	observeDial(fn func(latency time.Duration, err error))
+}
+
+var errNoAvailableServer = errors.New("no available server")
+
 type server struct {
 	Handler
+	name   string
 	weight int
+
+	// conns is the number of active connections, only maintained by load-aware strategies.
+	conns   int64
+	latency *ewma.Peak
+
+	// zone and region are the location of the server, for the locality-aware load-balancing.
+	zone   string
+	region string
 }
 
 // WRRLoadBalancer is a naive RoundRobin load balancer for TCP services.
+//
+// When a load-aware strategy is used, the healthy server with the least cost is picked instead,
+// and servers of the same cost are picked in turn.
 type WRRLoadBalancer struct {
-	servers       []server
+	strategy      string
+	servers       []*server
 	lock          sync.Mutex
 	currentWeight int
 	index         int
+
+	// status is a record of which servers of the balancer are healthy, keyed by name.
+	// A server is initially added to the map when it is added to the balancer,
+	// and it is later removed or added to the map as needed, through the SetStatus method.
+	status map[string]struct{}
+	// updaters is the list of hooks that are run (to update the balancer parent(s)),
+	// whenever the balancer status changes.
+	updaters []func(bool)
+
+	// affinity holds the name of the server chosen for a client IP, keyed by client IP.
+	affinity    *ttlmap.TtlMap
+	affinityTTL int // in seconds.
+
+	// locality picks the locality tier of the servers, when the locality-aware load-balancing is enabled.
+	locality *locality.Picker
 }
 
-// NewWRRLoadBalancer creates a new WRRLoadBalancer.
-func NewWRRLoadBalancer() *WRRLoadBalancer {
-	return &WRRLoadBalancer{
-		index: -1,
+// NewWRRLoadBalancer creates a new WRRLoadBalancer, using the given strategy to pick the servers.
+// An empty strategy means the weighted round robin one.
+// When sticky is not nil, the connections of a client IP are forwarded to the same server, as long as it is healthy.
+func NewWRRLoadBalancer(strategy string, sticky *dynamic.TCPSticky) (*WRRLoadBalancer, error) {
+	balancer := &WRRLoadBalancer{
+		strategy: strategy,
+		index:    -1,
+		status:   make(map[string]struct{}),
+	}
+
+	if sticky == nil {
+		return balancer, nil
+	}
+
+	affinity, err := ttlmap.NewConcurrent(maxAffinityEntries)
+	if err != nil {
+		return nil, fmt.Errorf("creating affinity table: %w", err)
+	}
+
+	balancer.affinity = affinity
+	balancer.affinityTTL = int(time.Duration(sticky.Timeout) / time.Second)
+	if balancer.affinityTTL < 1 {
+		balancer.affinityTTL = int(time.Duration(dynamic.DefaultTCPStickyTimeout) / time.Second)
 	}
+
+	return balancer, nil
 }
 
 // ServeTCP forwards the connection to the right service.
 func (b *WRRLoadBalancer) ServeTCP(conn WriteCloser) {
 	b.lock.Lock()
-	next, err := b.next()
+	next, err := b.nextServer(conn)
 	b.lock.Unlock()
 
 	if err != nil {
@@ -39,17 +116,23 @@ func (b *WRRLoadBalancer) ServeTCP(conn WriteCloser) {
 		return
 	}
 
+	if b.loadAware() {
+		atomic.AddInt64(&next.conns, 1)
+		defer atomic.AddInt64(&next.conns, -1)
+	}
+
 	next.ServeTCP(conn)
 }
 
 // AddServer appends a server to the existing list.
-func (b *WRRLoadBalancer) AddServer(serverHandler Handler) {
+func (b *WRRLoadBalancer) AddServer(name string, serverHandler Handler) {
 	w := 1
-	b.AddWeightServer(serverHandler, &w)
+	b.AddWeightServer(name, serverHandler, &w)
 }
 
 // AddWeightServer appends a server to the existing list with a weight.
-func (b *WRRLoadBalancer) AddWeightServer(serverHandler Handler, weight *int) {
+// The server is considered healthy until SetStatus is called with its name.
+func (b *WRRLoadBalancer) AddWeightServer(name string, serverHandler Handler, weight *int) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
@@ -57,22 +140,193 @@ func (b *WRRLoadBalancer) AddWeightServer(serverHandler Handler, weight *int) {
 	if weight != nil {
 		w = *weight
 	}
-	b.servers = append(b.servers, server{Handler: serverHandler, weight: w})
+	srv := &server{Handler: serverHandler, name: name, weight: w, latency: ewma.NewPeak(ewma.DefaultDecay)}
+
+	if observable, ok := serverHandler.(dialObservable); ok && b.strategy == dynamic.BalancerStrategyPeakEWMA {
+		observable.observeDial(func(latency time.Duration, err error) {
+			if err != nil && latency < dialErrorLatency {
+				latency = dialErrorLatency
+			}
+			srv.latency.Observe(latency, time.Now())
+		})
+	}
+
+	b.servers = append(b.servers, srv)
+	b.status[name] = struct{}{}
+}
+
+// SetLocality enables the locality-aware load-balancing, with the given picker.
+func (b *WRRLoadBalancer) SetLocality(picker *locality.Picker) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.locality = picker
+}
+
+// ShareSessions makes the balancer use the affinity table of the given service held by the given tables,
+// so that the affinities are kept when the balancer is rebuilt on a dynamic configuration reload.
+func (b *WRRLoadBalancer) ShareSessions(tables *sticky.Tables, serviceName string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.affinity == nil {
+		return nil
+	}
+
+	affinity, err := tables.Get(serviceName, "affinity", maxAffinityEntries)
+	if err != nil {
+		return fmt.Errorf("getting affinity table: %w", err)
+	}
+
+	b.affinity = affinity
+	return nil
+}
+
+// SetServerLocality sets the zone and region of the given server, for the locality-aware load-balancing.
+func (b *WRRLoadBalancer) SetServerLocality(name, zone, region string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, srv := range b.servers {
+		if srv.name == name {
+			srv.zone = zone
+			srv.region = region
+		}
+	}
+}
+
+// SetStatus sets on the balancer that its given server is now of the given status.
+func (b *WRRLoadBalancer) SetStatus(ctx context.Context, childName string, up bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	upBefore := len(b.status) > 0
+
+	status := "DOWN"
+	if up {
+		status = "UP"
+	}
+
+	log.Ctx(ctx).Debug().Msgf("Setting status of %s to %v", childName, status)
+
+	if up {
+		b.status[childName] = struct{}{}
+	} else {
+		delete(b.status, childName)
+	}
+
+	upAfter := len(b.status) > 0
+
+	// No Status Change
+	if upBefore == upAfter {
+		return
+	}
+
+	for _, fn := range b.updaters {
+		fn(upAfter)
+	}
+}
+
+// RegisterStatusUpdater adds fn to the list of hooks that are run when the status of the balancer changes.
+// Not thread safe.
+func (b *WRRLoadBalancer) RegisterStatusUpdater(fn func(up bool)) error {
+	b.updaters = append(b.updaters, fn)
+	return nil
+}
+
+// nextServer returns the server of the client IP of the given connection, if it is still healthy,
+// or the next healthy server otherwise.
+func (b *WRRLoadBalancer) nextServer(conn WriteCloser) (*server, error) {
+	if b.affinity == nil {
+		return b.next()
+	}
+
+	clientIP, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return nil, fmt.Errorf("parsing client address: %w", err)
+	}
+
+	if name, ok := b.affinity.Get(clientIP); ok {
+		if srv := b.healthyServer(name.(string)); srv != nil {
+			// Refreshes the expiration of the entry.
+			if err := b.affinity.Set(clientIP, srv.name, b.affinityTTL); err != nil {
+				log.Error().Err(err).Msg("Error while saving server affinity")
+			}
+			return srv, nil
+		}
+	}
+
+	srv, err := b.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.affinity.Set(clientIP, srv.name, b.affinityTTL); err != nil {
+		log.Error().Err(err).Msg("Error while saving server affinity")
+	}
+
+	return srv, nil
+}
+
+func (b *WRRLoadBalancer) healthyServer(name string) *server {
+	if _, ok := b.status[name]; !ok {
+		return nil
+	}
+
+	for _, srv := range b.servers {
+		if srv.name == name {
+			return srv
+		}
+	}
+
+	return nil
+}
+
+// eligibility returns the function reporting whether a server can be picked for the next connection.
+// A server can be picked when it is healthy, and, with the locality-aware load-balancing,
+// when it belongs to the locality tier picked for the connection.
+func (b *WRRLoadBalancer) eligibility() func(srv *server) bool {
+	healthy := func(srv *server) bool {
+		_, ok := b.status[srv.name]
+		return ok
+	}
+
+	if b.locality == nil {
+		return healthy
+	}
+
+	var capacities locality.Capacities
+	for _, srv := range b.servers {
+		capacities.Add(b.locality.Tier(srv.zone, srv.region), float64(srv.weight), healthy(srv))
+	}
+
+	tier, ok := b.locality.Pick(capacities)
+	if !ok {
+		return healthy
+	}
+
+	return func(srv *server) bool {
+		return healthy(srv) && b.locality.Tier(srv.zone, srv.region) == tier
+	}
 }
 
-func (b *WRRLoadBalancer) maxWeight() int {
+func (b *WRRLoadBalancer) maxWeight(eligible func(srv *server) bool) int {
 	max := -1
 	for _, s := range b.servers {
-		if s.weight > max {
+		if eligible(s) && s.weight > max {
 			max = s.weight
 		}
 	}
 	return max
 }
 
-func (b *WRRLoadBalancer) weightGcd() int {
+func (b *WRRLoadBalancer) weightGcd(eligible func(srv *server) bool) int {
 	divisor := -1
 	for _, s := range b.servers {
+		if !eligible(s) {
+			continue
+		}
+
 		if divisor == -1 {
 			divisor = s.weight
 		} else {
@@ -89,23 +343,86 @@ func gcd(a, b int) int {
 	return a
 }
 
-func (b *WRRLoadBalancer) next() (Handler, error) {
+func (b *WRRLoadBalancer) loadAware() bool {
+	return b.strategy == dynamic.TCPBalancerStrategyLeastConnections || b.strategy == dynamic.BalancerStrategyPeakEWMA
+}
+
+// leastCostServer returns the eligible server with the least cost, according to the load-aware strategy.
+// Starting the lookup after the last picked server makes the servers of the same cost picked in turn.
+func (b *WRRLoadBalancer) leastCostServer(eligible func(srv *server) bool) (*server, error) {
+	best := -1
+	var minCost float64
+	for i := 1; i <= len(b.servers); i++ {
+		index := (b.index + i) % len(b.servers)
+
+		srv := b.servers[index]
+		if !eligible(srv) || srv.weight <= 0 {
+			continue
+		}
+
+		cost := b.cost(srv)
+		if best == -1 || cost < minCost {
+			best = index
+			minCost = cost
+		}
+	}
+
+	if best == -1 {
+		return nil, errNoAvailableServer
+	}
+
+	b.index = best
+	return b.servers[best], nil
+}
+
+// cost returns the cost of sending a new connection to the given server.
+func (b *WRRLoadBalancer) cost(srv *server) float64 {
+	conns := float64(atomic.LoadInt64(&srv.conns))
+
+	if b.strategy != dynamic.BalancerStrategyPeakEWMA {
+		return (conns + 1) / float64(srv.weight)
+	}
+
+	// Idle servers without any observed latency yet are favored, to be probed,
+	// but not the ones already busy with their first connections.
+	latency := srv.latency.Value()
+	if latency == 0 && conns > 0 {
+		return unmeasuredPenalty
+	}
+
+	return latency * (conns + 1) / float64(srv.weight)
+}
+
+func (b *WRRLoadBalancer) next() (*server, error) {
 	if len(b.servers) == 0 {
 		return nil, fmt.Errorf("no servers in the pool")
 	}
 
+	if len(b.status) == 0 {
+		return nil, errNoAvailableServer
+	}
+
+	eligible := b.eligibility()
+
+	if b.loadAware() {
+		return b.leastCostServer(eligible)
+	}
+
 	// The algo below may look messy, but is actually very simple
 	// it calculates the GCD  and subtracts it on every iteration, what interleaves servers
 	// and allows us not to build an iterator every time we readjust weights
 
 	// Maximum weight across all enabled servers
-	max := b.maxWeight()
+	max := b.maxWeight(eligible)
 	if max == 0 {
 		return nil, fmt.Errorf("all servers have 0 weight")
 	}
+	if max < 0 {
+		return nil, errNoAvailableServer
+	}
 
 	// GCD across all enabled servers
-	gcd := b.weightGcd()
+	gcd := b.weightGcd(eligible)
 
 	for {
 		b.index = (b.index + 1) % len(b.servers)
@@ -116,6 +433,9 @@ func (b *WRRLoadBalancer) next() (Handler, error) {
 			}
 		}
 		srv := b.servers[b.index]
+		if !eligible(srv) {
+			continue
+		}
 		if srv.weight >= b.currentWeight {
 			return srv, nil
 		}
@@ -1,17 +1,25 @@
 package tcp
 
 import (
+	"context"
+	"errors"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/locality"
+	"github.com/traefik/traefik/v2/pkg/sticky"
+	"github.com/traefik/traefik/v2/pkg/types"
 )
 
 type fakeConn struct {
-	writeCall map[string]int
-	closeCall int
+	writeCall  map[string]int
+	closeCall  int
+	remoteAddr net.Addr
 }
 
 func (f *fakeConn) Read(b []byte) (n int, err error) {
@@ -33,7 +41,7 @@ func (f *fakeConn) LocalAddr() net.Addr {
 }
 
 func (f *fakeConn) RemoteAddr() net.Addr {
-	panic("implement me")
+	return f.remoteAddr
 }
 
 func (f *fakeConn) SetDeadline(t time.Time) error {
@@ -56,6 +64,7 @@ func TestLoadBalancing(t *testing.T) {
 	testCases := []struct {
 		desc          string
 		serversWeight map[string]int
+		serversDown   []string
 		totalCall     int
 		expectedWrite map[string]int
 		expectedClose int
@@ -118,6 +127,29 @@ func TestLoadBalancing(t *testing.T) {
 			expectedWrite: map[string]int{},
 			expectedClose: 10,
 		},
+		{
+			desc: "WeighedRoundRobin with one down server",
+			serversWeight: map[string]int{
+				"h1": 3,
+				"h2": 1,
+			},
+			serversDown: []string{"h1"},
+			totalCall:   4,
+			expectedWrite: map[string]int{
+				"h2": 4,
+			},
+		},
+		{
+			desc: "WeighedRoundRobin with all servers down",
+			serversWeight: map[string]int{
+				"h1": 3,
+				"h2": 1,
+			},
+			serversDown:   []string{"h1", "h2"},
+			totalCall:     4,
+			expectedWrite: map[string]int{},
+			expectedClose: 4,
+		},
 	}
 
 	for _, test := range testCases {
@@ -125,15 +157,21 @@ func TestLoadBalancing(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Parallel()
 
-			balancer := NewWRRLoadBalancer()
+			balancer, err := NewWRRLoadBalancer("", nil)
+			require.NoError(t, err)
+
 			for server, weight := range test.serversWeight {
 				server := server
-				balancer.AddWeightServer(HandlerFunc(func(conn WriteCloser) {
+				balancer.AddWeightServer(server, HandlerFunc(func(conn WriteCloser) {
 					_, err := conn.Write([]byte(server))
 					require.NoError(t, err)
 				}), &weight)
 			}
 
+			for _, server := range test.serversDown {
+				balancer.SetStatus(context.Background(), server, false)
+			}
+
 			conn := &fakeConn{writeCall: make(map[string]int)}
 			for i := 0; i < test.totalCall; i++ {
 				balancer.ServeTCP(conn)
@@ -144,3 +182,285 @@ func TestLoadBalancing(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadBalancing_StatusUpdater(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer("", nil)
+	require.NoError(t, err)
+
+	balancer.AddServer("h1", HandlerFunc(func(conn WriteCloser) {}))
+	balancer.AddServer("h2", HandlerFunc(func(conn WriteCloser) {}))
+
+	var updates []bool
+	err = balancer.RegisterStatusUpdater(func(up bool) {
+		updates = append(updates, up)
+	})
+	require.NoError(t, err)
+
+	balancer.SetStatus(context.Background(), "h1", false)
+	assert.Empty(t, updates)
+
+	balancer.SetStatus(context.Background(), "h2", false)
+	assert.Equal(t, []bool{false}, updates)
+
+	balancer.SetStatus(context.Background(), "h1", true)
+	assert.Equal(t, []bool{false, true}, updates)
+}
+
+func TestLoadBalancing_Sticky(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer("", &dynamic.TCPSticky{})
+	require.NoError(t, err)
+
+	for _, server := range []string{"h1", "h2"} {
+		server := server
+		balancer.AddServer(server, HandlerFunc(func(conn WriteCloser) {
+			_, err := conn.Write([]byte(server))
+			require.NoError(t, err)
+		}))
+	}
+
+	client1 := &fakeConn{writeCall: make(map[string]int), remoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}}
+	client1OtherPort := &fakeConn{writeCall: make(map[string]int), remoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4343}}
+	client2 := &fakeConn{writeCall: make(map[string]int), remoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 4242}}
+
+	balancer.ServeTCP(client1)
+	balancer.ServeTCP(client2)
+
+	for i := 0; i < 3; i++ {
+		balancer.ServeTCP(client1OtherPort)
+	}
+
+	require.Len(t, client1.writeCall, 1)
+	require.Len(t, client2.writeCall, 1)
+
+	var first, second string
+	for name := range client1.writeCall {
+		first = name
+	}
+	for name := range client2.writeCall {
+		second = name
+	}
+
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, map[string]int{first: 3}, client1OtherPort.writeCall)
+
+	// When the server of a client goes down, the client is moved to another server, and stays there.
+	balancer.SetStatus(context.Background(), first, false)
+	balancer.ServeTCP(client1)
+
+	balancer.SetStatus(context.Background(), first, true)
+	balancer.ServeTCP(client1)
+
+	assert.Equal(t, map[string]int{first: 1, second: 2}, client1.writeCall)
+}
+
+func TestLoadBalancing_Sticky_sharedSessions(t *testing.T) {
+	tables := sticky.NewTables()
+
+	// newBalancer returns the balancer of the service, as rebuilt on a dynamic configuration reload.
+	newBalancer := func() *WRRLoadBalancer {
+		t.Helper()
+
+		balancer, err := NewWRRLoadBalancer("", &dynamic.TCPSticky{})
+		require.NoError(t, err)
+		require.NoError(t, balancer.ShareSessions(tables, "service"))
+
+		for _, server := range []string{"h1", "h2"} {
+			server := server
+			balancer.AddServer(server, HandlerFunc(func(conn WriteCloser) {
+				_, err := conn.Write([]byte(server))
+				require.NoError(t, err)
+			}))
+		}
+
+		return balancer
+	}
+
+	client1 := &fakeConn{writeCall: make(map[string]int), remoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}}
+	client2 := &fakeConn{writeCall: make(map[string]int), remoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 4242}}
+
+	newBalancer().ServeTCP(client1)
+	require.Len(t, client1.writeCall, 1)
+
+	var first string
+	for name := range client1.writeCall {
+		first = name
+	}
+
+	// The client keeps its server after the rebuild, whatever the connections of the other clients.
+	balancer := newBalancer()
+	balancer.ServeTCP(client2)
+	for i := 0; i < 3; i++ {
+		balancer.ServeTCP(client1)
+	}
+
+	assert.Equal(t, map[string]int{first: 4}, client1.writeCall)
+}
+
+func TestLoadBalancing_LeastConnections(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer(dynamic.TCPBalancerStrategyLeastConnections, nil)
+	require.NoError(t, err)
+
+	entered := make(chan string, 1)
+	release := make(chan struct{})
+	for _, server := range []string{"h1", "h2"} {
+		server := server
+		balancer.AddServer(server, HandlerFunc(func(conn WriteCloser) {
+			if f, ok := conn.(*fakeConn); ok && f.writeCall["block"] > 0 {
+				entered <- server
+				<-release
+				return
+			}
+
+			_, err := conn.Write([]byte(server))
+			require.NoError(t, err)
+		}))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		balancer.ServeTCP(&fakeConn{writeCall: map[string]int{"block": 1}})
+		close(done)
+	}()
+
+	busy := <-entered
+
+	conn := &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 4; i++ {
+		balancer.ServeTCP(conn)
+	}
+
+	close(release)
+	<-done
+
+	assert.Equal(t, 0, conn.writeCall[busy])
+	assert.Len(t, conn.writeCall, 1)
+
+	// Once the connection is closed, the servers are picked in turn.
+	conn = &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 4; i++ {
+		balancer.ServeTCP(conn)
+	}
+
+	assert.Equal(t, map[string]int{"h1": 2, "h2": 2}, conn.writeCall)
+}
+
+func TestLoadBalancing_PeakEWMA(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer(dynamic.BalancerStrategyPeakEWMA, nil)
+	require.NoError(t, err)
+
+	for _, server := range []string{"fast", "slow"} {
+		server := server
+		balancer.AddServer(server, HandlerFunc(func(conn WriteCloser) {
+			_, err := conn.Write([]byte(server))
+			require.NoError(t, err)
+		}))
+	}
+
+	now := time.Now()
+	for _, srv := range balancer.servers {
+		latency := time.Millisecond
+		if srv.name == "slow" {
+			latency = 100 * time.Millisecond
+		}
+		srv.latency.Observe(latency, now)
+	}
+
+	conn := &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 4; i++ {
+		balancer.ServeTCP(conn)
+	}
+
+	assert.Equal(t, map[string]int{"fast": 4}, conn.writeCall)
+}
+
+func TestLoadBalancing_PeakEWMA_unmeasured(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer(dynamic.BalancerStrategyPeakEWMA, nil)
+	require.NoError(t, err)
+
+	for _, server := range []string{"measured", "unmeasured"} {
+		server := server
+		balancer.AddServer(server, HandlerFunc(func(conn WriteCloser) {
+			_, err := conn.Write([]byte(server))
+			require.NoError(t, err)
+		}))
+	}
+
+	for _, srv := range balancer.servers {
+		if srv.name == "measured" {
+			srv.latency.Observe(100*time.Millisecond, time.Now())
+			continue
+		}
+
+		// The server is busy with its first connection, which is not over yet.
+		atomic.StoreInt64(&srv.conns, 1)
+	}
+
+	conn := &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 4; i++ {
+		balancer.ServeTCP(conn)
+	}
+
+	assert.Equal(t, map[string]int{"measured": 4}, conn.writeCall)
+}
+
+func TestLoadBalancing_PeakEWMA_dialObserver(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer(dynamic.BalancerStrategyPeakEWMA, nil)
+	require.NoError(t, err)
+
+	proxy, err := NewProxy("127.0.0.1:1", 0, nil)
+	require.NoError(t, err)
+
+	balancer.AddServer("proxy", proxy)
+
+	require.NotNil(t, proxy.dialObserver)
+
+	proxy.dialObserver(time.Millisecond, errors.New("connection refused"))
+	assert.InDelta(t, float64(dialErrorLatency), balancer.servers[0].latency.Value(), 1)
+}
+
+func TestLoadBalancing_Locality(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer("", nil)
+	require.NoError(t, err)
+
+	balancer.SetLocality(locality.NewPicker(&dynamic.Locality{MinHealthyPercent: 50}, &types.Locality{Zone: "a", Region: "eu"}))
+
+	servers := map[string][2]string{
+		"local1": {"a", "eu"},
+		"local2": {"a", "eu"},
+		"region": {"b", "eu"},
+	}
+
+	for name, location := range servers {
+		name := name
+		balancer.AddServer(name, HandlerFunc(func(conn WriteCloser) {
+			_, err := conn.Write([]byte(name))
+			require.NoError(t, err)
+		}))
+		balancer.SetServerLocality(name, location[0], location[1])
+	}
+
+	conn := &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 4; i++ {
+		balancer.ServeTCP(conn)
+	}
+
+	assert.Equal(t, map[string]int{"local1": 2, "local2": 2}, conn.writeCall)
+
+	balancer.SetStatus(context.Background(), "local1", false)
+
+	conn = &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 4; i++ {
+		balancer.ServeTCP(conn)
+	}
+
+	assert.Equal(t, map[string]int{"local2": 4}, conn.writeCall)
+
+	balancer.SetStatus(context.Background(), "local2", false)
+
+	conn = &fakeConn{writeCall: make(map[string]int)}
+	for i := 0; i < 4; i++ {
+		balancer.ServeTCP(conn)
+	}
+
+	assert.Equal(t, map[string]int{"region": 4}, conn.writeCall)
+}
@@ -0,0 +1,7 @@
+package types
+
+// Locality holds the location of this Traefik instance, used by the locality-aware load-balancing.
+type Locality struct {
+	Zone   string `description:"Zone of this Traefik instance (e.g. the availability zone)." json:"zone,omitempty" toml:"zone,omitempty" yaml:"zone,omitempty" export:"true"`
+	Region string `description:"Region of this Traefik instance." json:"region,omitempty" toml:"region,omitempty" yaml:"region,omitempty" export:"true"`
+}
@@ -202,11 +202,26 @@ type Conn struct {
 	muActivity   sync.RWMutex
 	lastActivity time.Time // the last time the session saw either read or write activity
 
+	// readFilters decide whether each datagram read from the session is delivered.
+	readFilters []func() bool
+
 	timeout  time.Duration // for timeouts
 	doneOnce sync.Once
 	doneCh   chan struct{}
 }
 
+// RemoteAddr returns the remote network address of the session.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.rAddr
+}
+
+// AddReadFilter registers a filter called for each datagram read from the session,
+// which is silently discarded if the filter returns false.
+// It must be called before the session is read from, typically by a middleware.
+func (c *Conn) AddReadFilter(filter func() bool) {
+	c.readFilters = append(c.readFilters, filter)
+}
+
 // readLoop waits for data to come from the listener's readLoop.
 // It then waits for a Read operation to be ready to consume said data,
 // that is to say it waits on readCh to receive the slice of bytes that the Read operation wants to read onto.
@@ -256,19 +271,37 @@ func (c *Conn) readLoop() {
 // Each call corresponds to at most one datagram.
 // If p is smaller than the datagram, the extra bytes will be discarded.
 func (c *Conn) Read(p []byte) (int, error) {
-	select {
-	case c.readCh <- p:
-		n := <-c.sizeCh
-		c.muActivity.Lock()
-		c.lastActivity = time.Now()
-		c.muActivity.Unlock()
-		return n, nil
-
-	case <-c.doneCh:
-		return 0, io.EOF
+	for {
+		select {
+		case c.readCh <- p:
+			n := <-c.sizeCh
+			c.muActivity.Lock()
+			c.lastActivity = time.Now()
+			c.muActivity.Unlock()
+
+			if !c.accept() {
+				continue
+			}
+
+			return n, nil
+
+		case <-c.doneCh:
+			return 0, io.EOF
+		}
 	}
 }
 
+// accept reports whether all the read filters accept the datagram.
+func (c *Conn) accept() bool {
+	for _, filter := range c.readFilters {
+		if !filter() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Write writes len(p) bytes from p to the underlying connection.
 // Each call sends at most one datagram.
 // It is an error to send a message larger than the system's max UDP datagram size.
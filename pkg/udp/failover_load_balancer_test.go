@@ -0,0 +1,68 @@
+package udp
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailoverLoadBalancer(t *testing.T) {
+	balancer := NewFailoverLoadBalancer(0)
+
+	var updates []bool
+	err := balancer.RegisterStatusUpdater(func(up bool) {
+		updates = append(updates, up)
+	})
+	require.NoError(t, err)
+
+	calls := make(map[string]int)
+
+	primary := balancer.AddTier(2)
+	secondary := balancer.AddTier(1)
+
+	for tier, servers := range map[int][]string{primary: {"p1", "p2"}, secondary: {"s1"}} {
+		for _, server := range servers {
+			server := server
+			balancer.AddWeightedServer(tier, server, HandlerFunc(func(*Conn) {
+				calls[server]++
+			}), nil)
+		}
+	}
+
+	conn := &Conn{rAddr: &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}}
+
+	for i := 0; i < 4; i++ {
+		balancer.ServeUDP(conn)
+	}
+	assert.Equal(t, map[string]int{"p1": 2, "p2": 2}, calls)
+
+	// The primary tier is below its minimum of healthy servers.
+	balancer.SetStatus(context.Background(), primary, "p1", false)
+
+	calls = make(map[string]int)
+	for i := 0; i < 2; i++ {
+		balancer.ServeUDP(conn)
+	}
+	assert.Equal(t, map[string]int{"s1": 2}, calls)
+
+	balancer.SetStatus(context.Background(), secondary, "s1", false)
+	assert.Empty(t, updates)
+
+	// The primary tier is the only one with a healthy server.
+	calls = make(map[string]int)
+	balancer.ServeUDP(conn)
+	assert.Equal(t, map[string]int{"p2": 1}, calls)
+
+	balancer.SetStatus(context.Background(), primary, "p2", false)
+	assert.Equal(t, []bool{false}, updates)
+
+	balancer.SetStatus(context.Background(), secondary, "s1", true)
+	assert.Equal(t, []bool{false, true}, updates)
+
+	calls = make(map[string]int)
+	balancer.ServeUDP(conn)
+	assert.Equal(t, map[string]int{"s1": 1}, calls)
+}
@@ -1,14 +1,27 @@
 package udp
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"sync"
+	"time"
 
+	"github.com/mailgun/ttlmap"
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/sticky"
 )
 
+// maxAffinityEntries is the maximum number of client IPs remembered by a load balancer with source IP affinity.
+const maxAffinityEntries = 65536
+
+var errNoAvailableServer = errors.New("no available server")
+
 type server struct {
 	Handler
+	name   string
 	weight int
 }
 
@@ -18,19 +31,69 @@ type WRRLoadBalancer struct {
 	lock          sync.Mutex
 	currentWeight int
 	index         int
+
+	// status is a record of which servers of the balancer are healthy, keyed by name.
+	// A server is initially added to the map when it is added to the balancer,
+	// and it is later removed or added to the map as needed, through the SetStatus method.
+	status map[string]struct{}
+	// updaters is the list of hooks that are run (to update the balancer parent(s)),
+	// whenever the balancer status changes.
+	updaters []func(bool)
+
+	// affinity holds the name of the server chosen for a client IP, keyed by client IP.
+	affinity    *ttlmap.TtlMap
+	affinityTTL int // in seconds.
 }
 
 // NewWRRLoadBalancer creates a new WRRLoadBalancer.
-func NewWRRLoadBalancer() *WRRLoadBalancer {
-	return &WRRLoadBalancer{
-		index: -1,
+// When sticky is not nil, the sessions of a client IP are forwarded to the same server, as long as it is healthy.
+func NewWRRLoadBalancer(sticky *dynamic.UDPSticky) (*WRRLoadBalancer, error) {
+	balancer := &WRRLoadBalancer{
+		index:  -1,
+		status: make(map[string]struct{}),
+	}
+
+	if sticky == nil {
+		return balancer, nil
+	}
+
+	affinity, err := ttlmap.NewConcurrent(maxAffinityEntries)
+	if err != nil {
+		return nil, fmt.Errorf("creating affinity table: %w", err)
 	}
+
+	balancer.affinity = affinity
+	balancer.affinityTTL = int(time.Duration(sticky.Timeout) / time.Second)
+	if balancer.affinityTTL < 1 {
+		balancer.affinityTTL = int(time.Duration(dynamic.DefaultUDPStickyTimeout) / time.Second)
+	}
+
+	return balancer, nil
+}
+
+// ShareSessions makes the balancer use the affinity table of the given service held by the given tables,
+// so that the affinities are kept when the balancer is rebuilt on a dynamic configuration reload.
+func (b *WRRLoadBalancer) ShareSessions(tables *sticky.Tables, serviceName string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.affinity == nil {
+		return nil
+	}
+
+	affinity, err := tables.Get(serviceName, "affinity", maxAffinityEntries)
+	if err != nil {
+		return fmt.Errorf("getting affinity table: %w", err)
+	}
+
+	b.affinity = affinity
+	return nil
 }
 
 // ServeUDP forwards the connection to the right service.
 func (b *WRRLoadBalancer) ServeUDP(conn *Conn) {
 	b.lock.Lock()
-	next, err := b.next()
+	next, err := b.nextServer(conn)
 	b.lock.Unlock()
 
 	if err != nil {
@@ -43,13 +106,14 @@ func (b *WRRLoadBalancer) ServeUDP(conn *Conn) {
 }
 
 // AddServer appends a handler to the existing list.
-func (b *WRRLoadBalancer) AddServer(serverHandler Handler) {
+func (b *WRRLoadBalancer) AddServer(name string, serverHandler Handler) {
 	w := 1
-	b.AddWeightedServer(serverHandler, &w)
+	b.AddWeightedServer(name, serverHandler, &w)
 }
 
 // AddWeightedServer appends a handler to the existing list with a weight.
-func (b *WRRLoadBalancer) AddWeightedServer(serverHandler Handler, weight *int) {
+// The server is considered healthy until SetStatus is called with its name.
+func (b *WRRLoadBalancer) AddWeightedServer(name string, serverHandler Handler, weight *int) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
@@ -57,13 +121,101 @@ func (b *WRRLoadBalancer) AddWeightedServer(serverHandler Handler, weight *int)
 	if weight != nil {
 		w = *weight
 	}
-	b.servers = append(b.servers, server{Handler: serverHandler, weight: w})
+	b.servers = append(b.servers, server{Handler: serverHandler, name: name, weight: w})
+	b.status[name] = struct{}{}
+}
+
+// SetStatus sets on the balancer that its given server is now of the given status.
+func (b *WRRLoadBalancer) SetStatus(ctx context.Context, childName string, up bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	upBefore := len(b.status) > 0
+
+	status := "DOWN"
+	if up {
+		status = "UP"
+	}
+
+	log.Ctx(ctx).Debug().Msgf("Setting status of %s to %v", childName, status)
+
+	if up {
+		b.status[childName] = struct{}{}
+	} else {
+		delete(b.status, childName)
+	}
+
+	upAfter := len(b.status) > 0
+
+	// No Status Change
+	if upBefore == upAfter {
+		return
+	}
+
+	for _, fn := range b.updaters {
+		fn(upAfter)
+	}
+}
+
+// RegisterStatusUpdater adds fn to the list of hooks that are run when the status of the balancer changes.
+// Not thread safe.
+func (b *WRRLoadBalancer) RegisterStatusUpdater(fn func(up bool)) error {
+	b.updaters = append(b.updaters, fn)
+	return nil
+}
+
+// nextServer returns the server of the client IP of the given connection, if it is still healthy,
+// or the next healthy server otherwise.
+func (b *WRRLoadBalancer) nextServer(conn *Conn) (Handler, error) {
+	if b.affinity == nil {
+		return b.next()
+	}
+
+	clientIP, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return nil, fmt.Errorf("parsing client address: %w", err)
+	}
+
+	if name, ok := b.affinity.Get(clientIP); ok {
+		if srv, found := b.healthyServer(name.(string)); found {
+			// Refreshes the expiration of the entry.
+			if err := b.affinity.Set(clientIP, srv.name, b.affinityTTL); err != nil {
+				log.Error().Err(err).Msg("Error while saving server affinity")
+			}
+			return srv, nil
+		}
+	}
+
+	srv, err := b.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.affinity.Set(clientIP, srv.name, b.affinityTTL); err != nil {
+		log.Error().Err(err).Msg("Error while saving server affinity")
+	}
+
+	return srv, nil
+}
+
+func (b *WRRLoadBalancer) healthyServer(name string) (server, bool) {
+	if _, ok := b.status[name]; !ok {
+		return server{}, false
+	}
+
+	for _, srv := range b.servers {
+		if srv.name == name {
+			return srv, true
+		}
+	}
+
+	return server{}, false
 }
 
 func (b *WRRLoadBalancer) maxWeight() int {
 	max := -1
 	for _, s := range b.servers {
-		if s.weight > max {
+		if _, ok := b.status[s.name]; ok && s.weight > max {
 			max = s.weight
 		}
 	}
@@ -73,6 +225,10 @@ func (b *WRRLoadBalancer) maxWeight() int {
 func (b *WRRLoadBalancer) weightGcd() int {
 	divisor := -1
 	for _, s := range b.servers {
+		if _, ok := b.status[s.name]; !ok {
+			continue
+		}
+
 		if divisor == -1 {
 			divisor = s.weight
 		} else {
@@ -89,9 +245,13 @@ func gcd(a, b int) int {
 	return a
 }
 
-func (b *WRRLoadBalancer) next() (Handler, error) {
+func (b *WRRLoadBalancer) next() (server, error) {
 	if len(b.servers) == 0 {
-		return nil, fmt.Errorf("no servers in the pool")
+		return server{}, fmt.Errorf("no servers in the pool")
+	}
+
+	if len(b.status) == 0 {
+		return server{}, errNoAvailableServer
 	}
 
 	// The algorithm below may look messy,
@@ -101,7 +261,10 @@ func (b *WRRLoadBalancer) next() (Handler, error) {
 	// Maximum weight across all enabled servers
 	max := b.maxWeight()
 	if max == 0 {
-		return nil, fmt.Errorf("all servers have 0 weight")
+		return server{}, fmt.Errorf("all servers have 0 weight")
+	}
+	if max < 0 {
+		return server{}, errNoAvailableServer
 	}
 
 	// GCD across all enabled servers
@@ -116,6 +279,9 @@ func (b *WRRLoadBalancer) next() (Handler, error) {
 			}
 		}
 		srv := b.servers[b.index]
+		if _, ok := b.status[srv.name]; !ok {
+			continue
+		}
 		if srv.weight >= b.currentWeight {
 			return srv, nil
 		}
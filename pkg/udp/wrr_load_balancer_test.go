@@ -0,0 +1,212 @@
+package udp
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/dynamic"
+	"github.com/traefik/traefik/v2/pkg/sticky"
+)
+
+func TestWRRLoadBalancer_Weights(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		serversWeight map[string]int
+		serversDown   []string
+		totalCall     int
+		expected      map[string]int
+		expectedErr   bool
+	}{
+		{
+			desc: "RoundRobin",
+			serversWeight: map[string]int{
+				"h1": 1,
+				"h2": 1,
+			},
+			totalCall: 4,
+			expected: map[string]int{
+				"h1": 2,
+				"h2": 2,
+			},
+		},
+		{
+			desc: "WeighedRoundRobin",
+			serversWeight: map[string]int{
+				"h1": 3,
+				"h2": 1,
+			},
+			totalCall: 4,
+			expected: map[string]int{
+				"h1": 3,
+				"h2": 1,
+			},
+		},
+		{
+			desc: "down server is skipped",
+			serversWeight: map[string]int{
+				"h1": 3,
+				"h2": 1,
+			},
+			serversDown: []string{"h1"},
+			totalCall:   4,
+			expected: map[string]int{
+				"h2": 4,
+			},
+		},
+		{
+			desc: "all servers down",
+			serversWeight: map[string]int{
+				"h1": 1,
+				"h2": 1,
+			},
+			serversDown: []string{"h1", "h2"},
+			totalCall:   1,
+			expectedErr: true,
+		},
+		{
+			desc: "only server with 0 weight up",
+			serversWeight: map[string]int{
+				"h1": 1,
+				"h2": 0,
+			},
+			serversDown: []string{"h1"},
+			totalCall:   1,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			balancer, err := NewWRRLoadBalancer(nil)
+			require.NoError(t, err)
+
+			for name, weight := range test.serversWeight {
+				w := weight
+				balancer.AddWeightedServer(name, HandlerFunc(func(*Conn) {}), &w)
+			}
+
+			for _, name := range test.serversDown {
+				balancer.SetStatus(context.Background(), name, false)
+			}
+
+			conn := &Conn{rAddr: &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}}
+
+			calls := make(map[string]int)
+			for i := 0; i < test.totalCall; i++ {
+				srv, err := balancer.nextServer(conn)
+				if test.expectedErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+
+				calls[srv.(server).name]++
+			}
+
+			assert.Equal(t, test.expected, calls)
+		})
+	}
+}
+
+func TestWRRLoadBalancer_Sticky(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer(&dynamic.UDPSticky{})
+	require.NoError(t, err)
+
+	balancer.AddServer("h1", HandlerFunc(func(*Conn) {}))
+	balancer.AddServer("h2", HandlerFunc(func(*Conn) {}))
+
+	client1 := &Conn{rAddr: &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}}
+	client1OtherPort := &Conn{rAddr: &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4343}}
+	client2 := &Conn{rAddr: &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 4242}}
+
+	first, err := balancer.nextServer(client1)
+	require.NoError(t, err)
+
+	second, err := balancer.nextServer(client2)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.(server).name, second.(server).name)
+
+	for i := 0; i < 3; i++ {
+		srv, err := balancer.nextServer(client1OtherPort)
+		require.NoError(t, err)
+		assert.Equal(t, first.(server).name, srv.(server).name)
+	}
+
+	// When the server of a client goes down, the client is moved to another server, and stays there.
+	balancer.SetStatus(context.Background(), first.(server).name, false)
+
+	srv, err := balancer.nextServer(client1)
+	require.NoError(t, err)
+	assert.Equal(t, second.(server).name, srv.(server).name)
+
+	balancer.SetStatus(context.Background(), first.(server).name, true)
+
+	srv, err = balancer.nextServer(client1)
+	require.NoError(t, err)
+	assert.Equal(t, second.(server).name, srv.(server).name)
+}
+
+func TestWRRLoadBalancer_Sticky_sharedSessions(t *testing.T) {
+	tables := sticky.NewTables()
+
+	// newBalancer returns the balancer of the service, as rebuilt on a dynamic configuration reload.
+	newBalancer := func() *WRRLoadBalancer {
+		t.Helper()
+
+		balancer, err := NewWRRLoadBalancer(&dynamic.UDPSticky{})
+		require.NoError(t, err)
+		require.NoError(t, balancer.ShareSessions(tables, "service"))
+
+		balancer.AddServer("h1", HandlerFunc(func(*Conn) {}))
+		balancer.AddServer("h2", HandlerFunc(func(*Conn) {}))
+
+		return balancer
+	}
+
+	client1 := &Conn{rAddr: &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4242}}
+	client2 := &Conn{rAddr: &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 4242}}
+
+	first, err := newBalancer().nextServer(client1)
+	require.NoError(t, err)
+
+	// The client keeps its server after the rebuild, whatever the sessions of the other clients.
+	balancer := newBalancer()
+
+	_, err = balancer.nextServer(client2)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		srv, err := balancer.nextServer(client1)
+		require.NoError(t, err)
+		assert.Equal(t, first.(server).name, srv.(server).name)
+	}
+}
+
+func TestWRRLoadBalancer_StatusUpdater(t *testing.T) {
+	balancer, err := NewWRRLoadBalancer(nil)
+	require.NoError(t, err)
+
+	balancer.AddServer("h1", HandlerFunc(func(*Conn) {}))
+	balancer.AddServer("h2", HandlerFunc(func(*Conn) {}))
+
+	var updates []bool
+	err = balancer.RegisterStatusUpdater(func(up bool) {
+		updates = append(updates, up)
+	})
+	require.NoError(t, err)
+
+	balancer.SetStatus(context.Background(), "h1", false)
+	assert.Empty(t, updates)
+
+	balancer.SetStatus(context.Background(), "h2", false)
+	assert.Equal(t, []bool{false}, updates)
+
+	balancer.SetStatus(context.Background(), "h2", true)
+	assert.Equal(t, []bool{false, true}, updates)
+}